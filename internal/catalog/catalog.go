@@ -0,0 +1,232 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDir é a pasta dos tipos de mensagem do catálogo
+const DefaultDir = "catalog"
+
+// MessageType é um tipo de mensagem do catálogo, descrito em um arquivo YAML.
+//
+// Exemplo (catalog/pix-recurrence.yaml):
+//
+//	description: Falha de ordem de pagamento do PIX Recorrente
+//	targets: [sbq.pix.recurrence.payment.order.failure]
+//	subject: pix.recurrence.payment.order
+//	properties: {eventType: pix.recurrence.payment.order.failure}
+//	vars: {amount: "100.50"}
+//	template: |
+//	  {"orderId": "{{uuid}}", "amount": {{.amount}}}
+type MessageType struct {
+	// Name é o nome do tipo; por padrão, o nome do arquivo sem extensão
+	Name        string `yaml:"name,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// Targets são as filas ou tópicos aceitos (padrões como sbq.pismo.* são permitidos); vazio aceita qualquer destino
+	Targets []string `yaml:"targets,omitempty"`
+	// Template é o JSON da mensagem, com expressões de template; alternativa a TemplateFile
+	Template string `yaml:"template,omitempty"`
+	// TemplateFile é uma fixture da pasta messages usada como template
+	TemplateFile string                 `yaml:"templateFile,omitempty"`
+	Subject      string                 `yaml:"subject,omitempty"`
+	ContentType  string                 `yaml:"contentType,omitempty"`
+	Properties   map[string]interface{} `yaml:"properties,omitempty"`
+	// Vars são os valores padrão das variáveis do template, sobrescritos por --var
+	Vars map[string]string `yaml:"vars,omitempty"`
+
+	// Path é o arquivo de onde o tipo foi carregado (vazio para o tipo embutido)
+	Path string `yaml:"-"`
+}
+
+// Catalog é o conjunto de tipos de mensagem, ordenado por nome
+type Catalog struct {
+	types []*MessageType
+}
+
+// Simple é o tipo usado quando o catálogo não declara "simple": uma mensagem de teste aceita por qualquer destino
+var Simple = MessageType{
+	Name:        "simple",
+	Description: "Mensagem de teste simples, aceita por qualquer fila ou tópico",
+	Template: `{
+  "type": "test",
+  "timestamp": "{{now}}",
+  "data": {
+    "message": "Mensagem de teste simples",
+    "id": "{{uuid}}",
+    "target": "{{.target}}"
+  }
+}`,
+}
+
+// Load lê os arquivos .yaml e .yml da pasta. Uma pasta inexistente resulta em um catálogo apenas com o tipo simple.
+func Load(dir string) (*Catalog, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler catálogo: %w", err)
+	}
+
+	var types []*MessageType
+	var errs []error
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("erro ao ler %s: %w", file, err))
+			continue
+		}
+		messageType, err := Parse(data, strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		messageType.Path = file
+		types = append(types, messageType)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return New(types...)
+}
+
+// Parse interpreta um tipo de mensagem em YAML; defaultName é usado quando o arquivo não declara name
+func Parse(data []byte, defaultName string) (*MessageType, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var messageType MessageType
+	if err := decoder.Decode(&messageType); err != nil {
+		return nil, fmt.Errorf("tipo de mensagem inválido: %w", err)
+	}
+	if messageType.Name == "" {
+		messageType.Name = defaultName
+	}
+	if err := messageType.Validate(); err != nil {
+		return nil, err
+	}
+	return &messageType, nil
+}
+
+// New monta o catálogo, rejeitando nomes repetidos e incluindo o tipo simple se ele não for declarado
+func New(types ...*MessageType) (*Catalog, error) {
+	seen := make(map[string]string)
+	for _, messageType := range types {
+		if previous, ok := seen[messageType.Name]; ok {
+			return nil, fmt.Errorf("tipo '%s' declarado em %s e %s", messageType.Name, previous, messageType.Path)
+		}
+		seen[messageType.Name] = messageType.Path
+	}
+	if _, ok := seen[Simple.Name]; !ok {
+		simple := Simple
+		types = append(types, &simple)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return &Catalog{types: types}, nil
+}
+
+// Validate verifica nome, template e padrões de destino
+func (t *MessageType) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("campo 'name' é obrigatório")
+	}
+	if strings.ContainsAny(t.Name, " /") {
+		return fmt.Errorf("nome '%s' inválido: não use espaços nem barras", t.Name)
+	}
+	if (t.Template == "") == (t.TemplateFile == "") {
+		return fmt.Errorf("tipo '%s': informe 'template' ou 'templateFile'", t.Name)
+	}
+	for _, target := range t.Targets {
+		if _, err := path.Match(target, ""); err != nil {
+			return fmt.Errorf("tipo '%s': destino '%s' inválido: %w", t.Name, target, err)
+		}
+	}
+	return nil
+}
+
+// Supports indica se o tipo pode ser enviado para a fila ou tópico
+func (t *MessageType) Supports(target string) bool {
+	if len(t.Targets) == 0 {
+		return true
+	}
+	for _, pattern := range t.Targets {
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetsDescription descreve os destinos aceitos pelo tipo
+func (t *MessageType) TargetsDescription() string {
+	if len(t.Targets) == 0 {
+		return "qualquer destino"
+	}
+	return strings.Join(t.Targets, ", ")
+}
+
+// Source retorna o nome e o conteúdo do template; TemplateFile é procurado em messagesDir
+func (t *MessageType) Source(messagesDir string) (string, []byte, error) {
+	if t.TemplateFile == "" {
+		name := t.Path
+		if name == "" {
+			name = t.Name
+		}
+		return name, []byte(t.Template), nil
+	}
+
+	file := filepath.Join(messagesDir, t.TemplateFile)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("tipo '%s': template não encontrado: %s", t.Name, file)
+	}
+	return file, data, nil
+}
+
+// All retorna todos os tipos, ordenados por nome
+func (c *Catalog) All() []*MessageType {
+	return c.types
+}
+
+// Get procura um tipo pelo nome
+func (c *Catalog) Get(name string) (*MessageType, bool) {
+	for _, messageType := range c.types {
+		if messageType.Name == name {
+			return messageType, true
+		}
+	}
+	return nil, false
+}
+
+// ForTarget retorna os tipos compatíveis com a fila ou tópico
+func (c *Catalog) ForTarget(target string) []*MessageType {
+	var types []*MessageType
+	for _, messageType := range c.types {
+		if messageType.Supports(target) {
+			types = append(types, messageType)
+		}
+	}
+	return types
+}
+
+// Names retorna os nomes dos tipos informados
+func Names(types []*MessageType) []string {
+	names := make([]string, 0, len(types))
+	for _, messageType := range types {
+		names = append(names, messageType.Name)
+	}
+	return names
+}
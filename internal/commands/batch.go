@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"fin.orion.dev/internal/fixture"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para enviar mensagens em lote
+var pushBatchCmd = &cobra.Command{
+	Use:   "push-batch [queue] [dir|file.ndjson]",
+	Short: "Enviar mensagens em lote para fila",
+	Long: `Envia em lote todos os arquivos JSON de um diretório ou todas as linhas de um
+arquivo NDJSON, usando lotes do Service Bus divididos automaticamente pelo
+limite de tamanho. Caminhos relativos também são procurados na pasta messages.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPushBatch,
+}
+
+func runPushBatch(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	source := args[1]
+
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	// Validar nome da fila
+	if !isValidQueue(queueName) {
+		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
+		return fmt.Errorf("fila inválida")
+	}
+
+	repeat, _ := cmd.Flags().GetInt("repeat")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if repeat <= 0 {
+		return fmt.Errorf("--repeat deve ser maior que zero")
+	}
+
+	_, _ = blue.Printf("📦 Carregando mensagens de '%s'...\n", source)
+	path, err := resolveMessagePath(source)
+	if err != nil {
+		return err
+	}
+	sources, err := fixture.LoadBatch(path)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("nenhuma mensagem encontrada em '%s'", source)
+	}
+	vars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Montar a lista final renderizando cada fixture em cada repetição, para que uuid, seq e
+	// os valores aleatórios mudem de uma rodada para a outra
+	started := time.Now().Unix()
+	var messages []*servicebus.Message
+	var origins []string
+	var rendered [][]byte
+	messageIDs := make(map[string]bool)
+	for round := 0; round < repeat; round++ {
+		for _, item := range sources {
+			data, err := renderTemplate(item.Origin, item.Data, vars, sets)
+			if err != nil {
+				return fmt.Errorf("%s: %w", item.Origin, err)
+			}
+			message, err := parseMessage(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", item.Origin, err)
+			}
+
+			index := len(messages)
+			// Um MessageID fixo repetido seria descartado pela detecção de duplicadas
+			if message.MessageID == "" || messageIDs[message.MessageID] {
+				message.MessageID = fmt.Sprintf("batch-%d-%d", started, index)
+			}
+			messageIDs[message.MessageID] = true
+			if message.CorrelationID == "" {
+				message.CorrelationID = fmt.Sprintf("corr-%d-%d", started, index)
+			}
+			if err := applySendFlags(cmd, message); err != nil {
+				return err
+			}
+
+			messages = append(messages, message)
+			origins = append(origins, item.Origin)
+			rendered = append(rendered, data)
+		}
+	}
+
+	// Cada mensagem renderizada é validada, já que os valores mudam a cada repetição
+	if err := checkMessagesSchema(cmd, queueName, origins, rendered); err != nil {
+		return err
+	}
+	_, _ = green.Printf("✅ %d mensagem(ns) carregada(s)\n", len(messages))
+	fmt.Println()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	_, _ = blue.Printf("📤 Enviando para a fila '%s'...\n", queueName)
+	start := time.Now()
+	results, err := client.SendMessageBatch(cmd.Context(), queueName, messages)
+	elapsed := time.Since(start)
+
+	sent, failed, batches := 0, 0, 0
+	for _, result := range results {
+		if result.Batch > batches {
+			batches = result.Batch
+		}
+		if result.Batch == 0 && result.Err == nil {
+			// Mensagem não chegou a um lote porque o envio foi interrompido
+			continue
+		}
+		if result.Err != nil {
+			failed++
+			_, _ = red.Printf("  ❌ [%d] %s (%s): %v\n", result.Index+1, result.MessageID, origins[result.Index], result.Err)
+			continue
+		}
+		sent++
+		if !quiet {
+			_, _ = green.Printf("  ✅ [%d] %s (%s, lote %d)\n", result.Index+1, result.MessageID, origins[result.Index], result.Batch)
+		}
+	}
+
+	if err != nil {
+		// Mostrar o que já foi enviado antes de interromper
+		_, _ = yellow.Printf("⚠️  Envio interrompido: %d enviada(s), %d falha(s), %d não enviada(s)\n", sent, failed, len(messages)-sent-failed)
+		return fmt.Errorf("erro ao enviar lote: %w", err)
+	}
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(sent) / elapsed.Seconds()
+	}
+
+	fmt.Println()
+	_, _ = blue.Println("📊 Resumo:")
+	fmt.Printf("  Total:     %d\n", len(results))
+	fmt.Printf("  Enviadas:  %d\n", sent)
+	fmt.Printf("  Falhas:    %d\n", failed)
+	fmt.Printf("  Lotes:     %d\n", batches)
+	fmt.Printf("  Duração:   %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("  Taxa:      %.1f msg/s\n", rate)
+
+	if failed > 0 {
+		_, _ = yellow.Printf("⚠️  %d mensagem(ns) não foram enviadas\n", failed)
+		return fmt.Errorf("%d mensagem(ns) falharam", failed)
+	}
+
+	_, _ = green.Println("✅ Lote enviado com sucesso!")
+	return nil
+}
+
+// resolveMessagePath procura o caminho informado e, se não existir, dentro da pasta messages
+func resolveMessagePath(path string) (string, error) {
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	candidate := filepath.Join("messages", path)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate, nil
+	}
+
+	return "", fmt.Errorf("arquivo ou diretório não encontrado: %s", path)
+}
+
+func init() {
+	pushBatchCmd.Flags().Int("repeat", 1, "Repetir o conjunto de mensagens N vezes")
+	pushBatchCmd.Flags().String("session", "", "SessionID aplicado a todas as mensagens")
+	pushBatchCmd.Flags().StringArray("property", nil, "Propriedade de aplicação aplicada a todas as mensagens (chave=valor)")
+	pushBatchCmd.Flags().BoolP("quiet", "q", false, "Mostrar apenas falhas e o resumo")
+	addTemplateFlags(pushBatchCmd)
+	addSchemaFlags(pushBatchCmd)
+
+	pushBatchCmd.ValidArgsFunction = completeQueues
+}
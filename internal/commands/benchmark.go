@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fin.orion.dev/internal/catalog"
+	"fin.orion.dev/internal/load"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para medir a vazão de envio
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark [queue]",
+	Short: "Medir a vazão de envio para uma fila",
+	Long: `Envia mensagens para uma fila comparando o cliente com e sem cache de senders
+e as duas portas do emulador: AMQP direto (5672) e AMQPS pelo proxy TLS (5671).
+
+As mensagens enviadas permanecem na fila ao final do benchmark.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBenchmark,
+}
+
+// benchmarkScenario descreve uma combinação de transporte e cache
+type benchmarkScenario struct {
+	name      string
+	transport string
+	cache     bool
+}
+
+// benchmarkResult contém as medições de um cenário
+type benchmarkResult struct {
+	scenario  benchmarkScenario
+	sent      int
+	failed    int
+	duration  time.Duration
+	latencies []time.Duration
+	err       error
+}
+
+func runBenchmark(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	// Validar nome da fila
+	if !isValidQueue(queueName) {
+		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
+		return fmt.Errorf("fila inválida")
+	}
+
+	count, _ := cmd.Flags().GetInt("count")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	fixture, _ := cmd.Flags().GetString("fixture")
+	transport, _ := cmd.Flags().GetString("transport")
+	cache, _ := cmd.Flags().GetString("cache")
+	if count <= 0 || concurrency <= 0 {
+		return fmt.Errorf("--count e --concurrency devem ser maiores que zero")
+	}
+
+	scenarios, err := buildBenchmarkScenarios(transport, cache)
+	if err != nil {
+		return err
+	}
+
+	// Montar a mensagem usada em todos os envios
+	var template *servicebus.Message
+	if fixture != "" {
+		if template, err = loadMessageFromFile(fixture); err != nil {
+			return fmt.Errorf("erro ao carregar fixture: %w", err)
+		}
+	} else if template, err = buildCatalogMessage(cmd, &catalog.Simple, queueName, "benchmark"); err != nil {
+		return err
+	}
+
+	_, _ = blue.Printf("🏁 Benchmark de envio para '%s': %d mensagens, concorrência %d\n", queueName, count, concurrency)
+	fmt.Println()
+
+	var results []benchmarkResult
+	for _, scenario := range scenarios {
+		_, _ = blue.Printf("⏱️  %s...\n", scenario.name)
+		result := runBenchmarkScenario(cmd, scenario, queueName, template, count, concurrency)
+		if result.err != nil {
+			_, _ = red.Printf("  ❌ %v\n", result.err)
+		} else {
+			_, _ = green.Printf("  ✅ %d enviadas em %s\n", result.sent, result.duration.Round(time.Millisecond))
+		}
+		results = append(results, result)
+
+		if cmd.Context().Err() != nil {
+			break
+		}
+	}
+
+	fmt.Println()
+	_, _ = blue.Println("📊 Resultados:")
+	fmt.Printf("  %-32s %9s %7s %10s %10s %10s\n", "Cenário", "msg/s", "Falhas", "Média", "p50", "p95")
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Printf("  %-32s %9s\n", result.scenario.name, "erro")
+			continue
+		}
+
+		rate := 0.0
+		if result.duration > 0 {
+			rate = float64(result.sent) / result.duration.Seconds()
+		}
+		stats := load.Summarize(result.latencies)
+		fmt.Printf("  %-32s %9.1f %7d %10s %10s %10s\n",
+			result.scenario.name, rate, result.failed,
+			stats.Avg.Round(time.Microsecond),
+			stats.P50.Round(time.Microsecond),
+			stats.P95.Round(time.Microsecond))
+	}
+
+	fmt.Println()
+	_, _ = yellow.Printf("⚠️  As mensagens enviadas continuam na fila '%s'\n", queueName)
+	return nil
+}
+
+// buildBenchmarkScenarios combina os transportes e modos de cache solicitados
+func buildBenchmarkScenarios(transport, cache string) ([]benchmarkScenario, error) {
+	var transports []string
+	switch transport {
+	case "plain", "tls":
+		transports = []string{transport}
+	case "both":
+		transports = []string{"plain", "tls"}
+	default:
+		return nil, fmt.Errorf("--transport inválido '%s': use plain, tls ou both", transport)
+	}
+
+	var caches []bool
+	switch cache {
+	case "on":
+		caches = []bool{true}
+	case "off":
+		caches = []bool{false}
+	case "both":
+		caches = []bool{false, true}
+	default:
+		return nil, fmt.Errorf("--cache inválido '%s': use on, off ou both", cache)
+	}
+
+	var scenarios []benchmarkScenario
+	for _, t := range transports {
+		for _, c := range caches {
+			name := "AMQP 5672"
+			if t == "tls" {
+				name = "AMQPS 5671 (proxy)"
+			}
+			if c {
+				name += ", com cache"
+			} else {
+				name += ", sem cache"
+			}
+			scenarios = append(scenarios, benchmarkScenario{name: name, transport: t, cache: c})
+		}
+	}
+
+	return scenarios, nil
+}
+
+// runBenchmarkScenario envia count mensagens com um cliente configurado para o cenário
+func runBenchmarkScenario(cmd *cobra.Command, scenario benchmarkScenario, queueName string, template *servicebus.Message, count, concurrency int) benchmarkResult {
+	result := benchmarkResult{scenario: scenario}
+
+	connectionString := servicebus.SetDevelopmentEmulator(serviceBusConnectionString(), scenario.transport == "plain")
+	client, err := newServiceBusClient(cmd,
+		servicebus.WithConnectionString(connectionString),
+		servicebus.WithEntityCache(scenario.cache),
+	)
+	if err != nil {
+		result.err = fmt.Errorf("erro ao conectar ao service bus: %w", err)
+		return result
+	}
+	defer closeClient(cmd, client)
+
+	// Aquecer a conexão para que o handshake não entre na medição
+	if err := client.TestConnection(cmd.Context(), queueName); err != nil {
+		result.err = err
+		return result
+	}
+
+	ctx := cmd.Context()
+	started := time.Now().UnixNano()
+	var next int64 = -1
+	var failed int64
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				index := atomic.AddInt64(&next, 1)
+				if index >= int64(count) || ctx.Err() != nil {
+					return
+				}
+
+				message := *template
+				message.MessageID = fmt.Sprintf("benchmark-%d-%d", started, index)
+
+				sendStart := time.Now()
+				err := client.SendMessage(ctx, queueName, &message)
+				latency := time.Since(sendStart)
+
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+					continue
+				}
+				mu.Lock()
+				result.latencies = append(result.latencies, latency)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	result.duration = time.Since(start)
+	result.failed = int(failed)
+	result.sent = len(result.latencies)
+	if ctx.Err() != nil {
+		result.err = ctx.Err()
+	}
+	return result
+}
+
+func init() {
+	benchmarkCmd.Flags().Int("count", 200, "Número de mensagens por cenário")
+	benchmarkCmd.Flags().Int("concurrency", 10, "Número de envios simultâneos")
+	benchmarkCmd.Flags().String("fixture", "", "Arquivo JSON da pasta messages usado como mensagem")
+	benchmarkCmd.Flags().String("transport", "both", "Transporte: plain (5672), tls (5671) ou both")
+	benchmarkCmd.Flags().String("cache", "both", "Cache de senders: on, off ou both")
+
+	benchmarkCmd.ValidArgsFunction = completeQueues
+}
@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/catalog"
+	"fin.orion.dev/internal/fixture"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando agrupador do catálogo de tipos de mensagem
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Consultar o catálogo de tipos de mensagem",
+	Long: `Os tipos de mensagem usados por send-queue e send-topic ficam na pasta catalog,
+um arquivo YAML por tipo, com os destinos aceitos, o template e os valores
+padrão. Novos tipos não exigem alterações no código.`,
+}
+
+// Comando para listar os tipos do catálogo
+var catalogListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar os tipos de mensagem",
+	Args:  cobra.NoArgs,
+	RunE:  runCatalogList,
+}
+
+// Comando para mostrar um tipo do catálogo
+var catalogShowCmd = &cobra.Command{
+	Use:   "show [type]",
+	Short: "Mostrar a definição e um exemplo renderizado de um tipo",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCatalogShow,
+}
+
+func runCatalogList(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	cat, err := catalog.Load(catalog.DefaultDir)
+	if err != nil {
+		return err
+	}
+
+	types := cat.All()
+	target, _ := cmd.Flags().GetString("target")
+	if target != "" {
+		types = cat.ForTarget(target)
+		_, _ = blue.Printf("📚 Tipos de mensagem compatíveis com '%s' (%d):\n", target, len(types))
+	} else {
+		_, _ = blue.Printf("📚 Tipos de mensagem em %s (%d):\n", catalog.DefaultDir, len(types))
+	}
+	fmt.Println()
+
+	for _, messageType := range types {
+		_, _ = green.Printf("  • %s", messageType.Name)
+		if messageType.Description != "" {
+			fmt.Printf(" — %s", messageType.Description)
+		}
+		fmt.Println()
+		fmt.Printf("    Destinos: %s\n", messageType.TargetsDescription())
+	}
+	return nil
+}
+
+func runCatalogShow(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	cat, err := catalog.Load(catalog.DefaultDir)
+	if err != nil {
+		return err
+	}
+	messageType, ok := cat.Get(args[0])
+	if !ok {
+		return fmt.Errorf("tipo '%s' não existe no catálogo (tipos: %s)", args[0], strings.Join(catalog.Names(cat.All()), ", "))
+	}
+
+	target := "<destino>"
+	if len(messageType.Targets) > 0 {
+		target = messageType.Targets[0]
+	}
+	if flagTarget, _ := cmd.Flags().GetString("target"); flagTarget != "" {
+		target = flagTarget
+	}
+
+	_, _ = green.Printf("📄 %s\n", messageType.Name)
+	if messageType.Description != "" {
+		fmt.Printf("  Descrição:    %s\n", messageType.Description)
+	}
+	source := "embutido"
+	if messageType.Path != "" {
+		source = messageType.Path
+	}
+	fmt.Printf("  Arquivo:      %s\n", source)
+	fmt.Printf("  Destinos:     %s\n", messageType.TargetsDescription())
+	if messageType.TemplateFile != "" {
+		fmt.Printf("  Template:     messages/%s\n", messageType.TemplateFile)
+	}
+	if messageType.Subject != "" {
+		fmt.Printf("  Subject:      %s\n", messageType.Subject)
+	}
+	if len(messageType.Properties) > 0 {
+		properties, _ := json.Marshal(messageType.Properties)
+		fmt.Printf("  Propriedades: %s\n", properties)
+	}
+	if len(messageType.Vars) > 0 {
+		vars, _ := json.Marshal(messageType.Vars)
+		fmt.Printf("  Variáveis:    %s\n", vars)
+	}
+	fmt.Println()
+
+	message, err := buildCatalogMessage(cmd, messageType, target, "catalog")
+	if err != nil {
+		return err
+	}
+	_, _ = blue.Printf("🧩 Exemplo renderizado para '%s':\n", target)
+	body, err := json.Marshal(message.Body)
+	if err != nil {
+		return err
+	}
+	var formatted bytes.Buffer
+	if err := json.Indent(&formatted, body, "", "  "); err != nil {
+		return err
+	}
+	fmt.Println(formatted.String())
+	return nil
+}
+
+// resolveCatalogType carrega o catálogo e procura o tipo, que deve ser compatível com o destino
+func resolveCatalogType(name, target string) (*catalog.MessageType, error) {
+	blue := color.New(color.FgBlue)
+	red := color.New(color.FgRed)
+
+	cat, err := catalog.Load(catalog.DefaultDir)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType, ok := cat.Get(name)
+	if ok && messageType.Supports(target) {
+		return messageType, nil
+	}
+
+	if ok {
+		_, _ = red.Printf("❌ O tipo '%s' não é compatível com '%s' (destinos: %s)\n", name, target, messageType.TargetsDescription())
+	} else {
+		_, _ = red.Printf("❌ Tipo '%s' não existe no catálogo\n", name)
+	}
+	_, _ = blue.Printf("Tipos compatíveis com '%s':\n", target)
+	for _, compatible := range cat.ForTarget(target) {
+		_, _ = blue.Printf("  - %s\n", compatible.Name)
+	}
+	return nil, fmt.Errorf("tipo de mensagem inválido")
+}
+
+// buildCatalogMessage renderiza o template do tipo para o destino, aplicando os padrões do catálogo, --var e --set.
+// O destino fica disponível no template como {{.target}}. Em comandos com --schema-check a mensagem
+// renderizada é validada contra o schema do destino.
+func buildCatalogMessage(cmd *cobra.Command, messageType *catalog.MessageType, target, idPrefix string) (*servicebus.Message, error) {
+	flagVars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	message, rendered, err := renderCatalogMessage(messageType, target, idPrefix, flagVars, sets)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMessageSchema(cmd, target, rendered); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// renderCatalogMessage monta a mensagem do tipo com as variáveis e overrides já lidos das flags e
+// retorna também o conteúdo renderizado
+func renderCatalogMessage(messageType *catalog.MessageType, target, idPrefix string, flagVars map[string]string, sets []fixture.Set) (*servicebus.Message, []byte, error) {
+	vars := map[string]string{"target": target}
+	for name, value := range messageType.Vars {
+		vars[name] = value
+	}
+	for name, value := range flagVars {
+		vars[name] = value
+	}
+
+	name, data, err := messageType.Source("messages")
+	if err != nil {
+		return nil, nil, err
+	}
+	rendered, err := renderTemplate(name, data, vars, sets)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tipo '%s': %w", messageType.Name, err)
+	}
+	message, err := parseMessage(rendered)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tipo '%s': %w", messageType.Name, err)
+	}
+
+	if message.Subject == "" {
+		message.Subject = messageType.Subject
+	}
+	if messageType.ContentType != "" {
+		message.ContentType = messageType.ContentType
+	}
+	if len(messageType.Properties) > 0 {
+		properties := make(map[string]interface{}, len(messageType.Properties)+len(message.Properties))
+		for key, value := range messageType.Properties {
+			properties[key] = value
+		}
+		for key, value := range message.Properties {
+			properties[key] = value
+		}
+		message.Properties = properties
+	}
+	if message.MessageID == "" {
+		message.MessageID = fmt.Sprintf("%s-%s-%d", idPrefix, messageType.Name, time.Now().Unix())
+	}
+	if message.CorrelationID == "" {
+		message.CorrelationID = fmt.Sprintf("corr-%d", time.Now().Unix())
+	}
+	return message, rendered, nil
+}
+
+// completeTargetAndType completa o destino no primeiro argumento e os tipos compatíveis com ele no segundo
+func completeTargetAndType(completeTarget cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return completeTarget(cmd, args, toComplete)
+		case 1:
+			cat, err := catalog.Load(catalog.DefaultDir)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return filterCompletions(catalog.Names(cat.ForTarget(args[0])), toComplete), cobra.ShellCompDirectiveNoFileComp
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+}
+
+// completeCatalogTypes completa o nome de um tipo do catálogo no primeiro argumento
+func completeCatalogTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cat, err := catalog.Load(catalog.DefaultDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(catalog.Names(cat.All()), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCatalogTargets completa filas e tópicos declarados na configuração do emulador
+func completeCatalogTargets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := append(reg.QueueNames(), reg.TopicNames()...)
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	catalogCmd.AddCommand(catalogListCmd)
+	catalogCmd.AddCommand(catalogShowCmd)
+
+	catalogListCmd.Flags().String("target", "", "Listar apenas os tipos compatíveis com a fila ou tópico")
+	catalogShowCmd.Flags().String("target", "", "Destino usado no exemplo renderizado (padrão: primeiro destino do tipo)")
+	addTemplateFlags(catalogShowCmd)
+
+	catalogShowCmd.ValidArgsFunction = completeCatalogTypes
+	_ = catalogListCmd.RegisterFlagCompletionFunc("target", completeCatalogTargets)
+	_ = catalogShowCmd.RegisterFlagCompletionFunc("target", completeCatalogTargets)
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+// Garante que o .env seja carregado (e o aviso mostrado) uma única vez
+var loadEnvOnce sync.Once
+
+// serviceBusConnectionString carrega o .env e retorna a connection string SB_CNT_STR,
+// ou a connection string padrão do emulador quando ela não estiver definida
+func serviceBusConnectionString() string {
+	loadEnvOnce.Do(func() {
+		yellow := color.New(color.FgYellow)
+
+		// Carregar variáveis de ambiente
+		if err := godotenv.Load(); err != nil {
+			_, _ = yellow.Printf("⚠️  Aviso: erro ao carregar .env: %v\n", err)
+		}
+	})
+
+	if connectionString := os.Getenv("SB_CNT_STR"); connectionString != "" {
+		return connectionString
+	}
+	return servicebus.DefaultConnectionString
+}
+
+// newServiceBusClient cria o cliente do Service Bus com a connection string do ambiente.
+// Com --verbose, os diagnósticos do cliente são mostrados no terminal. Opções extras
+// sobrescrevem as padrão.
+func newServiceBusClient(cmd *cobra.Command, extra ...servicebus.Option) (*servicebus.Client, error) {
+	options := []servicebus.Option{
+		servicebus.WithConnectionString(serviceBusConnectionString()),
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		options = append(options, servicebus.WithLogger(verboseLogger{}))
+	}
+	options = append(options, extra...)
+
+	return servicebus.NewClient(options...)
+}
+
+// verboseLogger mostra os diagnósticos do cliente do Service Bus
+type verboseLogger struct{}
+
+func (verboseLogger) Printf(format string, args ...interface{}) {
+	_, _ = color.New(color.FgHiBlack).Printf("🔧 "+format+"\n", args...)
+}
+
+// closeClient fecha o cliente do Service Bus ignorando erros
+func closeClient(cmd *cobra.Command, client *servicebus.Client) {
+	if err := client.Close(cmd.Context()); err != nil {
+		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+			fmt.Printf("⚠️  Erro ao fechar cliente: %v\n", err)
+		}
+	}
+}
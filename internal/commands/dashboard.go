@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// dashboardConcurrency limita quantas entidades são espiadas ao mesmo tempo
+const dashboardConcurrency = 4
+
+// dashboardServices são os serviços locais verificados pelo check-messages, além do emulador
+var dashboardServices = []struct {
+	name string
+	port int
+}{
+	{name: "Orion Functions", port: 7071},
+	{name: "Orion API", port: 3333},
+}
+
+// dashboardEntity é uma linha do painel do check-messages
+type dashboardEntity struct {
+	servicebus.EntityStats
+	RequiresSession bool   `json:"requiresSession,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// serviceStatus indica se um serviço local respondeu na verificação de saúde
+type serviceStatus struct {
+	Name      string `json:"name"`
+	Port      int    `json:"port"`
+	Available bool   `json:"available"`
+}
+
+// dashboardSnapshot é o resultado de uma coleta do check-messages, usado na saída JSON
+type dashboardSnapshot struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Entities    []dashboardEntity `json:"entities"`
+	Services    []serviceStatus   `json:"services"`
+}
+
+func runCheckMessages(cmd *cobra.Command, args []string) error {
+	red := color.New(color.FgRed)
+
+	output, _ := cmd.Flags().GetString("output")
+	if output != "table" && output != "json" {
+		return fmt.Errorf("--output inválido '%s': use table ou json", output)
+	}
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if interval <= 0 {
+		return fmt.Errorf("--interval deve ser maior que zero")
+	}
+
+	if !utils.CheckPort(5672) {
+		_, _ = red.Println("❌ Service Bus Emulator não está respondendo na porta 5672")
+		return fmt.Errorf("emulador indisponível: execute 'orion-dev start'")
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	// Entidades com sessão não aceitam receivers comuns na sub-fila ativa
+	var entities []servicebus.Entity
+	sessions := map[string]bool{}
+	for _, queue := range reg.Queues() {
+		entities = append(entities, servicebus.Entity{Queue: queue.Name})
+		sessions[queue.Name] = queue.Properties.RequiresSession
+	}
+	for _, topic := range reg.Topics() {
+		for _, subscription := range topic.Subscriptions {
+			entity := servicebus.Entity{Topic: topic.Name, Subscription: subscription.Name}
+			entities = append(entities, entity)
+			sessions[entity.String()] = subscription.Properties.RequiresSession
+		}
+	}
+
+	ctx := cmd.Context()
+	for {
+		snapshot := collectDashboard(ctx, client, entities, sessions, limit)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			if !watch {
+				encoder.SetIndent("", "  ")
+			}
+			if err := encoder.Encode(snapshot); err != nil {
+				return fmt.Errorf("erro ao serializar painel: %w", err)
+			}
+		} else {
+			if watch {
+				// Limpar a tela antes de redesenhar o painel
+				fmt.Print("\033[H\033[2J")
+			}
+			printDashboard(snapshot, limit)
+			if watch {
+				_, _ = color.New(color.FgBlue).Printf("\n🔄 Atualizando a cada %s (Ctrl+C para sair)\n", interval)
+			}
+		}
+
+		if !watch {
+			return nil
+		}
+		if err := sleepOrDone(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// collectDashboard espia todas as entidades, algumas ao mesmo tempo, mantendo a ordem da configuração
+func collectDashboard(ctx context.Context, client *servicebus.Client, entities []servicebus.Entity, sessions map[string]bool, limit int) dashboardSnapshot {
+	rows := make([]dashboardEntity, len(entities))
+	semaphore := make(chan struct{}, dashboardConcurrency)
+	var wg sync.WaitGroup
+
+	for i, entity := range entities {
+		wg.Add(1)
+		go func(i int, entity servicebus.Entity) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			requiresSession := sessions[entity.String()]
+			row := dashboardEntity{EntityStats: servicebus.EntityStats{Entity: entity.String()}, RequiresSession: requiresSession}
+
+			stats, err := client.EntityStats(ctx, entity, servicebus.StatsOptions{Limit: limit, SkipActive: requiresSession})
+			if err != nil {
+				row.Error = err.Error()
+			} else {
+				row.EntityStats = *stats
+			}
+			rows[i] = row
+		}(i, entity)
+	}
+	wg.Wait()
+
+	return dashboardSnapshot{GeneratedAt: time.Now().UTC(), Entities: rows, Services: checkDashboardServices()}
+}
+
+// checkDashboardServices verifica se as Functions e a API respondem, como o check-messages sempre fez
+func checkDashboardServices() []serviceStatus {
+	statuses := make([]serviceStatus, len(dashboardServices))
+	var wg sync.WaitGroup
+	for i, service := range dashboardServices {
+		wg.Add(1)
+		go func(i int, name string, port int) {
+			defer wg.Done()
+			available := utils.CheckHTTPEndpointWithTimeout(fmt.Sprintf("http://localhost:%d", port), 2*time.Second)
+			statuses[i] = serviceStatus{Name: name, Port: port, Available: available}
+		}(i, service.name, service.port)
+	}
+	wg.Wait()
+	return statuses
+}
+
+// printDashboard mostra o painel em forma de tabela
+func printDashboard(snapshot dashboardSnapshot, limit int) {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	_, _ = blue.Printf("📨 Mensagens do Service Bus em %s\n", snapshot.GeneratedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Println()
+
+	fmt.Printf("  %-55s %8s %9s %6s  %-22s %s\n", "Entidade", "Ativas", "Agendadas", "DLQ", "Mais antiga", "Última mensagem")
+	truncated := false
+	for _, row := range snapshot.Entities {
+		if row.Error != "" {
+			_, _ = red.Printf("  %-55s ❌ %s\n", row.Entity, row.Error)
+			continue
+		}
+
+		active := fmt.Sprintf("%d", row.ActiveCount)
+		if row.RequiresSession {
+			active = "sessão"
+		}
+
+		oldest := "-"
+		if row.OldestEnqueuedTime != nil {
+			age := time.Since(*row.OldestEnqueuedTime).Round(time.Second)
+			oldest = fmt.Sprintf("%s (há %s)", row.OldestEnqueuedTime.Local().Format("15:04:05"), age)
+		}
+
+		lastMessage := "-"
+		if row.LastMessageID != "" {
+			lastMessage = row.LastMessageID
+		}
+
+		line := fmt.Sprintf("  %-55s %8s %9d %6d  %-22s %s", row.Entity, active, row.ScheduledCount, row.DeadLetterCount, oldest, lastMessage)
+		switch {
+		case row.DeadLetterCount > 0:
+			_, _ = red.Println(line)
+		case row.ActiveCount > 0 || row.ScheduledCount > 0:
+			_, _ = green.Println(line)
+		default:
+			fmt.Println(line)
+		}
+		truncated = truncated || row.Truncated
+	}
+
+	if truncated {
+		fmt.Println()
+		_, _ = yellow.Printf("⚠️  Algumas entidades têm mais de %d mensagens: as contagens são mínimas (use --limit)\n", limit)
+	}
+
+	fmt.Println()
+	for _, service := range snapshot.Services {
+		if service.Available {
+			_, _ = green.Printf("✅ %s (porta %d)\n", service.Name, service.Port)
+		} else {
+			_, _ = red.Printf("⚠️  %s (porta %d)\n", service.Name, service.Port)
+		}
+	}
+}
+
+func init() {
+	checkMessagesCmd.Flags().Bool("watch", false, "Atualizar o painel periodicamente até Ctrl+C")
+	checkMessagesCmd.Flags().Duration("interval", 5*time.Second, "Intervalo entre atualizações com --watch")
+	checkMessagesCmd.Flags().StringP("output", "o", "table", "Formato de saída: table ou json")
+	checkMessagesCmd.Flags().Int("limit", 1000, "Número máximo de mensagens espiadas por sub-fila")
+}
@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando agrupador da dead-letter queue
+var dlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Gerenciar dead-letter queues",
+	Long: `Inspeciona, reenvia e limpa mensagens das dead-letter queues ($DeadLetterQueue).
+
+A entidade pode ser uma fila (ex: sbq.pismo.all) ou uma subscription no
+formato tópico/subscription (ex: sbt.orion.core/subscription.orion.core).`,
+}
+
+// Comando para listar mensagens da dead-letter queue
+var dlqListCmd = &cobra.Command{
+	Use:   "list [entity]",
+	Short: "Listar mensagens da dead-letter queue",
+	Long:  `Lista mensagens da dead-letter queue sem removê-las, mostrando o motivo e o número de entregas.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDlqList,
+}
+
+// Comando para reenviar mensagens da dead-letter queue
+var dlqResubmitCmd = &cobra.Command{
+	Use:   "resubmit [entity]",
+	Short: "Reenviar mensagens da dead-letter queue",
+	Long: `Reenvia mensagens da dead-letter queue para a entidade de origem, preservando
+as propriedades originais. Para subscriptions o reenvio é feito no tópico.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDlqResubmit,
+}
+
+// Comando para limpar a dead-letter queue
+var dlqPurgeCmd = &cobra.Command{
+	Use:   "purge [entity]",
+	Short: "Limpar a dead-letter queue",
+	Long:  `Remove definitivamente todas as mensagens da dead-letter queue.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDlqPurge,
+}
+
+func runDlqList(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	fromSequence, _ := cmd.Flags().GetInt64("from-sequence")
+
+	_, _ = blue.Printf("🔍 Verificando dead-letter queue de '%s'...\n", entity)
+	fmt.Println()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	messages, err := client.PeekDeadLetterMessages(cmd.Context(), entity, maxMessages, fromSequence)
+	if err != nil {
+		return fmt.Errorf("erro ao ler dead-letter queue: %w", err)
+	}
+
+	if len(messages) == 0 {
+		_, _ = blue.Println("ℹ️  Nenhuma mensagem encontrada na dead-letter queue")
+	} else {
+		printReceivedMessages(messages)
+		printNextPageHint(cmd, messages)
+	}
+
+	_, _ = green.Println("✅ Verificação concluída")
+	return nil
+}
+
+func runDlqResubmit(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	sequences, _ := cmd.Flags().GetInt64Slice("sequence")
+	rawProperties, _ := cmd.Flags().GetStringArray("set-property")
+	bodyFile, _ := cmd.Flags().GetString("body")
+
+	properties, err := parseKeyValues(rawProperties)
+	if err != nil {
+		return err
+	}
+
+	options := servicebus.ResubmitOptions{
+		MaxMessages:     maxMessages,
+		SequenceNumbers: sequences,
+		Properties:      properties,
+	}
+
+	if bodyFile != "" {
+		message, err := loadMessageFromFile(bodyFile)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar novo body: %w", err)
+		}
+		options.Body = message.Body
+	}
+
+	_, _ = blue.Printf("♻️  Reenviando mensagens da dead-letter queue de '%s' para '%s'...\n", entity, entity.SendTarget())
+	if entity.IsSubscription() {
+		_, _ = yellow.Printf("⚠️  O reenvio é feito no tópico '%s': todas as subscriptions receberão a mensagem\n", entity.Topic)
+	}
+	fmt.Println()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	resubmitted, err := client.ResubmitDeadLetterMessages(cmd.Context(), entity, options)
+	for _, message := range resubmitted {
+		_, _ = green.Printf("  ✅ %s (seq %d)\n", message.MessageID, message.SequenceNumber)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao reenviar mensagens: %w", err)
+	}
+
+	if len(resubmitted) == 0 {
+		_, _ = blue.Println("ℹ️  Nenhuma mensagem reenviada")
+		return nil
+	}
+
+	fmt.Println()
+	_, _ = green.Printf("✅ %d mensagem(ns) reenviada(s)\n", len(resubmitted))
+	return nil
+}
+
+func runDlqPurge(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	if !force && !confirmAction(fmt.Sprintf("Remover definitivamente todas as mensagens da dead-letter queue de '%s'?", entity)) {
+		_, _ = blue.Println("ℹ️  Operação cancelada")
+		return nil
+	}
+
+	_, _ = blue.Printf("🧹 Limpando dead-letter queue de '%s'...\n", entity)
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	total, err := client.PurgeDeadLetterMessages(cmd.Context(), entity)
+	if err != nil {
+		return fmt.Errorf("erro ao limpar dead-letter queue: %w", err)
+	}
+
+	_, _ = green.Printf("✅ %d mensagem(ns) removida(s)\n", total)
+	return nil
+}
+
+// parseEntityArg interpreta e valida, contra a configuração do emulador, uma fila ou tópico/subscription informado na linha de comando
+func parseEntityArg(name string) (servicebus.Entity, error) {
+	entity, err := servicebus.ParseEntity(name)
+	if err != nil {
+		return servicebus.Entity{}, err
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return servicebus.Entity{}, fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+	if err := reg.ValidateEntity(entity); err != nil {
+		return servicebus.Entity{}, err
+	}
+
+	return entity, nil
+}
+
+// parseKeyValues converte argumentos chave=valor em propriedades; valores JSON (números, booleanos) são decodificados
+func parseKeyValues(values []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, item := range values {
+		key, value, found := strings.Cut(item, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("propriedade inválida '%s': use chave=valor", item)
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			result[key] = decoded
+		} else {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// confirmAction pede confirmação ao usuário para operações destrutivas
+func confirmAction(prompt string) bool {
+	yellow := color.New(color.FgYellow)
+	_, _ = yellow.Printf("⚠️  %s [s/N]: ", prompt)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "s" || answer == "sim" || answer == "y" || answer == "yes"
+}
+
+func init() {
+	dlqCmd.AddCommand(dlqListCmd)
+	dlqCmd.AddCommand(dlqResubmitCmd)
+	dlqCmd.AddCommand(dlqPurgeCmd)
+
+	dlqListCmd.Flags().Int("max", 10, "Número máximo de mensagens a listar")
+	dlqListCmd.Flags().Int64("from-sequence", 0, "Sequence number inicial")
+
+	dlqResubmitCmd.Flags().Int("max", 0, "Número máximo de mensagens a reenviar (0 = todas)")
+	dlqResubmitCmd.Flags().Int64Slice("sequence", nil, "Reenviar apenas os sequence numbers informados")
+	dlqResubmitCmd.Flags().StringArray("set-property", nil, "Sobrescrever propriedade de aplicação (chave=valor)")
+	dlqResubmitCmd.Flags().String("body", "", "Substituir o body por um arquivo JSON da pasta messages")
+
+	dlqPurgeCmd.Flags().BoolP("force", "f", false, "Não pedir confirmação")
+
+	for _, cmd := range []*cobra.Command{dlqListCmd, dlqResubmitCmd, dlqPurgeCmd} {
+		cmd.ValidArgsFunction = completeEntity
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando agrupador da configuração do emulador
+var emulatorCmd = &cobra.Command{
+	Use:   "emulator",
+	Short: "Gerenciar configuração do Service Bus Emulator",
+	Long:  `Altera a configuração do Service Bus Emulator (docker/service-bus/config.json).`,
+}
+
+// Comando para ligar ou desligar sessões
+var emulatorSetSessionCmd = &cobra.Command{
+	Use:   "set-session [entity] [true|false]",
+	Short: "Ligar ou desligar RequiresSession",
+	Long: `Liga ou desliga RequiresSession em uma fila ou subscription (tópico/subscription).
+
+O emulador só aplica a alteração depois de reiniciado; use --restart para
+reiniciar apenas o container do emulador e verificar a entidade.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEmulatorSetSession,
+}
+
+func runEmulatorSetSession(cmd *cobra.Command, args []string) error {
+	entity, err := servicebus.ParseEntity(args[0])
+	if err != nil {
+		return err
+	}
+
+	enabled, err := strconv.ParseBool(args[1])
+	if err != nil {
+		return fmt.Errorf("valor inválido '%s': use true ou false", args[1])
+	}
+
+	// Mesmo fluxo de entity set: a configuração é validada antes de gravar e, com --restart,
+	// a entidade é verificada depois de reiniciar o emulador
+	return updateEmulatorConfig(cmd, fmt.Sprintf("RequiresSession=%t em '%s'", enabled, entity), func(config *emulator.Config) error {
+		if entity.IsSubscription() {
+			return config.SetSubscriptionRequiresSession(entity.Topic, entity.Subscription, enabled)
+		}
+		return config.SetQueueRequiresSession(entity.Queue, enabled)
+	}, entity.String())
+}
+
+// restartEmulator reinicia apenas o container do Service Bus Emulator
+func restartEmulator() error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	_, _ = blue.Println("🔄 Reiniciando Service Bus Emulator...")
+	cmdExec := exec.Command("docker-compose", "restart", "emulator")
+	cmdExec.Stdout = nil
+	cmdExec.Stderr = nil
+	if err := cmdExec.Run(); err != nil {
+		return fmt.Errorf("erro ao reiniciar emulador: %w", err)
+	}
+
+	_, _ = green.Println("✅ Service Bus Emulator reiniciado")
+	return nil
+}
+
+func init() {
+	emulatorCmd.AddCommand(emulatorSetSessionCmd)
+	emulatorCmd.PersistentFlags().String("config", emulator.DefaultConfigPath, "Arquivo de configuração do emulador")
+
+	emulatorCmd.PersistentFlags().Bool("restart", false, "Reiniciar o container do emulador e verificar a entidade")
+	emulatorCmd.PersistentFlags().Duration("wait", 90*time.Second, "Tempo máximo de espera pela entidade após reiniciar")
+}
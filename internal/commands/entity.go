@@ -0,0 +1,459 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando agrupador para criar, remover e alterar entidades do emulador
+var entityCmd = &cobra.Command{
+	Use:   "entity",
+	Short: "Gerenciar filas, tópicos e subscriptions do emulador",
+	Long: `Cria, remove e altera entidades em docker/service-bus/config.json.
+
+Antes de gravar, a configuração é validada: durações ISO-8601 (ex.: PT1H),
+destinos de ForwardTo, expressões SQL das regras e os limites do emulador
+(1 namespace, 50 filas e tópicos por namespace, 50 subscriptions por tópico,
+20 filtros SQL e 1000 filtros de correlação por tópico).
+
+O emulador só aplica as alterações depois de reiniciado; use --restart para
+reiniciar apenas o container do emulador e verificar a entidade.`,
+}
+
+// Comando para adicionar uma fila
+var entityAddQueueCmd = &cobra.Command{
+	Use:   "add-queue [queue]",
+	Short: "Adicionar uma fila",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEntityAddQueue,
+}
+
+// Comando para adicionar um tópico
+var entityAddTopicCmd = &cobra.Command{
+	Use:   "add-topic [topic]",
+	Short: "Adicionar um tópico",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEntityAddTopic,
+}
+
+// Comando para adicionar uma subscription
+var entityAddSubscriptionCmd = &cobra.Command{
+	Use:   "add-subscription [topic/subscription]",
+	Short: "Adicionar uma subscription a um tópico",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEntityAddSubscription,
+}
+
+// Comando para remover uma entidade
+var entityRemoveCmd = &cobra.Command{
+	Use:   "remove [entity]",
+	Short: "Remover uma fila, tópico ou subscription",
+	Long: `Remove uma fila, um tópico (com todas as suas subscriptions) ou uma
+subscription (tópico/subscription). A remoção é recusada se outra entidade
+encaminhar mensagens para a entidade removida.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEntityRemove,
+}
+
+// Comando para alterar propriedades de uma entidade
+var entitySetCmd = &cobra.Command{
+	Use:   "set [entity] [Propriedade=valor]...",
+	Short: "Alterar propriedades de uma fila, tópico ou subscription",
+	Long: `Altera propriedades usando os mesmos nomes do arquivo de configuração.
+
+Exemplos:
+  orion-dev entity set sbq.pismo.all LockDuration=PT2M MaxDeliveryCount=5
+  orion-dev entity set sbt.orion.core/subscription.orion.core ForwardTo=sbq.pismo.all`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runEntitySet,
+}
+
+// Comando para adicionar uma regra a uma subscription
+var entityAddRuleCmd = &cobra.Command{
+	Use:   "add-rule [topic/subscription] [rule]",
+	Short: "Adicionar uma regra (filtro SQL ou de correlação) a uma subscription",
+	Long: `Adiciona uma regra a uma subscription. Use --sql para um filtro SQL ou as
+flags de correlação (--correlation-id, --subject, --match-property, ...) para
+um filtro de correlação. --action define uma ação SQL (SET/REMOVE) opcional.
+
+Uma subscription sem regras recebe todas as mensagens do tópico; com regras,
+recebe as mensagens selecionadas por ao menos uma delas.
+
+Exemplos:
+  orion-dev entity add-rule sbt.orion.core/subscription.orion.core pix --sql "tipo = 'pix' AND valor > 100"
+  orion-dev entity add-rule sbt.orion.core/subscription.orion.core ted --subject ted --match-property origem=pismo`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEntityAddRule,
+}
+
+// Comando para remover uma regra de uma subscription
+var entityRemoveRuleCmd = &cobra.Command{
+	Use:   "remove-rule [topic/subscription] [rule]",
+	Short: "Remover uma regra de uma subscription",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runEntityRemoveRule,
+}
+
+func runEntityAddQueue(cmd *cobra.Command, args []string) error {
+	properties := emulator.DefaultQueueProperties()
+	properties.DefaultMessageTimeToLive, _ = cmd.Flags().GetString("ttl")
+	properties.LockDuration, _ = cmd.Flags().GetString("lock-duration")
+	properties.MaxDeliveryCount, _ = cmd.Flags().GetInt("max-delivery-count")
+	properties.RequiresSession, _ = cmd.Flags().GetBool("requires-session")
+	properties.DeadLetteringOnMessageExpiration, _ = cmd.Flags().GetBool("dead-letter-on-expiration")
+	properties.ForwardTo, _ = cmd.Flags().GetString("forward-to")
+	properties.ForwardDeadLetteredMessagesTo, _ = cmd.Flags().GetString("forward-dlq-to")
+	properties.RequiresDuplicateDetection, _ = cmd.Flags().GetBool("duplicate-detection")
+	properties.DuplicateDetectionHistoryTimeWindow, _ = cmd.Flags().GetString("duplicate-window")
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("Fila '%s' adicionada", args[0]), func(config *emulator.Config) error {
+		return config.AddQueue(emulator.Queue{Name: args[0], Properties: properties})
+	}, args[0])
+}
+
+func runEntityAddTopic(cmd *cobra.Command, args []string) error {
+	properties := emulator.DefaultTopicProperties()
+	properties.DefaultMessageTimeToLive, _ = cmd.Flags().GetString("ttl")
+	properties.RequiresDuplicateDetection, _ = cmd.Flags().GetBool("duplicate-detection")
+	properties.DuplicateDetectionHistoryTimeWindow, _ = cmd.Flags().GetString("duplicate-window")
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("Tópico '%s' adicionado", args[0]), func(config *emulator.Config) error {
+		return config.AddTopic(emulator.Topic{Name: args[0], Properties: properties})
+	}, args[0])
+}
+
+func runEntityAddSubscription(cmd *cobra.Command, args []string) error {
+	entity, err := parseSubscriptionArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	properties := emulator.DefaultSubscriptionProperties()
+	properties.DefaultMessageTimeToLive, _ = cmd.Flags().GetString("ttl")
+	properties.LockDuration, _ = cmd.Flags().GetString("lock-duration")
+	properties.MaxDeliveryCount, _ = cmd.Flags().GetInt("max-delivery-count")
+	properties.RequiresSession, _ = cmd.Flags().GetBool("requires-session")
+	properties.DeadLetteringOnMessageExpiration, _ = cmd.Flags().GetBool("dead-letter-on-expiration")
+	properties.ForwardTo, _ = cmd.Flags().GetString("forward-to")
+	properties.ForwardDeadLetteredMessagesTo, _ = cmd.Flags().GetString("forward-dlq-to")
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("Subscription '%s' adicionada", entity), func(config *emulator.Config) error {
+		return config.AddSubscription(entity.Topic, emulator.Subscription{Name: entity.Subscription, Properties: properties})
+	}, entity.String())
+}
+
+func runEntityRemove(cmd *cobra.Command, args []string) error {
+	entity, err := servicebus.ParseEntity(args[0])
+	if err != nil {
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	if !force && !confirmAction(fmt.Sprintf("Remover '%s' da configuração do emulador?", entity)) {
+		_, _ = color.New(color.FgBlue).Println("Operação cancelada")
+		return nil
+	}
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("'%s' removida da configuração", entity), func(config *emulator.Config) error {
+		switch {
+		case entity.IsSubscription():
+			return config.RemoveSubscription(entity.Topic, entity.Subscription)
+		case config.FindTopic(entity.Queue) != nil:
+			return config.RemoveTopic(entity.Queue)
+		default:
+			return config.RemoveQueue(entity.Queue)
+		}
+	}, "")
+}
+
+func runEntitySet(cmd *cobra.Command, args []string) error {
+	entity, err := servicebus.ParseEntity(args[0])
+	if err != nil {
+		return err
+	}
+
+	assignments := args[1:]
+	return updateEmulatorConfig(cmd, fmt.Sprintf("'%s' atualizada: %s", entity, strings.Join(assignments, ", ")), func(config *emulator.Config) error {
+		for _, assignment := range assignments {
+			parts := strings.SplitN(assignment, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return fmt.Errorf("alteração inválida '%s': use Propriedade=valor", assignment)
+			}
+
+			var err error
+			switch {
+			case entity.IsSubscription():
+				err = config.SetSubscriptionProperty(entity.Topic, entity.Subscription, parts[0], parts[1])
+			case config.FindTopic(entity.Queue) != nil:
+				err = config.SetTopicProperty(entity.Queue, parts[0], parts[1])
+			default:
+				err = config.SetQueueProperty(entity.Queue, parts[0], parts[1])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, entity.String())
+}
+
+func runEntityAddRule(cmd *cobra.Command, args []string) error {
+	entity, err := parseSubscriptionArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	rule, err := buildRule(cmd, args[1])
+	if err != nil {
+		return err
+	}
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("Regra '%s' adicionada em '%s'", rule.Name, entity), func(config *emulator.Config) error {
+		return config.AddRule(entity.Topic, entity.Subscription, rule)
+	}, entity.String())
+}
+
+func runEntityRemoveRule(cmd *cobra.Command, args []string) error {
+	entity, err := parseSubscriptionArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	return updateEmulatorConfig(cmd, fmt.Sprintf("Regra '%s' removida de '%s'", args[1], entity), func(config *emulator.Config) error {
+		return config.RemoveRule(entity.Topic, entity.Subscription, args[1])
+	}, entity.String())
+}
+
+// parseSubscriptionArg interpreta um argumento no formato tópico/subscription
+func parseSubscriptionArg(name string) (servicebus.Entity, error) {
+	entity, err := servicebus.ParseEntity(name)
+	if err != nil {
+		return servicebus.Entity{}, err
+	}
+	if !entity.IsSubscription() {
+		return servicebus.Entity{}, fmt.Errorf("informe a subscription no formato tópico/subscription")
+	}
+	return entity, nil
+}
+
+// buildRule monta a regra a partir das flags de filtro SQL ou de correlação
+func buildRule(cmd *cobra.Command, name string) (emulator.Rule, error) {
+	rule := emulator.Rule{Name: name}
+
+	expression, _ := cmd.Flags().GetString("sql")
+	correlation := &emulator.CorrelationFilter{}
+	correlation.ContentType, _ = cmd.Flags().GetString("content-type")
+	correlation.CorrelationId, _ = cmd.Flags().GetString("correlation-id")
+	correlation.Label, _ = cmd.Flags().GetString("subject")
+	correlation.MessageId, _ = cmd.Flags().GetString("message-id")
+	correlation.ReplyTo, _ = cmd.Flags().GetString("reply-to")
+	correlation.ReplyToSessionId, _ = cmd.Flags().GetString("reply-to-session-id")
+	correlation.SessionId, _ = cmd.Flags().GetString("session-id")
+	correlation.To, _ = cmd.Flags().GetString("to")
+
+	rawProperties, _ := cmd.Flags().GetStringArray("match-property")
+	if len(rawProperties) > 0 {
+		properties, err := parseKeyValues(rawProperties)
+		if err != nil {
+			return rule, err
+		}
+		correlation.Properties = properties
+	}
+
+	switch {
+	case expression != "" && !correlation.IsEmpty():
+		return rule, fmt.Errorf("use --sql ou as flags de correlação, não ambos")
+	case expression != "":
+		rule.Properties.FilterType = emulator.FilterTypeSQL
+		rule.Properties.SqlFilter = &emulator.SqlFilter{SqlExpression: expression}
+	case !correlation.IsEmpty():
+		rule.Properties.FilterType = emulator.FilterTypeCorrelation
+		rule.Properties.CorrelationFilter = correlation
+	default:
+		return rule, fmt.Errorf("informe --sql ou ao menos uma flag de correlação")
+	}
+
+	if action, _ := cmd.Flags().GetString("action"); action != "" {
+		rule.Properties.Action = &emulator.SqlAction{SqlExpression: action}
+	}
+	return rule, nil
+}
+
+// updateEmulatorConfig carrega a configuração, aplica a alteração, valida e grava.
+// Com --restart, reinicia o emulador e verifica se a entidade informada em verify responde.
+func updateEmulatorConfig(cmd *cobra.Command, success string, change func(*emulator.Config) error, verify string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	configPath, _ := cmd.Flags().GetString("config")
+	config, err := emulator.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := change(config); err != nil {
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		_, _ = red.Println("❌ A configuração resultante é inválida:")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			_, _ = red.Printf("  - %s\n", line)
+		}
+		return fmt.Errorf("configuração do emulador inválida")
+	}
+
+	if err := config.Save(configPath); err != nil {
+		return err
+	}
+	_, _ = green.Printf("✅ %s\n", success)
+
+	restart, _ := cmd.Flags().GetBool("restart")
+	if !restart {
+		_, _ = blue.Printf("💡 Reinicie o emulador para aplicar: %s ... --restart\n", cmd.CommandPath())
+		return nil
+	}
+
+	if err := restartEmulator(); err != nil {
+		return err
+	}
+	if verify == "" {
+		return nil
+	}
+
+	wait, _ := cmd.Flags().GetDuration("wait")
+	return verifyEntity(cmd, config, verify, wait)
+}
+
+// verifyEntity aguarda o emulador voltar e abre um link com a entidade até ela responder
+func verifyEntity(cmd *cobra.Command, config *emulator.Config, name string, wait time.Duration) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	entity, err := servicebus.ParseEntity(name)
+	if err != nil {
+		return err
+	}
+
+	_, _ = blue.Printf("🔍 Verificando '%s' (até %s)...\n", entity, wait)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), wait)
+	defer cancel()
+
+	var lastErr error
+	for {
+		if lastErr = probeEntity(cmd, ctx, config, entity); lastErr == nil {
+			_, _ = green.Printf("✅ '%s' disponível no emulador\n", entity)
+			return nil
+		}
+
+		if err := sleepOrDone(ctx, 2*time.Second); err != nil {
+			_, _ = red.Printf("❌ '%s' não respondeu: %v\n", entity, lastErr)
+			return fmt.Errorf("entidade '%s' indisponível após reiniciar o emulador", entity)
+		}
+	}
+}
+
+// probeEntity abre um link com a entidade usando um cliente sem cache, descartado a cada tentativa
+func probeEntity(cmd *cobra.Command, ctx context.Context, config *emulator.Config, entity servicebus.Entity) error {
+	client, err := newServiceBusClient(cmd, servicebus.WithEntityCache(false))
+	if err != nil {
+		return err
+	}
+	defer closeClient(cmd, client)
+
+	if !entity.IsSubscription() {
+		return client.TestConnection(ctx, entity.Queue)
+	}
+
+	// Subscriptions com sessão não aceitam receivers comuns; nesse caso basta o link com o tópico
+	subscription := config.FindSubscription(entity.Topic, entity.Subscription)
+	if subscription != nil && subscription.Properties.RequiresSession {
+		return client.TestConnection(ctx, entity.Topic)
+	}
+	_, err = client.PeekMessagesFromTopic(ctx, entity.Topic, entity.Subscription, 1, 0)
+	return err
+}
+
+// sleepOrDone espera o intervalo ou retorna o erro do contexto, se ele terminar antes
+func sleepOrDone(ctx context.Context, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// addQueuePropertyFlags registra as flags de propriedades comuns a filas e subscriptions
+func addQueuePropertyFlags(cmd *cobra.Command, ttl, lockDuration string, maxDeliveryCount int) {
+	cmd.Flags().String("ttl", ttl, "DefaultMessageTimeToLive (ISO-8601)")
+	cmd.Flags().String("lock-duration", lockDuration, "LockDuration (ISO-8601, até PT5M)")
+	cmd.Flags().Int("max-delivery-count", maxDeliveryCount, "MaxDeliveryCount")
+	cmd.Flags().Bool("requires-session", false, "RequiresSession")
+	cmd.Flags().Bool("dead-letter-on-expiration", false, "DeadLetteringOnMessageExpiration")
+	cmd.Flags().String("forward-to", "", "ForwardTo: fila ou tópico de destino")
+	cmd.Flags().String("forward-dlq-to", "", "ForwardDeadLetteredMessagesTo: fila ou tópico de destino")
+}
+
+func init() {
+	entityCmd.AddCommand(entityAddQueueCmd)
+	entityCmd.AddCommand(entityAddTopicCmd)
+	entityCmd.AddCommand(entityAddSubscriptionCmd)
+	entityCmd.AddCommand(entityRemoveCmd)
+	entityCmd.AddCommand(entitySetCmd)
+	entityCmd.AddCommand(entityAddRuleCmd)
+	entityCmd.AddCommand(entityRemoveRuleCmd)
+
+	entityCmd.PersistentFlags().String("config", emulator.DefaultConfigPath, "Arquivo de configuração do emulador")
+	entityCmd.PersistentFlags().Bool("restart", false, "Reiniciar o container do emulador e verificar a entidade")
+	entityCmd.PersistentFlags().Duration("wait", 90*time.Second, "Tempo máximo de espera pela entidade após reiniciar")
+
+	queueDefaults := emulator.DefaultQueueProperties()
+	addQueuePropertyFlags(entityAddQueueCmd, queueDefaults.DefaultMessageTimeToLive, queueDefaults.LockDuration, queueDefaults.MaxDeliveryCount)
+	entityAddQueueCmd.Flags().Bool("duplicate-detection", false, "RequiresDuplicateDetection")
+	entityAddQueueCmd.Flags().String("duplicate-window", queueDefaults.DuplicateDetectionHistoryTimeWindow, "DuplicateDetectionHistoryTimeWindow (ISO-8601)")
+
+	topicDefaults := emulator.DefaultTopicProperties()
+	entityAddTopicCmd.Flags().String("ttl", topicDefaults.DefaultMessageTimeToLive, "DefaultMessageTimeToLive (ISO-8601)")
+	entityAddTopicCmd.Flags().Bool("duplicate-detection", false, "RequiresDuplicateDetection")
+	entityAddTopicCmd.Flags().String("duplicate-window", topicDefaults.DuplicateDetectionHistoryTimeWindow, "DuplicateDetectionHistoryTimeWindow (ISO-8601)")
+
+	subscriptionDefaults := emulator.DefaultSubscriptionProperties()
+	addQueuePropertyFlags(entityAddSubscriptionCmd, subscriptionDefaults.DefaultMessageTimeToLive, subscriptionDefaults.LockDuration, subscriptionDefaults.MaxDeliveryCount)
+
+	entityRemoveCmd.Flags().BoolP("force", "f", false, "Não pedir confirmação")
+
+	entityAddRuleCmd.Flags().String("sql", "", "Filtro SQL (ex.: \"tipo = 'pix' AND valor > 100\")")
+	entityAddRuleCmd.Flags().String("action", "", "Ação SQL opcional (ex.: \"SET prioridade = 'alta'\")")
+	entityAddRuleCmd.Flags().String("content-type", "", "Filtro de correlação: ContentType")
+	entityAddRuleCmd.Flags().String("correlation-id", "", "Filtro de correlação: CorrelationId")
+	entityAddRuleCmd.Flags().String("subject", "", "Filtro de correlação: Subject (Label)")
+	entityAddRuleCmd.Flags().String("message-id", "", "Filtro de correlação: MessageId")
+	entityAddRuleCmd.Flags().String("reply-to", "", "Filtro de correlação: ReplyTo")
+	entityAddRuleCmd.Flags().String("reply-to-session-id", "", "Filtro de correlação: ReplyToSessionId")
+	entityAddRuleCmd.Flags().String("session-id", "", "Filtro de correlação: SessionId")
+	entityAddRuleCmd.Flags().String("to", "", "Filtro de correlação: To")
+	entityAddRuleCmd.Flags().StringArray("match-property", nil, "Filtro de correlação: propriedade de aplicação (chave=valor, repetível)")
+
+	entityAddQueueCmd.ValidArgsFunction = cobra.NoFileCompletions
+	entityAddTopicCmd.ValidArgsFunction = cobra.NoFileCompletions
+	entityAddSubscriptionCmd.ValidArgsFunction = completeTopics
+	entityRemoveCmd.ValidArgsFunction = completeConfigEntities
+	entitySetCmd.ValidArgsFunction = completeConfigEntities
+	entityAddRuleCmd.ValidArgsFunction = completeSubscriptionEntities
+	entityRemoveRuleCmd.ValidArgsFunction = completeSubscriptionEntities
+}
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/diff"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para comparar a mensagem recebida em uma entidade com um golden file
+var expectCmd = &cobra.Command{
+	Use:   "expect [queue|topic/subscription]",
+	Short: "Comparar a mensagem recebida com um golden file",
+	Long: `Espia a entidade (sem remover mensagens) e compara o body e as propriedades
+de cada mensagem nova com o golden informado em --golden. Termina com sucesso na
+primeira mensagem sem diferenças; após --timeout mostra o diff colorido da
+mensagem mais próxima do golden e termina com erro.
+
+Campos voláteis são tratados pelas regras do golden (ignore, match e
+tolerance) ou pelas flags equivalentes:
+
+  orion-dev expect sbt.orion.core/subscription.orion.core --golden expected.json \
+    --ignore body.data.createdAt --match 'body.data.id=^[0-9a-f-]{36}$' \
+    --tolerance body.data.amount=0.01
+
+--update grava no golden o body e as propriedades da primeira mensagem recebida,
+mantendo as regras. Sem --from-start apenas mensagens enfileiradas depois do
+início do comando são consideradas.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpect,
+}
+
+func runExpect(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	goldenPath, _ := cmd.Flags().GetString("golden")
+	update, _ := cmd.Flags().GetBool("update")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	fromStart, _ := cmd.Flags().GetBool("from-start")
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout deve ser maior que zero")
+	}
+	filter, err := getMessageFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	golden, err := loadGolden(goldenPath, update)
+	if err != nil {
+		return err
+	}
+	options, err := getExpectOptions(cmd, golden.Options())
+	if err != nil {
+		return err
+	}
+	comparer, err := diff.New(options)
+	if err != nil {
+		return fmt.Errorf("%s: %w", goldenPath, err)
+	}
+	expected, err := golden.Expected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", goldenPath, err)
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	tailOptions := servicebus.TailOptions{Mode: servicebus.SettlePeek, PollInterval: interval, FromStart: true}
+	if !fromStart {
+		last, err := client.LastSequenceNumber(ctx, entity)
+		if err != nil {
+			return fmt.Errorf("erro ao consultar '%s': %w", entity, err)
+		}
+		tailOptions.FromSequence = last + 1
+	}
+
+	_, _ = blue.Printf("⏳ Aguardando mensagem em '%s' para comparar com '%s' (timeout %s)...\n", entity, goldenPath, timeout)
+
+	// Sem mensagem correspondente, o diff mostrado é o da mensagem com menos diferenças
+	var matched, closest *servicebus.Message
+	var closestDifferences []diff.Difference
+	var closestActual interface{}
+	var compareErr error
+	received := 0
+
+	err = client.Tail(ctx, entity, tailOptions, func(message *servicebus.Message) {
+		if matched != nil || ctx.Err() != nil || !filter.Match(message) {
+			return
+		}
+		received++
+		if update {
+			matched = message
+			cancel()
+			return
+		}
+
+		actual, err := golden.Actual(message)
+		if err != nil {
+			compareErr = err
+			cancel()
+			return
+		}
+		differences := comparer.Compare(expected, actual)
+		if len(differences) == 0 {
+			matched = message
+			cancel()
+			return
+		}
+		if closest == nil || len(differences) <= len(closestDifferences) {
+			closest, closestDifferences, closestActual = message, differences, actual
+		}
+	})
+	if compareErr != nil {
+		return compareErr
+	}
+	if err != nil && matched == nil {
+		return fmt.Errorf("erro ao acompanhar '%s': %w", entity, err)
+	}
+	if matched == nil && cmd.Context().Err() != nil {
+		return cmd.Context().Err()
+	}
+
+	if update {
+		if matched == nil {
+			return fmt.Errorf("nenhuma mensagem recebida em '%s' após %s: golden não atualizado", entity, timeout)
+		}
+		if err := golden.Update(matched); err != nil {
+			return err
+		}
+		var buffer bytes.Buffer
+		if err := diff.WriteGolden(&buffer, golden); err != nil {
+			return err
+		}
+		if err := os.WriteFile(goldenPath, buffer.Bytes(), 0644); err != nil {
+			return fmt.Errorf("erro ao gravar golden: %w", err)
+		}
+		_, _ = green.Printf("📝 Golden '%s' atualizado com a mensagem #%d (%s)\n", goldenPath, matched.SequenceNumber, matched.MessageID)
+		return nil
+	}
+
+	if matched != nil {
+		_, _ = green.Printf("✅ Mensagem #%d (%s) corresponde ao golden\n", matched.SequenceNumber, matched.MessageID)
+		return nil
+	}
+	if closest == nil {
+		_, _ = red.Printf("❌ Nenhuma mensagem recebida em '%s' após %s\n", entity, timeout)
+		return fmt.Errorf("nenhuma mensagem para comparar com '%s'", goldenPath)
+	}
+
+	_, _ = red.Printf("❌ Nenhuma das %d mensagem(ns) recebida(s) corresponde ao golden\n", received)
+	_, _ = blue.Printf("🔍 Mais próxima: #%d (%s), %d diferença(s):\n", closest.SequenceNumber, closest.MessageID, len(closestDifferences))
+	fmt.Println()
+	comparer.Write(os.Stdout, expected, closestActual)
+	fmt.Println()
+	for _, difference := range closestDifferences {
+		_, _ = red.Printf("  • %s\n", difference)
+	}
+	fmt.Println()
+	_, _ = blue.Println("💡 Trate campos voláteis com ignore, match ou tolerance, ou regrave o golden com --update")
+	return fmt.Errorf("%d diferença(s) em relação a '%s'", len(closestDifferences), goldenPath)
+}
+
+// loadGolden lê o golden; com --update um arquivo inexistente vira um golden vazio
+func loadGolden(path string, update bool) (*diff.Golden, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			if update {
+				return &diff.Golden{}, nil
+			}
+			return nil, fmt.Errorf("golden '%s' não encontrado: use --update para criá-lo", path)
+		}
+		return nil, fmt.Errorf("erro ao abrir golden: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	golden, err := diff.ReadGolden(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return golden, nil
+}
+
+// getExpectOptions junta as regras do golden às informadas por flags
+func getExpectOptions(cmd *cobra.Command, options diff.Options) (diff.Options, error) {
+	ignore, _ := cmd.Flags().GetStringArray("ignore")
+	rawMatch, _ := cmd.Flags().GetStringArray("match")
+	rawTolerance, _ := cmd.Flags().GetStringArray("tolerance")
+
+	result := diff.Options{
+		Ignore:    append(append([]string{}, options.Ignore...), ignore...),
+		Match:     make(map[string]string),
+		Tolerance: make(map[string]float64),
+	}
+	for path, expression := range options.Match {
+		result.Match[path] = expression
+	}
+	for path, delta := range options.Tolerance {
+		result.Tolerance[path] = delta
+	}
+
+	for _, item := range rawMatch {
+		path, expression, found := strings.Cut(item, "=")
+		if !found || path == "" {
+			return diff.Options{}, fmt.Errorf("--match inválido '%s': use caminho=regex", item)
+		}
+		result.Match[path] = expression
+	}
+	for _, item := range rawTolerance {
+		path, text, found := strings.Cut(item, "=")
+		delta, err := strconv.ParseFloat(text, 64)
+		if !found || path == "" || err != nil {
+			return diff.Options{}, fmt.Errorf("--tolerance inválido '%s': use caminho=número", item)
+		}
+		result.Tolerance[path] = delta
+	}
+	return result, nil
+}
+
+func init() {
+	expectCmd.Flags().String("golden", "", "Arquivo JSON com o body e as propriedades esperados")
+	expectCmd.Flags().Bool("update", false, "Gravar no golden a primeira mensagem recebida")
+	expectCmd.Flags().Duration("timeout", 30*time.Second, "Tempo máximo de espera por uma mensagem correspondente")
+	expectCmd.Flags().Duration("interval", 200*time.Millisecond, "Intervalo entre consultas à entidade")
+	expectCmd.Flags().Bool("from-start", false, "Considerar também as mensagens que já estavam na entidade")
+	expectCmd.Flags().StringArray("ignore", nil, "Caminho não comparado (ex: body.data.createdAt, repetível)")
+	expectCmd.Flags().StringArray("match", nil, "Caminho cujo valor deve corresponder à regex (caminho=regex, repetível)")
+	expectCmd.Flags().StringArray("tolerance", nil, "Diferença numérica aceita no caminho (caminho=número, repetível)")
+	addFilterFlags(expectCmd)
+	_ = expectCmd.MarkFlagRequired("golden")
+
+	expectCmd.ValidArgsFunction = completeEntities
+}
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/fixture"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para exportar as mensagens de uma entidade para NDJSON
+var exportCmd = &cobra.Command{
+	Use:   "export [entity] [file.ndjson]",
+	Short: "Exportar mensagens de uma entidade para NDJSON",
+	Long: `Espia, sem remover, todas as mensagens de uma fila, subscription ou dead-letter
+queue e grava uma mensagem completa por linha: body, propriedades de sistema
+(messageId, correlationId, subject, sessionId, sequenceNumber, ...) e
+propriedades de aplicação.
+
+O arquivo gerado pode ser versionado ou compartilhado e reenviado com 'import'.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runExport,
+}
+
+// Comando para reenviar mensagens exportadas
+var importCmd = &cobra.Command{
+	Use:   "import [entity] [file.ndjson]",
+	Short: "Reenviar mensagens exportadas com 'export'",
+	Long: `Reenvia para uma fila ou tópico as mensagens de um arquivo gerado por 'export',
+preservando MessageID, CorrelationID, SessionID e as demais propriedades.
+
+Campos atribuídos pelo broker (sequenceNumber, enqueuedTimeUtc, deliveryCount e
+dados de dead-letter) são ignorados: mensagens exportadas da dead-letter queue
+voltam como mensagens ativas. Agendamentos já vencidos são enviados na hora.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runImport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+	path := args[1]
+
+	deadLetter, _ := cmd.Flags().GetBool("dlq")
+	sessionID, _ := cmd.Flags().GetString("session")
+	limit, _ := cmd.Flags().GetInt("limit")
+	force, _ := cmd.Flags().GetBool("force")
+	if deadLetter && sessionID != "" {
+		return fmt.Errorf("--session não pode ser usado com --dlq: a dead-letter queue não usa sessões")
+	}
+	if limit <= 0 {
+		limit = math.MaxInt
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("o arquivo '%s' já existe (use --force para sobrescrever)", path)
+	}
+
+	source := entity.String()
+	if deadLetter {
+		source += " (dead-letter queue)"
+	}
+	_, _ = blue.Printf("📤 Exportando mensagens de '%s'...\n", source)
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	var messages []*servicebus.Message
+	switch {
+	case deadLetter:
+		messages, err = client.PeekDeadLetterMessages(cmd.Context(), entity, limit, 0)
+	case sessionID != "":
+		messages, _, err = client.PeekSessionMessages(cmd.Context(), entity, sessionID, limit, 0)
+	case entity.IsSubscription():
+		messages, err = client.PeekMessagesFromTopic(cmd.Context(), entity.Topic, entity.Subscription, limit, 0)
+	default:
+		messages, err = client.PeekMessagesFromQueue(cmd.Context(), entity.Queue, limit, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao espiar mensagens: %w", err)
+	}
+
+	if err := writeExportFile(path, messages); err != nil {
+		return err
+	}
+
+	if len(messages) == 0 {
+		_, _ = blue.Printf("ℹ️  Nenhuma mensagem encontrada: '%s' foi criado vazio\n", path)
+		return nil
+	}
+
+	first, last := messages[0].SequenceNumber, messages[len(messages)-1].SequenceNumber
+	_, _ = green.Printf("✅ %d mensagem(ns) exportada(s) para '%s' (seq %d a %d)\n", len(messages), path, first, last)
+	return nil
+}
+
+// writeExportFile grava uma mensagem por linha, substituindo o arquivo só depois de tudo gravado
+func writeExportFile(path string, messages []*servicebus.Message) error {
+	var buffer bytes.Buffer
+	for _, message := range messages {
+		line, err := servicebus.EncodeMessage(message)
+		if err != nil {
+			return fmt.Errorf("mensagem %s (seq %d): %w", message.MessageID, message.SequenceNumber, err)
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("erro ao criar diretório: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	newIDs, _ := cmd.Flags().GetBool("new-ids")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	path, err := resolveMessagePath(args[1])
+	if err != nil {
+		return err
+	}
+	_, _ = blue.Printf("📦 Carregando mensagens de '%s'...\n", path)
+	messages, origins, err := loadExportFile(path)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("nenhuma mensagem encontrada em '%s'", path)
+	}
+
+	started := time.Now()
+	for i, message := range messages {
+		message.PrepareForImport(started)
+		if newIDs {
+			message.MessageID = fmt.Sprintf("import-%d-%d", started.Unix(), i)
+		}
+	}
+	_, _ = green.Printf("✅ %d mensagem(ns) carregada(s)\n", len(messages))
+	fmt.Println()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	target := entity.SendTarget()
+	_, _ = blue.Printf("📥 Importando para '%s'...\n", target)
+	if entity.IsSubscription() {
+		_, _ = yellow.Printf("⚠️  O envio é feito no tópico '%s': todas as subscriptions receberão as mensagens\n", entity.Topic)
+	}
+	if !newIDs {
+		_, _ = blue.Println("ℹ️  MessageIDs preservados: com detecção de duplicadas ativa, use --new-ids para reimportar")
+	}
+
+	results, err := client.SendMessageBatch(cmd.Context(), target, messages)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar lote: %w", err)
+	}
+
+	sent, failed := 0, 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			_, _ = red.Printf("  ❌ [%d] %s (%s): %v\n", result.Index+1, result.MessageID, origins[result.Index], result.Err)
+			continue
+		}
+		sent++
+		if !quiet {
+			_, _ = green.Printf("  ✅ [%d] %s (%s)\n", result.Index+1, result.MessageID, origins[result.Index])
+		}
+	}
+
+	fmt.Println()
+	_, _ = blue.Println("📊 Resumo:")
+	fmt.Printf("  Total:     %d\n", len(results))
+	fmt.Printf("  Enviadas:  %d\n", sent)
+	fmt.Printf("  Falhas:    %d\n", failed)
+	fmt.Printf("  Duração:   %s\n", time.Since(started).Round(time.Millisecond))
+
+	if failed > 0 {
+		return fmt.Errorf("%d mensagem(ns) falharam", failed)
+	}
+	_, _ = green.Println("✅ Importação concluída!")
+	return nil
+}
+
+// loadExportFile lê as mensagens de um arquivo gerado por export, com a origem de cada linha
+func loadExportFile(path string) ([]*servicebus.Message, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), fixture.MaxNDJSONLineSize)
+
+	base := filepath.Base(path)
+	var messages []*servicebus.Message
+	var origins []string
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		message, err := servicebus.DecodeMessage([]byte(text))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", base, line, err)
+		}
+		messages = append(messages, message)
+		origins = append(origins, fmt.Sprintf("%s:%d", base, line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler %s: %w", base, err)
+	}
+
+	return messages, origins, nil
+}
+
+func init() {
+	exportCmd.Flags().Bool("dlq", false, "Exportar a dead-letter queue da entidade")
+	exportCmd.Flags().String("session", "", "Exportar apenas a sessão informada (entidades com RequiresSession)")
+	exportCmd.Flags().Int("limit", 0, "Número máximo de mensagens exportadas (0 = todas)")
+	exportCmd.Flags().BoolP("force", "f", false, "Sobrescrever o arquivo se ele já existir")
+
+	importCmd.Flags().Bool("new-ids", false, "Gerar novos MessageIDs em vez de preservar os originais")
+	importCmd.Flags().BoolP("quiet", "q", false, "Mostrar apenas falhas e o resumo")
+
+	exportCmd.ValidArgsFunction = completeEntityAndFile
+	importCmd.ValidArgsFunction = completeEntityAndFile
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// filterCondition representa uma condição "chave" (existência) ou "chave=valor"
+type filterCondition struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// messageFilter seleciona mensagens por caminho JSON no body ou por propriedade de aplicação
+type messageFilter struct {
+	paths      []filterCondition
+	properties []filterCondition
+}
+
+// parseFilterConditions interpreta argumentos no formato "chave" ou "chave=valor"
+func parseFilterConditions(values []string) ([]filterCondition, error) {
+	var conditions []filterCondition
+	for _, item := range values {
+		key, value, hasValue := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("filtro inválido '%s': use chave ou chave=valor", item)
+		}
+		conditions = append(conditions, filterCondition{key: key, value: value, hasValue: hasValue})
+	}
+	return conditions, nil
+}
+
+// getMessageFilter lê as flags --filter-path e --filter-property
+func getMessageFilter(cmd *cobra.Command) (messageFilter, error) {
+	rawPaths, _ := cmd.Flags().GetStringArray("filter-path")
+	rawProperties, _ := cmd.Flags().GetStringArray("filter-property")
+
+	paths, err := parseFilterConditions(rawPaths)
+	if err != nil {
+		return messageFilter{}, err
+	}
+	properties, err := parseFilterConditions(rawProperties)
+	if err != nil {
+		return messageFilter{}, err
+	}
+
+	return messageFilter{paths: paths, properties: properties}, nil
+}
+
+// addFilterFlags adiciona as flags de filtro a um comando
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("filter-path", nil, "Filtrar por caminho JSON no body (ex: data.status=created ou data.pix)")
+	cmd.Flags().StringArray("filter-property", nil, "Filtrar por propriedade de aplicação (ex: eventType=pix.in)")
+}
+
+// IsEmpty indica se o filtro aceita qualquer mensagem
+func (f messageFilter) IsEmpty() bool {
+	return len(f.paths) == 0 && len(f.properties) == 0
+}
+
+// Match indica se a mensagem atende a todas as condições do filtro
+func (f messageFilter) Match(message *servicebus.Message) bool {
+	for _, condition := range f.paths {
+		value, found := utils.LookupJSONPath(message.Body, condition.key)
+		if !found || (condition.hasValue && utils.JSONValueString(value) != condition.value) {
+			return false
+		}
+	}
+
+	for _, condition := range f.properties {
+		value, found := message.Properties[condition.key]
+		if !found || (condition.hasValue && utils.JSONValueString(value) != condition.value) {
+			return false
+		}
+	}
+
+	return true
+}
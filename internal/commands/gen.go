@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"fin.orion.dev/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// Comando agrupador dos geradores de dados de teste
+var genCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "Gerar dados de teste brasileiros (CPF, CNPJ, chave PIX, conta)",
+	Long: `Gera valores com dígitos verificadores válidos para usar em payloads de teste.
+Os mesmos geradores estão disponíveis nas fixtures como {{cpf}}, {{cnpj}},
+{{pixKey}}, {{branch}}, {{account}}, {{ispb}} e {{brl}}.
+
+Cada valor é impresso em uma linha. Use --seed para repetir a mesma sequência.`,
+}
+
+// Comando para gerar CPFs
+var genCPFCmd = &cobra.Command{
+	Use:   "cpf",
+	Short: "Gerar CPFs válidos",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printGenerated(cmd, func(g *generator.Generator) (string, error) {
+			if masked, _ := cmd.Flags().GetBool("mask"); masked {
+				return generator.FormatCPF(g.CPF()), nil
+			}
+			return g.CPF(), nil
+		})
+	},
+}
+
+// Comando para gerar CNPJs
+var genCNPJCmd = &cobra.Command{
+	Use:   "cnpj",
+	Short: "Gerar CNPJs válidos",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printGenerated(cmd, func(g *generator.Generator) (string, error) {
+			if masked, _ := cmd.Flags().GetBool("mask"); masked {
+				return generator.FormatCNPJ(g.CNPJ()), nil
+			}
+			return g.CNPJ(), nil
+		})
+	},
+}
+
+// Comando para gerar chaves PIX
+var genPixKeyCmd = &cobra.Command{
+	Use:   "pix-key",
+	Short: "Gerar chaves PIX (cpf, cnpj, email, phone ou evp)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("type")
+		return printGenerated(cmd, func(g *generator.Generator) (string, error) {
+			return g.PixKey(kind)
+		})
+	},
+}
+
+// Comando para gerar agência e conta
+var genAccountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Gerar agência e conta com dígito verificador e o ISPB da instituição",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		return printGenerated(cmd, func(g *generator.Generator) (string, error) {
+			account := g.Account()
+			if !asJSON {
+				return fmt.Sprintf("%s %s (ISPB %s) agência %s conta %s-%s", account.Code, account.Name, account.ISPB, account.Branch, account.Number, account.Digit), nil
+			}
+			data, err := json.Marshal(account)
+			return string(data), err
+		})
+	},
+}
+
+// printGenerated imprime --count valores usando um gerador com a seed de --seed (aleatória se omitida)
+func printGenerated(cmd *cobra.Command, next func(*generator.Generator) (string, error)) error {
+	count, _ := cmd.Flags().GetInt("count")
+	if count < 1 {
+		return fmt.Errorf("--count deve ser maior que zero")
+	}
+
+	g := generator.NewRandom()
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		g = generator.New(seed)
+	}
+
+	for i := 0; i < count; i++ {
+		value, err := next(g)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+	}
+	return nil
+}
+
+func init() {
+	genCmd.AddCommand(genCPFCmd)
+	genCmd.AddCommand(genCNPJCmd)
+	genCmd.AddCommand(genPixKeyCmd)
+	genCmd.AddCommand(genAccountCmd)
+
+	genCmd.PersistentFlags().IntP("count", "n", 1, "Quantidade de valores")
+	genCmd.PersistentFlags().Uint64("seed", 0, "Seed para gerar sempre a mesma sequência")
+	genCPFCmd.Flags().Bool("mask", false, "Aplicar a máscara 000.000.000-00")
+	genCNPJCmd.Flags().Bool("mask", false, "Aplicar a máscara 00.000.000/0000-00")
+	genPixKeyCmd.Flags().String("type", "", "Tipo da chave: cpf, cnpj, email, phone ou evp (aleatório se omitido)")
+	genAccountCmd.Flags().Bool("json", false, "Imprimir cada conta como JSON")
+
+	_ = genPixKeyCmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(generator.PixKeyTypes, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"fin.orion.dev/internal/catalog"
+	"fin.orion.dev/internal/load"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para gerar carga em uma fila ou tópico
+var loadCmd = &cobra.Command{
+	Use:   "load [queue]",
+	Short: "Gerar carga em uma fila com taxa controlada e medir a latência",
+	Long: `Envia mensagens para uma fila ou tópico na taxa de --rate durante --duration,
+com até --concurrency envios simultâneos. O template da fixture é renderizado a
+cada envio, então {{uuid}}, {{cpf}} e afins geram valores novos por mensagem.
+Cada mensagem recebe MessageID e CorrelationID próprios.
+
+Com --reply, a entidade de resposta é espiada (sem remover mensagens) e a
+latência de ponta a ponta é medida até a resposta com o mesmo CorrelationID
+(ou cujo CorrelationID seja o MessageID enviado). Como no request, use uma
+entidade de resposta sem consumidor ativo: respostas consumidas entre duas
+consultas não são vistas e aparecem como sem resposta.
+
+
+  orion-dev load sbq.pismo.transaction.creation --rate 200/s --duration 2m \
+    --fixture transacao.json --reply sbt.orion.core/subscription.orion.core \
+    --csv carga.csv --json carga.json
+
+Se os workers não acompanharem a taxa, os envios atrasam e a taxa obtida
+aparece abaixo da configurada. Ctrl+C interrompe e mostra o resultado parcial.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLoad,
+}
+
+func runLoad(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	target, err := parseDestinationArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	rateText, _ := cmd.Flags().GetString("rate")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	fixtureName, _ := cmd.Flags().GetString("fixture")
+	replyName, _ := cmd.Flags().GetString("reply")
+	replyTimeout, _ := cmd.Flags().GetDuration("reply-timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	csvPath, _ := cmd.Flags().GetString("csv")
+	jsonPath, _ := cmd.Flags().GetString("json")
+
+	rate, err := load.ParseRate(rateText)
+	if err != nil {
+		return fmt.Errorf("--rate: %w", err)
+	}
+
+	build, err := buildLoadMessages(cmd, target, fixtureName)
+	if err != nil {
+		return err
+	}
+
+	config := load.Config{
+		Target:       target,
+		Rate:         rate,
+		Duration:     duration,
+		Concurrency:  concurrency,
+		ReplyTimeout: replyTimeout,
+		Build:        build,
+		OnProgress: func(progress load.Progress) {
+			fmt.Printf("  ⏱️  %4.0fs  enviadas %d  falhas %d", progress.Elapsed.Seconds(), progress.Sent, progress.Failed)
+			if replyName != "" {
+				fmt.Printf("  respostas %d", progress.Replied)
+			}
+			fmt.Println()
+		},
+	}
+	if replyName != "" {
+		reply, err := parseEntityArg(replyName)
+		if err != nil {
+			return fmt.Errorf("--reply: %w", err)
+		}
+		config.Reply = &reply
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	// Aquecer a conexão para que o handshake não entre na medição
+	if err := client.TestConnection(cmd.Context(), target); err != nil {
+		return err
+	}
+
+	_, _ = blue.Printf("🚚 Carga em '%s': %.1f msg/s por %s, concorrência %d\n", target, rate, duration, concurrency)
+	if config.Reply != nil {
+		_, _ = blue.Printf("⏳ Medindo respostas em '%s' (espera final de até %s)\n", config.Reply, replyTimeout)
+	}
+	fmt.Println()
+
+	result, runErr := load.Run(cmd.Context(), scenarioBus{client: client, interval: interval}, config)
+	if result == nil {
+		return runErr
+	}
+
+	fmt.Println()
+	if result.Canceled {
+		_, _ = yellow.Println("⚠️  Execução interrompida, resultado parcial")
+	}
+	printLoadResult(result)
+
+	for _, export := range []struct {
+		path  string
+		write func(io.Writer, *load.Result) error
+	}{{csvPath, load.WriteCSV}, {jsonPath, load.WriteJSON}} {
+		if err := writeLoadReport(export.path, result, export.write); err != nil {
+			return err
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	if result.Failed == 0 && result.Missing == 0 && !result.Canceled {
+		_, _ = green.Println("✅ Carga concluída sem falhas")
+	}
+	return nil
+}
+
+// buildLoadMessages prepara a montagem das mensagens: a fixture é lida uma vez e renderizada a cada envio.
+// Sem --fixture é usado o tipo simple do catálogo. O schema do destino é verificado na primeira mensagem.
+// As flags de template (e a --seed) são lidas uma única vez, para que a seed não reinicie a cada envio.
+func buildLoadMessages(cmd *cobra.Command, target, fixtureName string) (func(int) (*servicebus.Message, error), error) {
+	vars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixtureName == "" {
+		return func(int) (*servicebus.Message, error) {
+			message, _, err := renderCatalogMessage(&catalog.Simple, target, "load", vars, sets)
+			return message, err
+		}, nil
+	}
+
+	path, err := resolveMessagePath(fixtureName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler fixture: %w", err)
+	}
+
+	first, err := renderTemplate(path, data, vars, sets)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMessageSchema(cmd, target, first); err != nil {
+		return nil, err
+	}
+	if _, err := parseMessage(first); err != nil {
+		return nil, err
+	}
+
+	return func(int) (*servicebus.Message, error) {
+		rendered, err := renderTemplate(path, data, vars, sets)
+		if err != nil {
+			return nil, err
+		}
+		return parseMessage(rendered)
+	}, nil
+}
+
+// printLoadResult mostra o resumo da execução
+func printLoadResult(result *load.Result) {
+	blue := color.New(color.FgBlue)
+	red := color.New(color.FgRed)
+
+	_, _ = blue.Println("📊 Resultados:")
+	fmt.Printf("  Enviadas:   %d de %d em %s (%.1f msg/s, configurado %.1f msg/s)\n",
+		result.Sent, result.Planned, result.Elapsed.Round(time.Millisecond), result.AchievedRate, result.Rate)
+	fmt.Printf("  Falhas:     %d\n", result.Failed)
+	if result.ReplyLatency != nil {
+		fmt.Printf("  Respostas:  %d (%d sem resposta)\n", result.Replied, result.Missing)
+	}
+	fmt.Println()
+
+	fmt.Printf("  %-14s %10s %10s %10s %10s %10s %10s\n", "Latência", "Mín", "Média", "p50", "p95", "p99", "Máx")
+	printLoadStats("Envio", result.SendLatency)
+	if result.ReplyLatency != nil {
+		printLoadStats("Ponta a ponta", *result.ReplyLatency)
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Println()
+		_, _ = red.Println("❌ Erros:")
+		messages := make([]string, 0, len(result.Errors))
+		for message := range result.Errors {
+			messages = append(messages, message)
+		}
+		sort.Slice(messages, func(i, j int) bool { return result.Errors[messages[i]] > result.Errors[messages[j]] })
+		for _, message := range messages {
+			_, _ = red.Printf("  %d× %s\n", result.Errors[message], message)
+		}
+	}
+	fmt.Println()
+}
+
+func printLoadStats(name string, stats load.Stats) {
+	if stats.Count == 0 {
+		fmt.Printf("  %-14s %10s\n", name, "-")
+		return
+	}
+	round := func(d time.Duration) time.Duration { return d.Round(100 * time.Microsecond) }
+	fmt.Printf("  %-14s %10s %10s %10s %10s %10s %10s\n", name,
+		round(stats.Min), round(stats.Avg), round(stats.P50), round(stats.P95), round(stats.P99), round(stats.Max))
+}
+
+// writeLoadReport grava o resultado no arquivo, se informado
+func writeLoadReport(path string, result *load.Result, write func(io.Writer, *load.Result) error) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("erro ao criar relatório: %w", err)
+	}
+	if err := write(file, result); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar relatório: %w", err)
+	}
+
+	_, _ = color.New(color.FgBlue).Printf("📝 Resultado gravado em %s\n", path)
+	return nil
+}
+
+func init() {
+	loadCmd.Flags().String("rate", "50/s", "Taxa de envio (ex: 200/s, 600/m)")
+	loadCmd.Flags().Duration("duration", 30*time.Second, "Tempo de envio")
+	loadCmd.Flags().Int("concurrency", 20, "Número de envios simultâneos")
+	loadCmd.Flags().String("fixture", "", "Fixture da pasta messages (padrão: tipo simple do catálogo)")
+	loadCmd.Flags().String("reply", "", "Fila ou tópico/subscription onde a resposta é publicada, para medir a latência de ponta a ponta")
+	loadCmd.Flags().Duration("reply-timeout", 30*time.Second, "Espera pelas respostas pendentes depois do último envio")
+	loadCmd.Flags().Duration("interval", 200*time.Millisecond, "Intervalo entre consultas à entidade de resposta")
+	loadCmd.Flags().String("csv", "", "Exportar as medições por mensagem em CSV")
+	loadCmd.Flags().String("json", "", "Exportar o resultado em JSON")
+	addTemplateFlags(loadCmd)
+	addSchemaFlags(loadCmd)
+
+	loadCmd.ValidArgsFunction = completeCatalogTargets
+	_ = loadCmd.RegisterFlagCompletionFunc("fixture", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeMessageFiles(cmd, nil, toComplete)
+	})
+	_ = loadCmd.RegisterFlagCompletionFunc("reply", completeEntities)
+}
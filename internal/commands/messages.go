@@ -1,40 +1,35 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/fixture"
 	"fin.orion.dev/internal/proxy"
 	"fin.orion.dev/internal/servicebus"
-	"fin.orion.dev/internal/utils"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
-// Lista de filas válidas
-var validQueues = []string{
-	"sbq.pismo.onboarding.succeeded",
-	"sbq.pismo.transaction.creation",
-	"sbq.pismo.pix.transaction.in",
-	"sbq.pismo.all",
-	"sbq.orion.pixqrcode.persist",
-	"sbq.orion.transaction.chained",
-	"sbq.orion.billet-payment.verify",
-	"sbq.pismo.authorization.cancelation",
-	"sbq.pismo.ted.transaction",
-	"sbq.pix.recurrence.payment.order.failure",
-}
-
 // Comando para verificar mensagens
 var checkMessagesCmd = &cobra.Command{
 	Use:   "check-messages",
 	Short: "Verificar mensagens do Service Bus",
-	Long:  `Verifica mensagens das filas e tópicos do Service Bus.`,
-	RunE:  runCheckMessages,
+	Long: `Mostra um painel com uma linha por fila e subscription declarada na configuração
+do emulador: mensagens ativas, agendadas, na dead-letter queue, o horário da
+mensagem ativa mais antiga e o ID da mais recente.
+
+As mensagens são apenas espiadas (peek); nada é bloqueado ou removido. Em
+entidades com sessão apenas a dead-letter queue é contada.`,
+	Args: cobra.NoArgs,
+	RunE: runCheckMessages,
 }
 
 // Comando para enviar mensagens
@@ -46,47 +41,68 @@ var pushMessageCmd = &cobra.Command{
 	RunE:  runPushMessage,
 }
 
+// Comando para cancelar mensagens agendadas
+var cancelScheduledCmd = &cobra.Command{
+	Use:   "cancel-scheduled [queue] [sequence...]",
+	Short: "Cancelar mensagem agendada",
+	Long:  `Cancela mensagens agendadas com push-message/send-json --at/--in usando o sequence number retornado.`,
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runCancelScheduled,
+}
+
 // Comando para verificar filas
 var checkQueueCmd = &cobra.Command{
 	Use:   "check-queue [queue]",
 	Short: "Verificar mensagens da fila",
-	Long:  `Verifica mensagens de uma fila específica.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runCheckQueue,
+	Long: `Verifica mensagens de uma fila específica.
+
+Por padrão as mensagens são apenas espiadas (--peek) e a fila não é alterada.
+Use --complete, --abandon ou --dead-letter para liquidar as mensagens lidas e
+--max/--from-sequence para paginar filas com muitas mensagens.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCheckQueue,
 }
 
 // Comando para verificar tópicos
 var checkTopicCmd = &cobra.Command{
 	Use:   "check-topic [subscription]",
 	Short: "Verificar mensagens do tópico",
-	Long:  `Verifica mensagens de um tópico específico.`,
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runCheckTopic,
+	Long: `Verifica mensagens de um tópico específico.
+
+Por padrão as mensagens são apenas espiadas (--peek) e a subscription não é alterada.
+Use --complete, --abandon ou --dead-letter para liquidar as mensagens lidas e
+--max/--from-sequence para paginar subscriptions com muitas mensagens.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCheckTopic,
 }
 
 // Comando para listar
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Listar recursos",
-	Long:  `Lista filas, arquivos JSON e outros recursos disponíveis.`,
+	Long:  `Lista filas, tópicos, subscriptions e arquivos JSON disponíveis.`,
 	RunE:  runList,
 }
 
 // Comando para teste rápido de mensagem
 var testMessageCmd = &cobra.Command{
-	Use:   "test-message",
+	Use:   "test-message [queue]",
 	Short: "Teste rápido de envio de mensagem",
-	Long:  `Executa um teste rápido de envio de mensagem para verificar conectividade.`,
-	RunE:  runTestMessage,
+	Long: `Executa um teste rápido de envio de mensagem para verificar conectividade.
+Sem argumentos, usa a primeira fila declarada na configuração do emulador.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTestMessage,
 }
 
 // Comando para enviar mensagem de teste para fila
 var sendQueueCmd = &cobra.Command{
 	Use:   "send-queue [queue] [type]",
 	Short: "Enviar mensagem de teste para fila",
-	Long:  `Envia uma mensagem de teste para uma fila específica.`,
-	Args:  cobra.RangeArgs(1, 2),
-	RunE:  runSendQueue,
+	Long: `Envia para a fila uma mensagem de um tipo do catálogo (pasta catalog). Sem o
+tipo, envia uma mensagem simple. Apenas os tipos compatíveis com a fila são
+aceitos; veja-os com 'orion-dev catalog list --target <fila>'.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSendQueue,
 }
 
 // Comando para enviar mensagem JSON para fila
@@ -102,7 +118,7 @@ var sendJsonCmd = &cobra.Command{
 var listQueuesCmd = &cobra.Command{
 	Use:   "list-queues",
 	Short: "Listar filas disponíveis",
-	Long:  `Lista todas as filas disponíveis no Service Bus.`,
+	Long:  `Lista as filas declaradas na configuração do emulador e suas propriedades.`,
 	RunE:  runListQueues,
 }
 
@@ -118,9 +134,11 @@ var listMessagesCmd = &cobra.Command{
 var validateJsonCmd = &cobra.Command{
 	Use:   "validate-json [file]",
 	Short: "Validar arquivo JSON",
-	Long:  `Valida se um arquivo contém JSON válido.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runValidateJson,
+	Long: `Valida se um arquivo contém JSON válido e, com --schema, se ele atende a um
+JSON Schema. --schema aceita o caminho do schema ou o nome de uma fila ou tópico
+com schema na pasta schemas. Fixtures com template são renderizadas antes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runValidateJson,
 }
 
 // Comando para formatar JSON
@@ -149,22 +167,6 @@ var proxyCmd = &cobra.Command{
 	RunE:  runProxy,
 }
 
-func runCheckMessages(cmd *cobra.Command, args []string) error {
-	blue := color.New(color.FgBlue)
-	_, _ = blue.Println("📨 Verificando mensagens do Service Bus...")
-
-	client, err := servicebus.NewClient()
-	if err != nil {
-		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
-	}
-	defer func() { _ = client.Close() }()
-
-	// Verificar status do ambiente
-	checkEnvironmentStatus()
-
-	return nil
-}
-
 func runPushMessage(cmd *cobra.Command, args []string) error {
 	queueName := args[0]
 	jsonFile := args[1]
@@ -180,33 +182,44 @@ func runPushMessage(cmd *cobra.Command, args []string) error {
 	// Validar nome da fila
 	if !isValidQueue(queueName) {
 		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
-		_, _ = blue.Println("Filas válidas:")
-		for _, queue := range validQueues {
-			_, _ = blue.Printf("  - %s\n", queue)
-		}
+		printValidQueues()
 		return fmt.Errorf("fila inválida")
 	}
 
+	// Verificar agendamento
+	enqueueTime, scheduled, err := getScheduleTime(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Carregar mensagem do arquivo JSON
 	_, _ = blue.Println("📄 Carregando mensagem do arquivo...")
-	message, err := loadMessageFromFile(jsonFile)
+	message, err := loadMessageForSend(cmd, queueName, jsonFile)
 	if err != nil {
 		return fmt.Errorf("erro ao carregar arquivo: %w", err)
 	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
 	_, _ = green.Println("✅ Mensagem carregada com sucesso")
 
 	// Conectar ao Service Bus
 	_, _ = blue.Println("🔗 Conectando ao Service Bus...")
-	client, err := servicebus.NewClient()
+	client, err := newServiceBusClient(cmd)
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
-	defer func() { _ = client.Close() }()
+	defer closeClient(cmd, client)
 	_, _ = green.Println("✅ Conectado ao Service Bus")
 
+	// Agendar mensagem
+	if scheduled {
+		return scheduleMessage(cmd.Context(), client, queueName, message, enqueueTime)
+	}
+
 	// Enviar mensagem
 	_, _ = blue.Println("📤 Enviando mensagem...")
-	if err := client.SendMessageToQueue(queueName, message); err != nil {
+	if err := client.SendMessageToQueue(cmd.Context(), queueName, message); err != nil {
 		return fmt.Errorf("erro ao enviar mensagem: %w", err)
 	}
 
@@ -214,6 +227,40 @@ func runPushMessage(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCancelScheduled(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	// Validar nome da fila
+	if !isValidQueue(queueName) {
+		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
+		return fmt.Errorf("fila inválida")
+	}
+
+	sequenceNumbers, err := servicebus.ParseSequenceNumbers(args[1:])
+	if err != nil {
+		return err
+	}
+
+	_, _ = blue.Printf("🗑️  Cancelando %d mensagem(ns) agendada(s) na fila '%s'...\n", len(sequenceNumbers), queueName)
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	if err := client.CancelScheduledMessages(cmd.Context(), queueName, sequenceNumbers...); err != nil {
+		return err
+	}
+
+	_, _ = green.Println("✅ Agendamento cancelado")
+	return nil
+}
+
 func runCheckQueue(cmd *cobra.Command, args []string) error {
 	queueName := args[0]
 
@@ -227,22 +274,19 @@ func runCheckQueue(cmd *cobra.Command, args []string) error {
 	// Validar nome da fila
 	if !isValidQueue(queueName) {
 		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
-		_, _ = blue.Println("Filas válidas:")
-		for _, queue := range validQueues {
-			_, _ = blue.Printf("  - %s\n", queue)
-		}
+		printValidQueues()
 		return fmt.Errorf("fila inválida")
 	}
 
 	// Conectar ao Service Bus
-	client, err := servicebus.NewClient()
+	client, err := newServiceBusClient(cmd)
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
-	defer func() { _ = client.Close() }()
+	defer closeClient(cmd, client)
 
 	// Receber mensagens
-	messages, err := client.ReceiveMessagesFromQueue(queueName, 10)
+	messages, err := receiveForCheck(cmd, client, servicebus.Entity{Queue: queueName})
 	if err != nil {
 		return fmt.Errorf("erro ao receber mensagens: %w", err)
 	}
@@ -250,24 +294,8 @@ func runCheckQueue(cmd *cobra.Command, args []string) error {
 	if len(messages) == 0 {
 		_, _ = blue.Println("ℹ️  Nenhuma mensagem encontrada na fila")
 	} else {
-		_, _ = green.Printf("📨 Encontradas %d mensagem(ns):\n", len(messages))
-		fmt.Println()
-
-		for i, message := range messages {
-			_, _ = blue.Printf("--- Mensagem %d ---\n", i+1)
-			fmt.Printf("ID: %s\n", message.MessageID)
-			fmt.Printf("Correlation ID: %s\n", message.CorrelationID)
-			fmt.Printf("Content Type: %s\n", message.ContentType)
-			if message.EnqueuedTimeUtc != nil {
-				fmt.Printf("Timestamp: %s\n", message.EnqueuedTimeUtc.Format(time.RFC3339))
-			}
-			fmt.Printf("Delivery Count: %d\n", message.DeliveryCount)
-			fmt.Println("Body:")
-
-			bodyJSON, _ := json.MarshalIndent(message.Body, "", "  ")
-			fmt.Println(string(bodyJSON))
-			fmt.Println()
-		}
+		printReceivedMessages(messages)
+		printNextPageHint(cmd, messages)
 	}
 
 	_, _ = green.Println("✅ Verificação concluída")
@@ -280,22 +308,37 @@ func runCheckTopic(cmd *cobra.Command, args []string) error {
 		subscription = args[0]
 	}
 
+	topic, _ := cmd.Flags().GetString("topic")
+
 	blue := color.New(color.FgBlue)
 	green := color.New(color.FgGreen)
 
-	_, _ = blue.Println("🔍 Verificando mensagens do tópico 'sbt.orion.core'...")
+	// --topic substitui o par sbt.orion.core/subscription.orion.core fixo no código; o tópico e a
+	// subscription precisam estar declarados na configuração do emulador
+	if _, err := loadTopic(topic); err != nil {
+		return err
+	}
+	reg, err := loadRegistry()
+	if err != nil {
+		return fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+	if err := reg.ValidateEntity(servicebus.Entity{Topic: topic, Subscription: subscription}); err != nil {
+		return err
+	}
+
+	_, _ = blue.Printf("🔍 Verificando mensagens do tópico '%s'...\n", topic)
 	_, _ = blue.Printf("📋 Subscription: %s\n", subscription)
 	fmt.Println()
 
 	// Conectar ao Service Bus
-	client, err := servicebus.NewClient()
+	client, err := newServiceBusClient(cmd)
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
-	defer func() { _ = client.Close() }()
+	defer closeClient(cmd, client)
 
 	// Receber mensagens
-	messages, err := client.ReceiveMessagesFromTopic("sbt.orion.core", subscription, 10)
+	messages, err := receiveForCheck(cmd, client, servicebus.Entity{Topic: topic, Subscription: subscription})
 	if err != nil {
 		return fmt.Errorf("erro ao receber mensagens: %w", err)
 	}
@@ -303,23 +346,8 @@ func runCheckTopic(cmd *cobra.Command, args []string) error {
 	if len(messages) == 0 {
 		_, _ = blue.Println("ℹ️  Nenhuma mensagem encontrada no tópico")
 	} else {
-		_, _ = green.Printf("📨 Encontradas %d mensagem(ns):\n", len(messages))
-		fmt.Println()
-
-		for i, message := range messages {
-			_, _ = blue.Printf("--- Mensagem %d ---\n", i+1)
-			fmt.Printf("ID: %s\n", message.MessageID)
-			fmt.Printf("Correlation ID: %s\n", message.CorrelationID)
-			fmt.Printf("Content Type: %s\n", message.ContentType)
-			if message.EnqueuedTimeUtc != nil {
-				fmt.Printf("Timestamp: %s\n", message.EnqueuedTimeUtc.Format(time.RFC3339))
-			}
-			fmt.Println("Body:")
-
-			bodyJSON, _ := json.MarshalIndent(message.Body, "", "  ")
-			fmt.Println(string(bodyJSON))
-			fmt.Println()
-		}
+		printReceivedMessages(messages)
+		printNextPageHint(cmd, messages)
 	}
 
 	_, _ = green.Println("✅ Verificação concluída")
@@ -333,13 +361,28 @@ func runList(cmd *cobra.Command, args []string) error {
 	_, _ = blue.Println("📋 Listando recursos disponíveis...")
 	fmt.Println()
 
+	reg, err := loadRegistry()
+	if err != nil {
+		return fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+
 	// Listar filas
 	_, _ = blue.Println("📨 Filas disponíveis:")
-	for i, queue := range validQueues {
+	for i, queue := range reg.QueueNames() {
 		_, _ = green.Printf("  %d. %s\n", i+1, queue)
 	}
 	fmt.Println()
 
+	// Listar tópicos e subscriptions
+	_, _ = blue.Println("📢 Tópicos disponíveis:")
+	for i, topic := range reg.Topics() {
+		_, _ = green.Printf("  %d. %s\n", i+1, topic.Name)
+		for _, subscription := range topic.Subscriptions {
+			_, _ = green.Printf("     └─ %s\n", subscription.Name)
+		}
+	}
+	fmt.Println()
+
 	// Listar arquivos JSON
 	_, _ = blue.Println("📁 Arquivos JSON disponíveis:")
 	files, err := listJSONFiles()
@@ -365,13 +408,34 @@ func runTestMessage(cmd *cobra.Command, args []string) error {
 	_, _ = blue.Println("🔗 Testando conexão com Service Bus...")
 	fmt.Println()
 
-	client, err := servicebus.NewClient()
+	// Usar a fila informada ou a primeira fila declarada no emulador
+	reg, err := loadRegistry()
+	if err != nil {
+		return fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+	queueNames := reg.QueueNames()
+	if len(queueNames) == 0 {
+		return fmt.Errorf("nenhuma fila declarada em %s", emulator.DefaultConfigPath)
+	}
+	queueName := queueNames[0]
+	if len(args) > 0 {
+		queueName = args[0]
+		if !reg.HasQueue(queueName) {
+			printValidQueues()
+			return fmt.Errorf("fila inválida")
+		}
+	}
+
+	client, err := newServiceBusClient(cmd)
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
-	defer func() { _ = client.Close() }()
+	defer closeClient(cmd, client)
 
-	queueName := "test-queue" // Nome da fila de teste
+	if err := client.TestConnection(cmd.Context(), queueName); err != nil {
+		return err
+	}
+	_, _ = green.Println("✅ Conexão com Service Bus estabelecida com sucesso")
 	message := &servicebus.Message{
 		Body:          map[string]string{"message": "Hello from test-message!"},
 		MessageID:     fmt.Sprintf("test-%d", time.Now().Unix()),
@@ -379,7 +443,7 @@ func runTestMessage(cmd *cobra.Command, args []string) error {
 		ContentType:   "application/json",
 	}
 
-	if err := client.SendMessageToQueue(queueName, message); err != nil {
+	if err := client.SendMessageToQueue(cmd.Context(), queueName, message); err != nil {
 		return fmt.Errorf("erro ao enviar mensagem de teste: %w", err)
 	}
 
@@ -404,104 +468,32 @@ func runSendQueue(cmd *cobra.Command, args []string) error {
 	// Validar nome da fila
 	if !isValidQueue(queueName) {
 		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
-		_, _ = blue.Println("Filas válidas:")
-		for _, queue := range validQueues {
-			_, _ = blue.Printf("  - %s\n", queue)
-		}
+		printValidQueues()
 		return fmt.Errorf("fila inválida")
 	}
 
-	// Conectar ao Service Bus
-	client, err := servicebus.NewClient()
+	// Criar mensagem a partir do tipo do catálogo
+	catalogType, err := resolveCatalogType(messageType, queueName)
 	if err != nil {
-		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+		return err
 	}
-	defer func() { _ = client.Close() }()
-
-	// Criar mensagem baseada no tipo
-	var messageBody interface{}
-	switch messageType {
-	case "simple":
-		messageBody = map[string]interface{}{
-			"type":      "test",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"message": "Mensagem de teste simples para fila",
-				"random":  time.Now().Unix(),
-				"queue":   queueName,
-			},
-		}
-	case "pix-recurrence":
-		messageBody = map[string]interface{}{
-			"type":      "pix.recurrence.payment.order",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"orderId":         fmt.Sprintf("order-%d", time.Now().Unix()),
-				"amount":          100.5,
-				"description":     "Teste PIX Recurrence para fila",
-				"recurrenceType":  "monthly",
-				"nextPaymentDate": time.Now().AddDate(0, 1, 0).Format(time.RFC3339),
-				"queue":           queueName,
-			},
-		}
-	case "transaction":
-		messageBody = map[string]interface{}{
-			"type":      "transaction.created",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"transactionId": fmt.Sprintf("txn-%d", time.Now().Unix()),
-				"amount":        250.0,
-				"currency":      "BRL",
-				"description":   "Teste de transação para fila",
-				"status":        "pending",
-				"queue":         queueName,
-			},
-		}
-	case "pismo-transaction":
-		messageBody = map[string]interface{}{
-			"type":      "pismo.transaction.creation",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"transactionId": fmt.Sprintf("pismo-txn-%d", time.Now().Unix()),
-				"amount":        150.0,
-				"currency":      "BRL",
-				"description":   "Teste transação Pismo",
-				"status":        "created",
-				"accountId":     "acc-123456",
-				"queue":         queueName,
-			},
-		}
-	case "authorization-cancelled":
-		messageBody = map[string]interface{}{
-			"type":      "pismo.authorization.cancelled",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"authorizationId": fmt.Sprintf("auth-%d", time.Now().Unix()),
-				"reason":          "user_cancelled",
-				"description":     "Autorização cancelada pelo usuário",
-				"queue":           queueName,
-			},
-		}
-	default:
-		messageBody = map[string]interface{}{
-			"type":      "test",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"data": map[string]interface{}{
-				"message": fmt.Sprintf("Mensagem de teste (tipo: %s)", messageType),
-				"queue":   queueName,
-			},
-		}
+	message, err := buildCatalogMessage(cmd, catalogType, queueName, "send-queue")
+	if err != nil {
+		return err
+	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
 	}
 
-	// Enviar mensagem
-	message := &servicebus.Message{
-		Body:          messageBody,
-		MessageID:     fmt.Sprintf("send-queue-%s-%d", messageType, time.Now().Unix()),
-		CorrelationID: fmt.Sprintf("corr-%d", time.Now().Unix()),
-		ContentType:   "application/json",
+	// Conectar ao Service Bus
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
+	defer closeClient(cmd, client)
 
-	if err := client.SendMessageToQueue(queueName, message); err != nil {
+	// Enviar mensagem
+	if err := client.SendMessageToQueue(cmd.Context(), queueName, message); err != nil {
 		return fmt.Errorf("erro ao enviar mensagem de teste: %w", err)
 	}
 
@@ -524,28 +516,39 @@ func runSendJson(cmd *cobra.Command, args []string) error {
 	// Validar nome da fila
 	if !isValidQueue(queueName) {
 		_, _ = red.Printf("❌ Fila '%s' não é válida\n", queueName)
-		_, _ = blue.Println("Filas válidas:")
-		for _, queue := range validQueues {
-			_, _ = blue.Printf("  - %s\n", queue)
-		}
+		printValidQueues()
 		return fmt.Errorf("fila inválida")
 	}
 
+	// Verificar agendamento
+	enqueueTime, scheduled, err := getScheduleTime(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Carregar mensagem do arquivo JSON
-	message, err := loadMessageFromFile(jsonFile)
+	message, err := loadMessageForSend(cmd, queueName, jsonFile)
 	if err != nil {
 		return fmt.Errorf("erro ao carregar arquivo: %w", err)
 	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
 
 	// Conectar ao Service Bus
-	client, err := servicebus.NewClient()
+	client, err := newServiceBusClient(cmd)
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
 	}
-	defer func() { _ = client.Close() }()
+	defer closeClient(cmd, client)
+
+	// Agendar mensagem
+	if scheduled {
+		return scheduleMessage(cmd.Context(), client, queueName, message, enqueueTime)
+	}
 
 	// Enviar mensagem
-	if err := client.SendMessageToQueue(queueName, message); err != nil {
+	if err := client.SendMessageToQueue(cmd.Context(), queueName, message); err != nil {
 		return fmt.Errorf("erro ao enviar mensagem JSON: %w", err)
 	}
 
@@ -557,14 +560,32 @@ func runListQueues(cmd *cobra.Command, args []string) error {
 	blue := color.New(color.FgBlue)
 	green := color.New(color.FgGreen)
 
-	_, _ = blue.Println("📋 Listando filas disponíveis no Service Bus...")
+	_, _ = blue.Printf("📋 Listando filas declaradas em %s...\n", emulator.DefaultConfigPath)
 	fmt.Println()
 
-	// Como o client não tem método ListQueues, vamos listar as filas válidas
-	_, _ = green.Printf("📨 Filas válidas (%d):\n", len(validQueues))
+	reg, err := loadRegistry()
+	if err != nil {
+		return fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+
+	queues := reg.Queues()
+	_, _ = green.Printf("📨 Filas válidas (%d):\n", len(queues))
 	fmt.Println()
-	for i, queue := range validQueues {
-		_, _ = green.Printf("  %d. %s\n", i+1, queue)
+	for i, queue := range queues {
+		properties := queue.Properties
+		_, _ = green.Printf("  %d. %s\n", i+1, queue.Name)
+		fmt.Printf("     RequiresSession: %t  MaxDeliveryCount: %d  LockDuration: %s\n",
+			properties.RequiresSession, properties.MaxDeliveryCount, properties.LockDuration)
+		fmt.Printf("     DefaultMessageTimeToLive: %s  DeadLetteringOnMessageExpiration: %t\n",
+			properties.DefaultMessageTimeToLive, properties.DeadLetteringOnMessageExpiration)
+		fmt.Printf("     RequiresDuplicateDetection: %t  DuplicateDetectionHistoryTimeWindow: %s\n",
+			properties.RequiresDuplicateDetection, properties.DuplicateDetectionHistoryTimeWindow)
+		if properties.ForwardTo != "" {
+			fmt.Printf("     ForwardTo: %s\n", properties.ForwardTo)
+		}
+		if properties.ForwardDeadLetteredMessagesTo != "" {
+			fmt.Printf("     ForwardDeadLetteredMessagesTo: %s\n", properties.ForwardDeadLetteredMessagesTo)
+		}
 	}
 
 	return nil
@@ -610,14 +631,22 @@ func runValidateJson(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("erro ao ler arquivo: %w", err)
 	}
 
-	var jsonData interface{}
-	if err := json.Unmarshal(data, &jsonData); err != nil {
+	// Fixtures com template são validadas depois de renderizadas
+	vars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if data, err = renderTemplate(jsonFile, data, vars, sets); err != nil {
 		_, _ = red.Printf("❌ JSON inválido: %s\n", err.Error())
 		return fmt.Errorf("json inválido")
 	}
-
 	_, _ = green.Println("✅ JSON válido!")
-	return nil
+
+	schemaName, _ := cmd.Flags().GetString("schema")
+	if schemaName == "" {
+		return nil
+	}
+	return validateWithSchema(schemaName, data)
 }
 
 func runFormatJson(cmd *cobra.Command, args []string) error {
@@ -699,38 +728,270 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func isValidQueue(queueName string) bool {
-	for _, queue := range validQueues {
-		if queue == queueName {
-			return true
+// getScheduleTime lê as flags --at/--in e indica se a mensagem deve ser agendada
+func getScheduleTime(cmd *cobra.Command) (time.Time, bool, error) {
+	at, _ := cmd.Flags().GetString("at")
+	in, _ := cmd.Flags().GetDuration("in")
+	return servicebus.ParseScheduleTime(at, in, time.Now())
+}
+
+// scheduleMessage agenda a mensagem e mostra o sequence number para cancelamento
+func scheduleMessage(ctx context.Context, client *servicebus.Client, queueName string, message *servicebus.Message, enqueueTime time.Time) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	if enqueueTime.Before(time.Now()) {
+		_, _ = yellow.Println("⚠️  Horário no passado: a mensagem será enfileirada imediatamente")
+	}
+
+	_, _ = blue.Printf("📅 Agendando mensagem para %s...\n", enqueueTime.Format(time.RFC3339))
+	sequenceNumber, err := client.ScheduleMessage(ctx, queueName, message, enqueueTime)
+	if err != nil {
+		return err
+	}
+
+	_, _ = green.Printf("✅ Mensagem agendada (sequence number: %d)\n", sequenceNumber)
+	_, _ = blue.Printf("💡 Para cancelar: orion-dev cancel-scheduled %s %d\n", queueName, sequenceNumber)
+	return nil
+}
+
+// applySendFlags aplica à mensagem as flags de envio que alteram seu conteúdo
+func applySendFlags(cmd *cobra.Command, message *servicebus.Message) error {
+	if sessionID, _ := cmd.Flags().GetString("session"); sessionID != "" {
+		message.SessionID = sessionID
+	}
+
+	rawProperties, _ := cmd.Flags().GetStringArray("property")
+	if len(rawProperties) == 0 {
+		return nil
+	}
+
+	properties, err := parseKeyValues(rawProperties)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]interface{}, len(message.Properties)+len(properties))
+	for key, value := range message.Properties {
+		merged[key] = value
+	}
+	for key, value := range properties {
+		merged[key] = value
+	}
+	message.Properties = merged
+	return nil
+}
+
+// addSendFlags adiciona as flags de agendamento, sessão e propriedades a um comando de envio
+func addSendFlags(cmd *cobra.Command) {
+	cmd.Flags().String("at", "", "Agendar o enfileiramento para um horário RFC3339")
+	cmd.Flags().Duration("in", 0, "Agendar o enfileiramento após uma duração (ex: 30s, 5m, 2h)")
+	cmd.Flags().String("session", "", "SessionID da mensagem (obrigatório em filas com RequiresSession)")
+	cmd.Flags().StringArray("property", nil, "Propriedade de aplicação da mensagem (chave=valor, repetível)")
+	cmd.MarkFlagsMutuallyExclusive("at", "in")
+}
+
+// receiveForCheck lê as flags de liquidação, paginação e sessão e executa a leitura da entidade
+func receiveForCheck(cmd *cobra.Command, client *servicebus.Client, entity servicebus.Entity) ([]*servicebus.Message, error) {
+	blue := color.New(color.FgBlue)
+	yellow := color.New(color.FgYellow)
+
+	mode, err := getSettleMode(cmd)
+	if err != nil {
+		return nil, err
+	}
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	fromSequence, _ := cmd.Flags().GetInt64("from-sequence")
+	sessionID, _ := cmd.Flags().GetString("session")
+	nextSession, _ := cmd.Flags().GetBool("next-session")
+
+	if err := servicebus.CheckPaging(mode, maxMessages, fromSequence); err != nil {
+		return nil, err
+	}
+
+	_, _ = blue.Printf("⚙️  Modo: %s (máximo %d mensagem(ns))\n", mode, maxMessages)
+	if mode != servicebus.SettlePeek {
+		_, _ = yellow.Println("⚠️  As mensagens lidas serão liquidadas e a fila será alterada")
+	}
+
+	// Entidades com sessão exigem um receiver de sessão
+	if sessionID != "" || nextSession {
+		var messages []*servicebus.Message
+		var acceptedSession string
+		var err error
+
+		if mode == servicebus.SettlePeek {
+			messages, acceptedSession, err = client.PeekSessionMessages(cmd.Context(), entity, sessionID, maxMessages, fromSequence)
+		} else {
+			messages, acceptedSession, err = client.ReceiveSessionMessages(cmd.Context(), entity, sessionID, maxMessages, mode)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, _ = blue.Printf("🔐 Sessão: %s\n", acceptedSession)
+		fmt.Println()
+		return messages, nil
+	}
+	fmt.Println()
+
+	switch {
+	case entity.IsSubscription() && mode == servicebus.SettlePeek:
+		return client.PeekMessagesFromTopic(cmd.Context(), entity.Topic, entity.Subscription, maxMessages, fromSequence)
+	case entity.IsSubscription():
+		return client.ReceiveMessagesFromTopic(cmd.Context(), entity.Topic, entity.Subscription, maxMessages, mode)
+	case mode == servicebus.SettlePeek:
+		return client.PeekMessagesFromQueue(cmd.Context(), entity.Queue, maxMessages, fromSequence)
+	default:
+		return client.ReceiveMessagesFromQueue(cmd.Context(), entity.Queue, maxMessages, mode)
+	}
+}
+
+// printNextPageHint indica como continuar a navegação quando a página de peek veio cheia
+func printNextPageHint(cmd *cobra.Command, messages []*servicebus.Message) {
+	blue := color.New(color.FgBlue)
+
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	mode, _ := getSettleMode(cmd)
+	next, ok := servicebus.NextPageSequence(messages, maxMessages)
+	if mode != servicebus.SettlePeek || !ok {
+		return
+	}
+
+	_, _ = blue.Printf("➡️  Próxima página: --from-sequence %d\n", next)
+}
+
+// getSettleMode retorna o modo de liquidação escolhido nas flags (peek por padrão)
+func getSettleMode(cmd *cobra.Command) (servicebus.SettleMode, error) {
+	peek, _ := cmd.Flags().GetBool("peek")
+	complete, _ := cmd.Flags().GetBool("complete")
+	abandon, _ := cmd.Flags().GetBool("abandon")
+	deadLetter, _ := cmd.Flags().GetBool("dead-letter")
+	return servicebus.ParseSettleMode(peek, complete, abandon, deadLetter)
+}
+
+// addSettleFlags adiciona as flags de liquidação e paginação a um comando de leitura
+func addSettleFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("peek", true, "Apenas espiar as mensagens, sem alterar a fila")
+	cmd.Flags().Bool("complete", false, "Completar (remover) as mensagens lidas")
+	cmd.Flags().Bool("abandon", false, "Abandonar as mensagens lidas, devolvendo-as para a fila")
+	cmd.Flags().Bool("dead-letter", false, "Mover as mensagens lidas para a dead-letter queue")
+	cmd.Flags().Int("max", 10, "Número máximo de mensagens a ler")
+	cmd.Flags().Int64("from-sequence", 0, "Sequence number inicial para espiar (apenas com --peek)")
+	cmd.Flags().String("session", "", "Ler apenas a sessão informada (entidades com RequiresSession)")
+	cmd.Flags().Bool("next-session", false, "Ler a próxima sessão disponível (entidades com RequiresSession)")
+	// --peek tem padrão true e fica fora do grupo: a combinação é validada por servicebus.ParseSettleMode
+	cmd.MarkFlagsMutuallyExclusive("complete", "abandon", "dead-letter")
+	cmd.MarkFlagsMutuallyExclusive("session", "next-session")
+}
+
+// printReceivedMessages imprime as mensagens lidas de uma fila ou subscription
+func printReceivedMessages(messages []*servicebus.Message) {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	_, _ = green.Printf("📨 Encontradas %d mensagem(ns):\n", len(messages))
+	fmt.Println()
+
+	for i, message := range messages {
+		_, _ = blue.Printf("--- Mensagem %d ---\n", i+1)
+		fmt.Printf("ID: %s\n", message.MessageID)
+		fmt.Printf("Sequence Number: %d\n", message.SequenceNumber)
+		fmt.Printf("Correlation ID: %s\n", message.CorrelationID)
+		fmt.Printf("Content Type: %s\n", message.ContentType)
+		if message.EnqueuedTimeUtc != nil {
+			fmt.Printf("Timestamp: %s\n", message.EnqueuedTimeUtc.Format(time.RFC3339))
+		}
+		fmt.Printf("Delivery Count: %d\n", message.DeliveryCount)
+		if message.SessionID != "" {
+			fmt.Printf("Session ID: %s\n", message.SessionID)
+		}
+		if message.Subject != "" {
+			fmt.Printf("Subject: %s\n", message.Subject)
+		}
+		if message.To != "" {
+			fmt.Printf("To: %s\n", message.To)
+		}
+		if message.ReplyTo != "" {
+			fmt.Printf("Reply To: %s\n", message.ReplyTo)
+		}
+		if message.ReplyToSessionID != "" {
+			fmt.Printf("Reply To Session ID: %s\n", message.ReplyToSessionID)
+		}
+		if message.PartitionKey != "" {
+			fmt.Printf("Partition Key: %s\n", message.PartitionKey)
 		}
+		if message.TimeToLive != nil {
+			fmt.Printf("Time To Live: %s\n", message.TimeToLive)
+		}
+		if len(message.Properties) > 0 {
+			propertiesJSON, _ := json.Marshal(message.Properties)
+			fmt.Printf("Properties: %s\n", propertiesJSON)
+		}
+		if message.DeadLetterReason != "" {
+			fmt.Printf("Dead-Letter Reason: %s\n", message.DeadLetterReason)
+			fmt.Printf("Dead-Letter Error Description: %s\n", message.DeadLetterErrorDescription)
+		}
+		if message.DeadLetterSource != "" {
+			fmt.Printf("Dead-Letter Source: %s\n", message.DeadLetterSource)
+		}
+		fmt.Println("Body:")
+
+		bodyJSON, _ := json.MarshalIndent(message.Body, "", "  ")
+		fmt.Println(string(bodyJSON))
+		fmt.Println()
 	}
-	return false
 }
 
 func loadMessageFromFile(filename string) (*servicebus.Message, error) {
-	filePath := filepath.Join("messages", filename)
+	return loadMessageTemplate(filename, nil, nil)
+}
 
-	// Validar JSON antes de carregar
-	if err := utils.ValidateJSONFile(filePath); err != nil {
-		return nil, fmt.Errorf("erro de validação JSON: %w", err)
+// loadMessageTemplate carrega uma fixture da pasta messages, renderizando o template antes de validar o JSON
+func loadMessageTemplate(filename string, vars map[string]string, sets []fixture.Set) (*servicebus.Message, error) {
+	data, err := renderFixture(filepath.Join("messages", filename), vars, sets)
+	if err != nil {
+		return nil, err
 	}
+	return newFixtureMessage(data)
+}
 
-	data, err := os.ReadFile(filePath)
+// newFixtureMessage cria a mensagem de uma fixture já renderizada, preenchendo MessageID e CorrelationID
+func newFixtureMessage(data []byte) (*servicebus.Message, error) {
+	message, err := parseMessage(data)
 	if err != nil {
-		return nil, fmt.Errorf("arquivo não encontrado: %s", filePath)
+		return nil, err
+	}
+
+	if message.MessageID == "" {
+		message.MessageID = fmt.Sprintf("json-%d", time.Now().Unix())
+	}
+	if message.CorrelationID == "" {
+		message.CorrelationID = fmt.Sprintf("corr-%d", time.Now().Unix())
 	}
 
+	return message, nil
+}
+
+// parseMessage cria uma mensagem a partir do conteúdo JSON de um arquivo ou linha NDJSON
+func parseMessage(data []byte) (*servicebus.Message, error) {
+	// Arquivos com a chave $envelope descrevem a mensagem completa
+	if servicebus.IsEnvelope(data) {
+		return servicebus.ParseEnvelope(data)
+	}
+
+	// Números mantêm a representação original, para que inteiros maiores que 2^53 não sejam arredondados
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
 	var body interface{}
-	if err := json.Unmarshal(data, &body); err != nil {
+	if err := decoder.Decode(&body); err != nil {
 		return nil, fmt.Errorf("erro de sintaxe JSON: %w", err)
 	}
 
 	message := &servicebus.Message{
-		Body:          body,
-		MessageID:     fmt.Sprintf("json-%d", time.Now().Unix()),
-		CorrelationID: fmt.Sprintf("corr-%d", time.Now().Unix()),
-		ContentType:   "application/json",
+		Body:        body,
+		ContentType: "application/json",
 	}
 
 	return message, nil
@@ -755,32 +1016,30 @@ func listJSONFiles() ([]string, error) {
 	return jsonFiles, nil
 }
 
-func checkEnvironmentStatus() {
-	blue := color.New(color.FgBlue)
-	green := color.New(color.FgGreen)
-	red := color.New(color.FgRed)
-
-	_, _ = blue.Println("Verificando status do ambiente...")
-	fmt.Println()
-
-	// Verificar Service Bus
-	if utils.CheckPort(5672) {
-		_, _ = green.Println("✅ Service Bus Emulator (porta 5672)")
-	} else {
-		_, _ = red.Println("❌ Service Bus Emulator (porta 5672)")
-	}
-
-	// Verificar Orion Functions
-	if utils.CheckHTTPEndpoint("http://localhost:7071") {
-		_, _ = green.Println("✅ Orion Functions (porta 7071)")
-	} else {
-		_, _ = red.Println("⚠️  Orion Functions (porta 7071)")
-	}
-
-	// Verificar Orion API
-	if utils.CheckHTTPEndpoint("http://localhost:3333") {
-		_, _ = green.Println("✅ Orion API (porta 3333)")
-	} else {
-		_, _ = red.Println("⚠️  Orion API (porta 3333)")
-	}
+func init() {
+	addSettleFlags(checkQueueCmd)
+	addSettleFlags(checkTopicCmd)
+	checkTopicCmd.Flags().String("topic", "sbt.orion.core", "Tópico a verificar")
+
+	// Completar filas e arquivos a partir da configuração do emulador e da pasta messages
+	pushMessageCmd.ValidArgsFunction = completeQueueAndFile
+	sendJsonCmd.ValidArgsFunction = completeQueueAndFile
+	sendQueueCmd.ValidArgsFunction = completeTargetAndType(completeQueues)
+	checkQueueCmd.ValidArgsFunction = completeQueues
+	cancelScheduledCmd.ValidArgsFunction = completeQueues
+	testMessageCmd.ValidArgsFunction = completeQueues
+	checkTopicCmd.ValidArgsFunction = completeSubscriptions
+	addSendFlags(pushMessageCmd)
+	addSendFlags(sendJsonCmd)
+	addTemplateFlags(pushMessageCmd)
+	addTemplateFlags(sendJsonCmd)
+	addTemplateFlags(sendQueueCmd)
+	addTemplateFlags(validateJsonCmd)
+	addSchemaFlags(pushMessageCmd)
+	addSchemaFlags(sendJsonCmd)
+	addSchemaFlags(sendQueueCmd)
+	validateJsonCmd.Flags().String("schema", "", "Schema para validar o arquivo: caminho ou nome da fila/tópico (pasta schemas)")
+	_ = validateJsonCmd.RegisterFlagCompletionFunc("schema", completeSchemas)
+	sendQueueCmd.Flags().String("session", "", "SessionID da mensagem (obrigatório em filas com RequiresSession)")
+	sendQueueCmd.Flags().StringArray("property", nil, "Propriedade de aplicação da mensagem (chave=valor, repetível)")
 }
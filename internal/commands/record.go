@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/traffic"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para gravar o tráfego de filas e subscriptions
+var recordCmd = &cobra.Command{
+	Use:   "record [queue|topic/subscription...]",
+	Short: "Gravar as mensagens que passam pelas entidades para reproduzir depois",
+	Long: `Espia as filas e subscriptions informadas (sem remover mensagens) e grava cada
+mensagem nova em NDJSON, com o horário relativo ao início da gravação, até
+Ctrl+C, --duration ou --max. Cada linha é gravada assim que a mensagem é vista.
+
+Cópias da mesma mensagem em subscriptions de um mesmo tópico são gravadas uma
+única vez. Mensagens consumidas entre duas consultas podem não ser vistas:
+reduza --interval em filas com consumidores rápidos.
+
+  orion-dev record sbq.pismo.authorization sbq.pismo.transaction.creation --out sessao.ndjson
+  orion-dev replay sessao.ndjson --speed 2x`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRecord,
+}
+
+// Comando para reproduzir uma gravação
+var replayCmd = &cobra.Command{
+	Use:   "replay [session.ndjson]",
+	Short: "Reenviar uma gravação mantendo ordem e espaçamento",
+	Long: `Reenvia as mensagens gravadas pelo record na mesma ordem e com o mesmo
+espaçamento, dividido por --speed (2x reproduz duas vezes mais rápido, max sem
+esperas). Mensagens vistas em subscriptions são enviadas ao tópico.
+
+--map troca o destino: a chave é a entidade gravada (fila, tópico ou
+tópico/subscription) e o valor a fila ou tópico de destino:
+
+  orion-dev replay sessao.ndjson --map sbq.pismo.all=sbq.pismo.transaction.creation`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+// replayBus implementa traffic.Sender com o cliente do Service Bus
+type replayBus struct {
+	client *servicebus.Client
+}
+
+func (b replayBus) Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error {
+	return b.client.SendMessage(ctx, queueOrTopic, message)
+}
+
+func runRecord(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	var entities []servicebus.Entity
+	for _, arg := range args {
+		entity, err := parseEntityArg(arg)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, entity)
+	}
+
+	out, _ := cmd.Flags().GetString("out")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	filter, err := getMessageFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("erro ao criar gravação: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	ctx, stop := context.WithCancel(cmd.Context())
+	defer stop()
+	if duration > 0 {
+		ctx, stop = context.WithTimeout(ctx, duration)
+		defer stop()
+	}
+
+	started := time.Now()
+	recorder := traffic.NewRecorder(file, started)
+	options := servicebus.TailOptions{Mode: servicebus.SettlePeek, PollInterval: interval}
+
+	_, _ = blue.Printf("🔴 Gravando %d entidade(s) em '%s' (Ctrl+C para encerrar)...\n", len(entities), out)
+	fmt.Println()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var writeErr error
+	errs := make([]error, len(entities))
+
+	for i, entity := range entities {
+		prefix := color.New(tailColors[i%len(tailColors)]).Sprintf("[%s]", entity)
+
+		wg.Add(1)
+		go func(i int, entity servicebus.Entity) {
+			defer wg.Done()
+			errs[i] = client.Tail(ctx, entity, options, func(message *servicebus.Message) {
+				if !filter.Match(message) || ctx.Err() != nil {
+					return
+				}
+
+				record, err := recorder.Add(entity, message)
+				if err != nil {
+					mu.Lock()
+					writeErr = err
+					mu.Unlock()
+					stop()
+					return
+				}
+				if record == nil {
+					return
+				}
+				if !quiet {
+					mu.Lock()
+					body, _ := json.Marshal(message.Body)
+					fmt.Printf("%s +%s #%d %s %s\n", prefix, formatOffset(record.Offset()), message.SequenceNumber, message.MessageID, body)
+					mu.Unlock()
+				}
+				if maxMessages > 0 && recorder.Count() >= maxMessages {
+					stop()
+				}
+			})
+			if errs[i] != nil {
+				// Um erro em uma entidade encerra a gravação das demais
+				stop()
+			}
+		}(i, entity)
+	}
+
+	wg.Wait()
+	fmt.Println()
+
+	if writeErr != nil {
+		return writeErr
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("erro ao gravar '%s': %w", entities[i], err)
+		}
+	}
+
+	_, _ = green.Printf("✅ %d mensagem(ns) gravada(s) em '%s' (%s)\n", recorder.Count(), out, time.Since(started).Round(time.Second))
+	return nil
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	speedText, _ := cmd.Flags().GetString("speed")
+	rawMap, _ := cmd.Flags().GetStringArray("map")
+	newIDs, _ := cmd.Flags().GetBool("new-ids")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	speed, err := traffic.ParseSpeed(speedText)
+	if err != nil {
+		return fmt.Errorf("--speed: %w", err)
+	}
+
+	mapping := make(map[string]string, len(rawMap))
+	for _, item := range rawMap {
+		from, to, found := strings.Cut(item, "=")
+		if !found || from == "" || to == "" {
+			return fmt.Errorf("--map inválido '%s': use origem=destino", item)
+		}
+		if _, err := parseDestinationArg(to); err != nil {
+			return fmt.Errorf("--map %s: %w", item, err)
+		}
+		mapping[from] = to
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("erro ao abrir gravação: %w", err)
+	}
+	records, err := traffic.ReadRecords(file)
+	_ = file.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("nenhuma mensagem encontrada em '%s'", args[0])
+	}
+
+	options := traffic.ReplayOptions{Speed: speed, Map: mapping, NewIDs: newIDs}
+
+	// Conferir todos os destinos antes de enviar a primeira mensagem
+	targets := make(map[string]bool)
+	for _, record := range records {
+		target, err := options.Target(record)
+		if err != nil {
+			return err
+		}
+		if !targets[target] {
+			if _, err := parseDestinationArg(target); err != nil {
+				return fmt.Errorf("mensagem gravada em '%s': %w (use --map)", record.Entity, err)
+			}
+			targets[target] = true
+		}
+	}
+
+	span := records[len(records)-1].Offset() - records[0].Offset()
+	expected := time.Duration(0)
+	if speed > 0 {
+		expected = time.Duration(float64(span) / speed)
+	}
+	_, _ = blue.Printf("▶️  Reproduzindo %d mensagem(ns) de '%s' em %s (gravação de %s, velocidade %s)\n",
+		len(records), args[0], expected.Round(time.Millisecond), span.Round(time.Millisecond), speedText)
+	if !newIDs {
+		_, _ = blue.Println("ℹ️  MessageIDs preservados: com detecção de duplicadas ativa, use --new-ids")
+	}
+	fmt.Println()
+
+	if dryRun {
+		for _, record := range records {
+			target, _ := options.Target(record)
+			offset := time.Duration(0)
+			if speed > 0 {
+				offset = time.Duration(float64(record.Offset()-records[0].Offset()) / speed)
+			}
+			fmt.Printf("  +%s %s → %s %s\n", formatOffset(offset), record.Entity, target, record.Message.MessageID)
+		}
+		fmt.Println()
+		_, _ = yellow.Println("⚠️  --dry-run: nenhuma mensagem foi enviada")
+		return nil
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	started := time.Now()
+	options.OnSend = func(index int, record traffic.Record, target string, err error) {
+		elapsed := formatOffset(time.Since(started))
+		if err != nil {
+			_, _ = red.Printf("  ❌ +%s [%d] %s → %s: %v\n", elapsed, index+1, record.Message.MessageID, target, err)
+			return
+		}
+		if !quiet {
+			_, _ = green.Printf("  ✅ +%s [%d] %s → %s\n", elapsed, index+1, record.Message.MessageID, target)
+		}
+	}
+
+	result, err := traffic.Replay(cmd.Context(), replayBus{client: client}, records, options)
+
+	fmt.Println()
+	_, _ = blue.Println("📊 Resumo:")
+	fmt.Printf("  Total:         %d\n", len(records))
+	fmt.Printf("  Enviadas:      %d\n", result.Sent)
+	fmt.Printf("  Falhas:        %d\n", result.Failed)
+	fmt.Printf("  Duração:       %s\n", result.Duration.Round(time.Millisecond))
+	fmt.Printf("  Maior atraso:  %s\n", result.MaxLag.Round(time.Millisecond))
+
+	if err != nil {
+		return fmt.Errorf("replay interrompido: %w", err)
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d mensagem(ns) falharam", result.Failed)
+	}
+	_, _ = green.Println("✅ Replay concluído!")
+	return nil
+}
+
+// formatOffset formata um horário relativo com milissegundos (ex: 1.532s)
+func formatOffset(offset time.Duration) string {
+	return fmt.Sprintf("%.3fs", offset.Seconds())
+}
+
+func init() {
+	recordCmd.Flags().String("out", "", "Arquivo NDJSON da gravação")
+	recordCmd.Flags().Duration("duration", 0, "Encerrar a gravação depois deste tempo (padrão: até Ctrl+C)")
+	recordCmd.Flags().Int("max", 0, "Encerrar depois de gravar este número de mensagens")
+	recordCmd.Flags().Duration("interval", 200*time.Millisecond, "Intervalo entre consultas quando não há mensagens novas")
+	recordCmd.Flags().Bool("quiet", false, "Não mostrar cada mensagem gravada")
+	addFilterFlags(recordCmd)
+	_ = recordCmd.MarkFlagRequired("out")
+
+	replayCmd.Flags().String("speed", "1x", "Velocidade: 2x, 0.5x ou max (sem esperas)")
+	replayCmd.Flags().StringArray("map", nil, "Trocar o destino: entidade gravada=fila ou tópico (repetível)")
+	replayCmd.Flags().Bool("new-ids", false, "Gerar MessageIDs novos")
+	replayCmd.Flags().Bool("dry-run", false, "Mostrar o plano de envio sem enviar")
+	replayCmd.Flags().Bool("quiet", false, "Mostrar apenas falhas")
+
+	recordCmd.ValidArgsFunction = completeEntities
+	_ = replayCmd.RegisterFlagCompletionFunc("speed", cobra.FixedCompletions([]string{"0.5x", "1x", "2x", "10x", "max"}, cobra.ShellCompDirectiveNoFileComp))
+}
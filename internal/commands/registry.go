@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"strings"
+	"sync"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/registry"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryOnce   sync.Once
+	entityRegistry *registry.Registry
+	registryErr    error
+)
+
+// loadRegistry carrega, uma única vez por execução, as entidades declaradas na configuração do emulador
+func loadRegistry() (*registry.Registry, error) {
+	registryOnce.Do(func() {
+		entityRegistry, registryErr = registry.Load(emulator.DefaultConfigPath)
+	})
+	return entityRegistry, registryErr
+}
+
+// isValidQueue verifica se a fila está declarada na configuração do emulador
+func isValidQueue(queueName string) bool {
+	reg, err := loadRegistry()
+	if err != nil {
+		return false
+	}
+	return reg.HasQueue(queueName)
+}
+
+// printValidQueues lista as filas declaradas, ou o motivo de não ser possível lê-las
+func printValidQueues() {
+	blue := color.New(color.FgBlue)
+	red := color.New(color.FgRed)
+
+	reg, err := loadRegistry()
+	if err != nil {
+		_, _ = red.Printf("❌ Não foi possível ler as filas do emulador: %v\n", err)
+		return
+	}
+
+	_, _ = blue.Println("Filas válidas:")
+	for _, queue := range reg.QueueNames() {
+		_, _ = blue.Printf("  - %s\n", queue)
+	}
+}
+
+// completeQueueAndFile completa o nome da fila no primeiro argumento e arquivos JSON no segundo
+func completeQueueAndFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeQueues(cmd, args, toComplete)
+	case 1:
+		return completeMessageFiles(cmd, nil, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeMessageFiles completa os arquivos JSON da pasta messages no primeiro argumento
+func completeMessageFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	files, err := listJSONFiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(files, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTopicAndFile completa o nome do tópico no primeiro argumento e arquivos JSON no segundo
+func completeTopicAndFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		return completeQueueAndFile(cmd, args, toComplete)
+	}
+	return completeTopics(cmd, args, toComplete)
+}
+
+// completeQueues completa o nome da fila no primeiro argumento
+func completeQueues(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(reg.QueueNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTopics completa o nome do tópico no primeiro argumento
+func completeTopics(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(reg.TopicNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSubscriptions completa as subscriptions do tópico informado em --topic
+func completeSubscriptions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	topicName, _ := cmd.Flags().GetString("topic")
+	topic, ok := reg.Topic(topicName)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, subscription := range topic.Subscriptions {
+		names = append(names, subscription.Name)
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEntities completa filas e subscriptions (tópico/subscription) em qualquer argumento
+func completeEntities(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(reg.EntityNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEntity completa uma única fila ou subscription no primeiro argumento
+func completeEntity(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeEntities(cmd, args, toComplete)
+}
+
+// completeEntityAndFile completa a fila ou subscription no primeiro argumento e arquivos no segundo
+func completeEntityAndFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completeEntity(cmd, args, toComplete)
+}
+
+// completeTransfer completa a origem (fila ou subscription) e o destino (fila ou tópico)
+func completeTransfer(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeEntities(cmd, args, toComplete)
+	case 1:
+		reg, err := loadRegistry()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names := append(reg.QueueNames(), reg.TopicNames()...)
+		return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeConfigEntities completa filas, tópicos e subscriptions (tópico/subscription) no primeiro argumento
+func completeConfigEntities(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := append(reg.EntityNames(), reg.TopicNames()...)
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSubscriptionEntities completa subscriptions (tópico/subscription) no primeiro argumento
+func completeSubscriptionEntities(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(reg.SubscriptionNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// filterCompletions mantém apenas as opções que começam com o texto digitado
+func filterCompletions(options []string, toComplete string) []string {
+	var result []string
+	for _, option := range options {
+		if strings.HasPrefix(option, toComplete) {
+			result = append(result, option)
+		}
+	}
+	return result
+}
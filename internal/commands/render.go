@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fin.orion.dev/internal/fixture"
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// fixtures renderiza os templates das fixtures; os contadores de seq valem para toda a execução
+var fixtures = fixture.NewRenderer()
+
+// Comando para visualizar uma fixture renderizada
+var renderCmd = &cobra.Command{
+	Use:   "render [file]",
+	Short: "Mostrar uma fixture com o template renderizado",
+	Long: `Renderiza as expressões de template de uma fixture e mostra o JSON resultante,
+exatamente como seria enviado por push-message ou send-json.
+
+Funções disponíveis:
+  {{uuid}}                   UUID v4
+  {{now}} {{now "-1h"}}      Horário RFC3339 (UTC), com deslocamento e layout opcionais
+  {{date "+2d"}}             Data AAAA-MM-DD
+  {{unix "-30m"}}            Timestamp em segundos
+  {{amount 10 500}}          Valor aleatório com duas casas decimais
+  {{randInt 1 9}}            Inteiro aleatório (inclusive)
+  {{seq "pedido"}}           Contador por nome, começando em 1
+  {{env "TENANT" "orion"}}   Variável de ambiente, com valor padrão opcional
+  {{.conta}}                 Variável definida com --var conta=123
+  {{json .descricao}}        Valor codificado como JSON (com aspas)
+  {{cpf}} {{cpf "mask"}}     CPF válido, sem ou com máscara
+  {{cnpj}} {{cnpj "mask"}}   CNPJ válido, sem ou com máscara
+  {{pixKey "evp"}}           Chave PIX: cpf, cnpj, email, phone ou evp (aleatório se omitido)
+  {{branch}} {{account}}     Agência (4 dígitos) e conta com dígito verificador
+  {{ispb}}                   ISPB de uma instituição participante do PIX
+  {{brl 10 5000}}            Valor aleatório formatado em reais (R$ 1.234,56)
+
+--set altera campos do body depois da renderização (ex: --set data.amount=10.5).
+--seed torna os valores aleatórios reproduzíveis (uuid e now não são afetados).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRender,
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	red := color.New(color.FgRed)
+
+	path, err := resolveMessagePath(args[0])
+	if err != nil {
+		return err
+	}
+	vars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := renderFixture(path, vars, sets)
+	if err != nil {
+		_, _ = red.Printf("❌ %v\n", err)
+		return fmt.Errorf("erro ao renderizar '%s'", args[0])
+	}
+
+	var formatted bytes.Buffer
+	if err := json.Indent(&formatted, bytes.TrimSpace(data), "", "  "); err != nil {
+		return err
+	}
+	fmt.Println(formatted.String())
+	return nil
+}
+
+// renderFixture lê a fixture, renderiza o template, valida o JSON e aplica os overrides de --set
+func renderFixture(path string, vars map[string]string, sets []fixture.Set) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("arquivo não encontrado: %s", path)
+	}
+
+	return renderTemplate(path, data, vars, sets)
+}
+
+// renderTemplate renderiza o conteúdo, valida o JSON resultante e aplica os overrides de --set
+func renderTemplate(name string, data []byte, vars map[string]string, sets []fixture.Set) ([]byte, error) {
+	rendered, err := fixtures.Render(name, data, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar o JSON depois de renderizar: expressões podem gerar valores sem aspas (ex: {{amount 1 10}})
+	if err := utils.ValidateJSON(rendered); err != nil {
+		if fixture.IsTemplate(data) {
+			return nil, fmt.Errorf("erro de validação JSON após renderizar o template (veja com 'orion-dev render'): %w", err)
+		}
+		return nil, fmt.Errorf("erro de validação JSON: %w", err)
+	}
+
+	if len(sets) == 0 {
+		return rendered, nil
+	}
+	return fixture.ApplySets(rendered, sets)
+}
+
+// loadMessageForSend carrega a fixture da pasta messages aplicando --var e --set do comando
+// e, em comandos com --schema-check, valida a mensagem contra o schema do destino
+func loadMessageForSend(cmd *cobra.Command, target, filename string) (*servicebus.Message, error) {
+	vars, sets, err := getTemplateFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	data, err := renderFixture(filepath.Join("messages", filename), vars, sets)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMessageSchema(cmd, target, data); err != nil {
+		return nil, err
+	}
+	return newFixtureMessage(data)
+}
+
+// getTemplateFlags lê as flags --var (nome=valor) e --set (caminho=valor JSON ou texto) e aplica --seed ao renderizador
+func getTemplateFlags(cmd *cobra.Command) (map[string]string, []fixture.Set, error) {
+	rawVars, _ := cmd.Flags().GetStringArray("var")
+	rawSets, _ := cmd.Flags().GetStringArray("set")
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		fixtures.Seed(seed)
+	}
+
+	vars := make(map[string]string, len(rawVars))
+	for _, item := range rawVars {
+		name, value, found := strings.Cut(item, "=")
+		if !found || name == "" {
+			return nil, nil, fmt.Errorf("--var inválido '%s': use nome=valor", item)
+		}
+		vars[name] = value
+	}
+
+	// Valores de --set também aceitam expressões, como --set data.id={{uuid}}
+	renderedSets := make([]string, 0, len(rawSets))
+	for _, item := range rawSets {
+		rendered, err := fixtures.Render("--set", []byte(item), vars)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--set %s: %w", item, err)
+		}
+		renderedSets = append(renderedSets, string(rendered))
+	}
+	sets, err := fixture.ParseSets(renderedSets)
+	if err != nil {
+		return nil, nil, fmt.Errorf("--set: %w", err)
+	}
+	return vars, sets, nil
+}
+
+// addTemplateFlags adiciona as flags de variáveis e overrides de template a um comando que carrega fixtures
+func addTemplateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("var", nil, "Variável do template, usada como {{.nome}} (nome=valor, repetível)")
+	cmd.Flags().StringArray("set", nil, "Alterar um campo do body após renderizar (caminho=valor, repetível)")
+	cmd.Flags().Uint64("seed", 0, "Seed dos valores aleatórios do template, para resultados reproduzíveis")
+}
+
+func init() {
+	addTemplateFlags(renderCmd)
+	renderCmd.ValidArgsFunction = completeMessageFiles
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para enviar uma mensagem e aguardar a resposta em outra entidade
+var requestCmd = &cobra.Command{
+	Use:   "request [queue] [file]",
+	Short: "Enviar uma mensagem e aguardar a resposta correlacionada",
+	Long: `Envia a mensagem com um CorrelationID novo e aguarda, na entidade informada em
+--reply, uma mensagem com o mesmo CorrelationID (ou cujo CorrelationID seja o
+MessageID enviado). Mostra a resposta e a latência.
+
+A entidade de resposta é apenas espiada, sem remover mensagens. Por isso use
+uma fila ou subscription sem consumidor ativo: uma resposta consumida entre
+duas consultas não é vista e o comando termina por timeout mesmo que o fluxo
+tenha funcionado. Se nenhuma resposta chegar dentro de --timeout o comando
+termina com erro, o que permite usá-lo em smoke tests:
+
+  orion-dev request sbq.pismo.transaction.creation transacao.json \
+    --reply sbt.orion.core/subscription.orion.core --timeout 30s`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRequest,
+}
+
+// requestResult é a saída do request no formato JSON
+type requestResult struct {
+	CorrelationID string              `json:"correlationId"`
+	MessageID     string              `json:"messageId"`
+	LatencyMs     int64               `json:"latencyMs"`
+	Reply         *servicebus.Message `json:"reply"`
+}
+
+func runRequest(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	target, err := parseDestinationArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	replyName, _ := cmd.Flags().GetString("reply")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	output, _ := cmd.Flags().GetString("output")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("--output inválido '%s': use text ou json", output)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout deve ser maior que zero")
+	}
+	reply, err := parseEntityArg(replyName)
+	if err != nil {
+		return fmt.Errorf("--reply: %w", err)
+	}
+	quiet := output == "json"
+
+	message, err := loadMessageForSend(cmd, target, args[1])
+	if err != nil {
+		return fmt.Errorf("erro ao carregar arquivo: %w", err)
+	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
+
+	message.CorrelationID = utils.NewUUID()
+	if message.ReplyTo == "" {
+		message.ReplyTo = reply.SendTarget()
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	// Apenas respostas enfileiradas depois do envio são consideradas
+	last, err := client.LastSequenceNumber(cmd.Context(), reply)
+	if err != nil {
+		return fmt.Errorf("erro ao consultar '%s': %w", reply, err)
+	}
+
+	if !quiet {
+		_, _ = blue.Printf("📤 Enviando '%s' para '%s' (CorrelationID %s)...\n", args[1], target, message.CorrelationID)
+	}
+	sent := time.Now()
+	if err := client.SendMessage(cmd.Context(), target, message); err != nil {
+		return fmt.Errorf("erro ao enviar mensagem: %w", err)
+	}
+	if !quiet {
+		_, _ = blue.Printf("⏳ Aguardando resposta em '%s' (timeout %s)...\n", reply, timeout)
+	}
+
+	response, err := waitForReply(cmd.Context(), client, reply, message, last+1, sent.Add(timeout), interval)
+	latency := time.Since(sent)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			if !quiet {
+				_, _ = red.Printf("❌ Nenhuma resposta em '%s' após %s\n", reply, timeout)
+			}
+			return fmt.Errorf("timeout aguardando resposta com CorrelationID %s", message.CorrelationID)
+		}
+		return fmt.Errorf("erro ao aguardar resposta: %w", err)
+	}
+
+	if quiet {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(requestResult{
+			CorrelationID: message.CorrelationID,
+			MessageID:     message.MessageID,
+			LatencyMs:     latency.Milliseconds(),
+			Reply:         response,
+		})
+	}
+
+	_, _ = green.Printf("✅ Resposta recebida em %s\n", latency.Round(time.Millisecond))
+	if response.EnqueuedTimeUtc != nil {
+		_, _ = blue.Printf("⏱️  Enfileirada %s após o envio\n", response.EnqueuedTimeUtc.Sub(sent).Round(time.Millisecond))
+	}
+	fmt.Println()
+	printReceivedMessages([]*servicebus.Message{response})
+	return nil
+}
+
+// waitForReply espia a entidade de resposta a partir de fromSequence até encontrar a mensagem
+// correlacionada ou atingir o prazo. Começar depois da última mensagem anterior ao envio evita que
+// uma resposta antiga com o mesmo MessageID seja aceita.
+func waitForReply(ctx context.Context, client *servicebus.Client, reply servicebus.Entity, request *servicebus.Message, fromSequence int64, deadline time.Time, interval time.Duration) (*servicebus.Message, error) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var response *servicebus.Message
+	options := servicebus.TailOptions{Mode: servicebus.SettlePeek, PollInterval: interval, FromSequence: fromSequence}
+	err := client.Tail(ctx, reply, options, func(message *servicebus.Message) {
+		if response != nil {
+			return
+		}
+		if message.CorrelationID == request.CorrelationID || message.CorrelationID == request.MessageID {
+			response = message
+			cancel()
+		}
+	})
+	if response != nil {
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
+}
+
+func init() {
+	requestCmd.Flags().String("reply", "", "Fila ou tópico/subscription onde a resposta é publicada")
+	requestCmd.Flags().Duration("timeout", 30*time.Second, "Tempo máximo de espera pela resposta")
+	requestCmd.Flags().Duration("interval", 200*time.Millisecond, "Intervalo entre consultas à entidade de resposta")
+	requestCmd.Flags().StringP("output", "o", "text", "Formato de saída: text ou json")
+	requestCmd.Flags().String("session", "", "SessionID da mensagem")
+	requestCmd.Flags().StringArray("property", nil, "Propriedade de aplicação da mensagem (chave=valor, repetível)")
+	addTemplateFlags(requestCmd)
+	addSchemaFlags(requestCmd)
+	_ = requestCmd.MarkFlagRequired("reply")
+
+	requestCmd.ValidArgsFunction = completeQueueAndFile
+	_ = requestCmd.RegisterFlagCompletionFunc("reply", completeEntities)
+}
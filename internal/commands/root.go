@@ -1,6 +1,11 @@
 package commands
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"fin.orion.dev/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -23,12 +28,18 @@ o status dos serviços.`,
 	},
 }
 
-// Execute adiciona todos os comandos filhos ao comando raiz e configura flags
+// Execute adiciona todos os comandos filhos ao comando raiz e configura flags.
+// Ctrl+C cancela o contexto dos comandos, interrompendo operações em andamento.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
+	rootCmd.PersistentFlags().Bool("verbose", false, "Mostrar diagnósticos do cliente do Service Bus")
+
 	// Adicionar subcomandos de ambiente
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(startCmd)
@@ -53,18 +64,42 @@ func init() {
 	// Adicionar subcomandos de mensagens
 	rootCmd.AddCommand(checkMessagesCmd)
 	rootCmd.AddCommand(pushMessageCmd)
+	rootCmd.AddCommand(cancelScheduledCmd)
+	rootCmd.AddCommand(pushBatchCmd)
+	rootCmd.AddCommand(pushTopicCmd)
+	rootCmd.AddCommand(sendTopicCmd)
+	rootCmd.AddCommand(routeTestCmd)
+	rootCmd.AddCommand(requestCmd)
+	rootCmd.AddCommand(expectCmd)
+	rootCmd.AddCommand(scenarioCmd)
+	rootCmd.AddCommand(benchmarkCmd)
+	rootCmd.AddCommand(loadCmd)
 	rootCmd.AddCommand(checkQueueCmd)
 	rootCmd.AddCommand(checkTopicCmd)
+	rootCmd.AddCommand(tailCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(recordCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(moveCmd)
+	rootCmd.AddCommand(copyCmd)
+	rootCmd.AddCommand(purgeCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(testMessageCmd)
 	rootCmd.AddCommand(sendQueueCmd)
+	rootCmd.AddCommand(catalogCmd)
 	rootCmd.AddCommand(sendJsonCmd)
 	rootCmd.AddCommand(listQueuesCmd)
 	rootCmd.AddCommand(listMessagesCmd)
 	rootCmd.AddCommand(validateJsonCmd)
 	rootCmd.AddCommand(formatJsonCmd)
 	rootCmd.AddCommand(showJsonCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(genCmd)
 	rootCmd.AddCommand(proxyCmd)
+	rootCmd.AddCommand(dlqCmd)
+	rootCmd.AddCommand(emulatorCmd)
+	rootCmd.AddCommand(entityCmd)
 
 	// Adicionar subcomandos de commitlint
 	rootCmd.AddCommand(commitlintCmd)
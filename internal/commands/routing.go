@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/routing"
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/sqlfilter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para simular o roteamento de uma mensagem pelas regras das subscriptions
+var routeTestCmd = &cobra.Command{
+	Use:   "route-test [topic] [file]",
+	Short: "Simular quais subscriptions receberiam uma mensagem",
+	Long: `Avalia, sem acessar o emulador, as propriedades de uma mensagem contra as
+regras (filtros SQL e de correlação) de todas as subscriptions do tópico e
+mostra quais subscriptions a receberiam e o resultado das ações.
+
+Use arquivos no formato envelope para informar subject, correlationId e
+propriedades de aplicação, ou sobrescreva-os com as flags.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRouteTest,
+}
+
+func runRouteTest(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	yellow := color.New(color.FgYellow)
+
+	topic, err := loadTopic(args[0])
+	if err != nil {
+		return err
+	}
+
+	message, err := loadMessageForSend(cmd, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("erro ao carregar arquivo: %w", err)
+	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
+	if subject, _ := cmd.Flags().GetString("subject"); subject != "" {
+		message.Subject = subject
+	}
+	if correlationID, _ := cmd.Flags().GetString("correlation-id"); correlationID != "" {
+		message.CorrelationID = correlationID
+	}
+
+	_, _ = blue.Printf("🧭 Roteamento de '%s' no tópico '%s'\n", args[1], topic.Name)
+	printFilterMessage("Propriedades avaliadas", routing.FilterMessage(message))
+	fmt.Println()
+
+	results, err := routing.Route(topic, message)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		_, _ = yellow.Printf("⚠️  O tópico '%s' não possui subscriptions: a mensagem será descartada\n", topic.Name)
+		return nil
+	}
+
+	showRules, _ := cmd.Flags().GetBool("rules")
+	for i, result := range results {
+		printRouteResult(topic, topic.Subscriptions[i], result, showRules)
+	}
+	return nil
+}
+
+// printRouteResult mostra se a subscription receberia a mensagem e por quais regras
+func printRouteResult(topic *emulator.Topic, subscription emulator.Subscription, result routing.Result, showRules bool) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	blue := color.New(color.FgBlue)
+
+	if result.Matched() {
+		_, _ = green.Printf("✅ %s/%s\n", topic.Name, result.Subscription)
+	} else {
+		_, _ = red.Printf("❌ %s/%s\n", topic.Name, result.Subscription)
+	}
+
+	if showRules || !result.Matched() {
+		if len(subscription.Rules) == 0 {
+			_, _ = blue.Printf("    %s: sem regras, aceita todas as mensagens\n", emulator.DefaultRuleName)
+		}
+		for _, rule := range subscription.Rules {
+			_, _ = blue.Printf("    %s: %s\n", rule.Name, describeRule(rule))
+		}
+	}
+
+	for _, match := range result.Matches {
+		if len(subscription.Rules) > 0 {
+			_, _ = green.Printf("    ↳ regra '%s'\n", match.Rule)
+		}
+		if match.Action != "" {
+			_, _ = blue.Printf("      ação: %s\n", match.Action)
+			printFilterMessage("      resultado", match.Message)
+		}
+	}
+}
+
+// describeRule resume o filtro de uma regra em uma linha
+func describeRule(rule emulator.Rule) string {
+	properties := rule.Properties
+	switch {
+	case properties.FilterType == emulator.FilterTypeSQL && properties.SqlFilter != nil:
+		return "SQL " + properties.SqlFilter.SqlExpression
+	case properties.FilterType == emulator.FilterTypeCorrelation && properties.CorrelationFilter != nil:
+		data, _ := json.Marshal(properties.CorrelationFilter)
+		return "Correlation " + string(data)
+	default:
+		return "FilterType " + properties.FilterType
+	}
+}
+
+// printFilterMessage mostra as propriedades de sistema e de aplicação em ordem alfabética
+func printFilterMessage(title string, message *sqlfilter.Message) {
+	blue := color.New(color.FgBlue)
+
+	_, _ = blue.Printf("%s:\n", title)
+	for _, group := range []struct {
+		prefix string
+		values map[string]interface{}
+	}{{"sys.", message.System}, {"", message.Properties}} {
+		keys := make([]string, 0, len(group.values))
+		for key := range group.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s%s = %v\n", group.prefix, key, group.values[key])
+		}
+	}
+}
+
+// routedSubscriptions retorna as subscriptions que receberiam a mensagem, segundo as regras da configuração
+func routedSubscriptions(topic *emulator.Topic, message *servicebus.Message) ([]string, error) {
+	results, err := routing.Route(topic, message)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, result := range results {
+		if result.Matched() {
+			names = append(names, result.Subscription)
+		}
+	}
+	return names, nil
+}
+
+func init() {
+	routeTestCmd.Flags().String("session", "", "SessionID da mensagem")
+	routeTestCmd.Flags().StringArray("property", nil, "Propriedade de aplicação da mensagem (chave=valor, repetível)")
+	routeTestCmd.Flags().String("subject", "", "Subject da mensagem (sys.Label)")
+	routeTestCmd.Flags().String("correlation-id", "", "CorrelationID da mensagem")
+	routeTestCmd.Flags().Bool("rules", false, "Mostrar as regras de todas as subscriptions")
+	addTemplateFlags(routeTestCmd)
+
+	routeTestCmd.ValidArgsFunction = completeTopicAndFile
+}
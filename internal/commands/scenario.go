@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/scenario"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// scenariosDir é a pasta dos cenários, ao lado de messages
+const scenariosDir = "scenarios"
+
+// Comando agrupador dos cenários de ponta a ponta
+var scenarioCmd = &cobra.Command{
+	Use:   "scenario",
+	Short: "Executar cenários de ponta a ponta descritos em YAML",
+	Long: `Executa cenários declarativos com passos em ordem: enviar fixtures da pasta
+messages (send), aguardar mensagens com assertions por caminho JSON (wait),
+chamar endpoints da Orion API e das Functions (http), aguardar (sleep) e
+verificar resultados (assert).
+
+Os passos wait apenas espiam a entidade. Aguarde em filas ou subscriptions
+sem consumidor ativo: uma mensagem consumida entre duas consultas não é vista
+e o passo falha por timeout.
+
+Os cenários ficam na pasta scenarios, ao lado de messages.`,
+}
+
+// Comando para executar cenários
+var scenarioRunCmd = &cobra.Command{
+	Use:   "run [file.yaml...]",
+	Short: "Executar um ou mais cenários",
+	Long: `Executa os cenários em ordem, mostrando o tempo de cada passo. Um passo com
+falha interrompe o cenário e os passos seguintes são marcados como ignorados.
+
+Os relatórios podem ser exportados em JUnit XML (--junit) e JSON (--json).
+O comando termina com erro se algum cenário falhar.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runScenarioRun,
+}
+
+// Comando para listar cenários
+var scenarioListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar os cenários da pasta scenarios",
+	Args:  cobra.NoArgs,
+	RunE:  runScenarioList,
+}
+
+// scenarioBus implementa scenario.Bus, usado pelos cenários e pela carga, com o cliente do Service Bus
+type scenarioBus struct {
+	client   *servicebus.Client
+	interval time.Duration
+}
+
+func (b scenarioBus) Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error {
+	return b.client.SendMessage(ctx, queueOrTopic, message)
+}
+
+func (b scenarioBus) LastSequenceNumber(ctx context.Context, entity servicebus.Entity) (int64, error) {
+	return b.client.LastSequenceNumber(ctx, entity)
+}
+
+func (b scenarioBus) Watch(ctx context.Context, entity servicebus.Entity, fromSequence int64, handler func(*servicebus.Message) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	options := servicebus.TailOptions{Mode: servicebus.SettlePeek, PollInterval: b.interval, FromSequence: fromSequence}
+	return b.client.Tail(ctx, entity, options, func(message *servicebus.Message) {
+		if ctx.Err() == nil && handler(message) {
+			cancel()
+		}
+	})
+}
+
+func runScenarioRun(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	junitPath, _ := cmd.Flags().GetString("junit")
+	jsonPath, _ := cmd.Flags().GetString("json")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	// Carregar e validar todos os cenários antes de executar o primeiro
+	var scenarios []*scenario.Scenario
+	needsBus := false
+	for _, arg := range args {
+		path, err := resolveScenarioPath(arg)
+		if err != nil {
+			return err
+		}
+		loaded, err := scenario.Load(path)
+		if err != nil {
+			return err
+		}
+		if err := validateScenarioEntities(loaded); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, step := range loaded.Steps {
+			needsBus = needsBus || step.Send != nil || step.Wait != nil
+		}
+		scenarios = append(scenarios, loaded)
+	}
+
+	runner := &scenario.Runner{
+		LoadFixture: loadMessageFromFile,
+		OnStep:      printScenarioStep,
+	}
+	if needsBus {
+		client, err := newServiceBusClient(cmd)
+		if err != nil {
+			return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+		}
+		defer closeClient(cmd, client)
+		runner.Bus = scenarioBus{client: client, interval: interval}
+	}
+
+	var reports []*scenario.Report
+	for _, loaded := range scenarios {
+		_, _ = blue.Printf("🎬 %s (%s)\n", loaded.Name, loaded.Path)
+		if loaded.Description != "" {
+			fmt.Printf("   %s\n", loaded.Description)
+		}
+
+		report := runner.Run(cmd.Context(), loaded)
+		reports = append(reports, report)
+
+		if report.Passed {
+			_, _ = green.Printf("✅ Cenário aprovado em %s\n", report.Duration.Round(time.Millisecond))
+		} else {
+			_, _ = red.Printf("❌ Cenário reprovado em %s\n", report.Duration.Round(time.Millisecond))
+		}
+		fmt.Println()
+		if cmd.Context().Err() != nil {
+			break
+		}
+	}
+
+	if err := writeScenarioReport(junitPath, reports, scenario.WriteJUnit); err != nil {
+		return err
+	}
+	if err := writeScenarioReport(jsonPath, reports, scenario.WriteJSON); err != nil {
+		return err
+	}
+
+	passed := 0
+	for _, report := range reports {
+		if report.Passed {
+			passed++
+		}
+	}
+	_, _ = blue.Printf("📊 %d de %d cenário(s) aprovado(s)\n", passed, len(reports))
+
+	if scenario.Failed(reports) {
+		return fmt.Errorf("%d cenário(s) reprovado(s)", len(reports)-passed)
+	}
+	return nil
+}
+
+// printScenarioStep mostra o resultado de um passo com o tempo gasto
+func printScenarioStep(index int, step *scenario.Step, result scenario.StepResult) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	line := fmt.Sprintf("  [%d] %-6s %s", index+1, result.Kind, result.Name)
+	switch result.Status {
+	case scenario.StatusPassed:
+		_, _ = green.Printf("%s ✅ %s\n", line, result.Duration.Round(time.Millisecond))
+	case scenario.StatusFailed:
+		_, _ = red.Printf("%s ❌ %s\n", line, result.Duration.Round(time.Millisecond))
+		if result.Error != "" {
+			_, _ = red.Printf("        %s\n", result.Error)
+		}
+		for _, failure := range result.Failures {
+			_, _ = red.Printf("        ✗ %s\n", failure)
+		}
+	default:
+		_, _ = yellow.Printf("%s ⏭️  ignorado\n", line)
+	}
+}
+
+// writeScenarioReport grava um relatório quando o caminho foi informado
+func writeScenarioReport(path string, reports []*scenario.Report, write func(io.Writer, []*scenario.Report) error) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("erro ao criar relatório: %w", err)
+	}
+	if err := write(file, reports); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar relatório: %w", err)
+	}
+
+	_, _ = color.New(color.FgBlue).Printf("📝 Relatório gravado em %s\n", path)
+	return nil
+}
+
+// validateScenarioEntities confere, contra a configuração do emulador, as entidades usadas nos passos
+func validateScenarioEntities(loaded *scenario.Scenario) error {
+	var errs []error
+	for i, step := range loaded.Steps {
+		var err error
+		switch {
+		case step.Send != nil:
+			_, err = parseDestinationArg(step.Send.To)
+		case step.Wait != nil:
+			_, err = parseEntityArg(step.Wait.Entity)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("passo %d (%s): %w", i+1, step.Title(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveScenarioPath procura o cenário no caminho informado e na pasta scenarios, com ou sem extensão
+func resolveScenarioPath(name string) (string, error) {
+	candidates := []string{name, filepath.Join(scenariosDir, name)}
+	if filepath.Ext(name) == "" {
+		for _, ext := range []string{".yaml", ".yml"} {
+			candidates = append(candidates, name+ext, filepath.Join(scenariosDir, name+ext))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("cenário não encontrado: %s", name)
+}
+
+// listScenarioFiles lista os arquivos YAML da pasta scenarios
+func listScenarioFiles() ([]string, error) {
+	entries, err := os.ReadDir(scenariosDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+func runScenarioList(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	files, err := listScenarioFiles()
+	if err != nil {
+		return fmt.Errorf("erro ao listar cenários: %w", err)
+	}
+	if len(files) == 0 {
+		_, _ = blue.Printf("ℹ️  Nenhum cenário encontrado na pasta %s\n", scenariosDir)
+		return nil
+	}
+
+	_, _ = blue.Printf("🎬 Cenários em %s:\n", scenariosDir)
+	for _, file := range files {
+		loaded, err := scenario.Load(filepath.Join(scenariosDir, file))
+		if err != nil {
+			_, _ = red.Printf("  ❌ %s: %v\n", file, err)
+			continue
+		}
+		_, _ = green.Printf("  • %s", file)
+		fmt.Printf(" — %s (%d passo(s))\n", loaded.Name, len(loaded.Steps))
+	}
+	return nil
+}
+
+// completeScenarios completa os arquivos da pasta scenarios
+func completeScenarios(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	files, err := listScenarioFiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(files, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	scenarioCmd.AddCommand(scenarioRunCmd)
+	scenarioCmd.AddCommand(scenarioListCmd)
+
+	scenarioRunCmd.Flags().String("junit", "", "Gravar o relatório em JUnit XML no arquivo informado")
+	scenarioRunCmd.Flags().String("json", "", "Gravar o relatório em JSON no arquivo informado")
+	scenarioRunCmd.Flags().Duration("interval", 500*time.Millisecond, "Intervalo entre consultas dos passos wait")
+
+	scenarioRunCmd.ValidArgsFunction = completeScenarios
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fin.orion.dev/internal/schema"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Modos de --schema-check
+const (
+	schemaCheckStrict = "strict"
+	schemaCheckWarn   = "warn"
+	schemaCheckOff    = "off"
+)
+
+// addSchemaFlags adiciona --schema-check aos comandos que enviam fixtures
+func addSchemaFlags(cmd *cobra.Command) {
+	cmd.Flags().String("schema-check", schemaCheckWarn, "Validação contra schemas/<destino>.json: warn (avisa), strict (recusa) ou off")
+	_ = cmd.RegisterFlagCompletionFunc("schema-check", cobra.FixedCompletions(
+		[]string{schemaCheckStrict, schemaCheckWarn, schemaCheckOff}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// checkMessageSchema valida a fixture renderizada contra o schema do destino, se houver.
+// Em modo strict as violações impedem o envio; em modo warn são apenas exibidas.
+// Comandos sem --schema-check não validam.
+func checkMessageSchema(cmd *cobra.Command, target string, data []byte) error {
+	return checkMessagesSchema(cmd, target, []string{""}, [][]byte{data})
+}
+
+// checkMessagesSchema valida cada mensagem renderizada de um envio em lote contra o schema do
+// destino; origins identifica cada mensagem nas violações (vazio para uma mensagem só)
+func checkMessagesSchema(cmd *cobra.Command, target string, origins []string, items [][]byte) error {
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	mode, err := cmd.Flags().GetString("schema-check")
+	if err != nil || mode == schemaCheckOff {
+		return nil
+	}
+	if mode != schemaCheckStrict && mode != schemaCheckWarn {
+		return fmt.Errorf("--schema-check inválido '%s' (use strict, warn ou off)", mode)
+	}
+
+	sch, path, err := schema.ForEntity(schema.DefaultDir, target)
+	if err != nil {
+		return err
+	}
+	if sch == nil {
+		return nil
+	}
+
+	printer := yellow
+	if mode == schemaCheckStrict {
+		printer = red
+	}
+
+	total := 0
+	for i, data := range items {
+		violations, err := validateMessageData(sch, data)
+		if err != nil {
+			if origins[i] != "" {
+				return fmt.Errorf("%s: %w", origins[i], err)
+			}
+			return err
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		total += len(violations)
+
+		if origins[i] != "" {
+			_, _ = printer.Printf("⚠️  A mensagem %s não atende ao schema %s:\n", origins[i], path)
+		} else {
+			_, _ = printer.Printf("⚠️  A mensagem não atende ao schema %s:\n", path)
+		}
+		for _, violation := range violations {
+			_, _ = printer.Printf("  ✗ %s\n", violation)
+		}
+	}
+
+	if total > 0 && mode == schemaCheckStrict {
+		return fmt.Errorf("mensagem não atende ao schema de '%s' (%d violação(ões)); remova --schema-check strict para enviar mesmo assim", target, total)
+	}
+	return nil
+}
+
+// validateWithSchema valida o conteúdo com o schema informado em validate-json --schema:
+// um arquivo existente ou o nome de uma fila/tópico com schema na pasta schemas
+func validateWithSchema(name string, data []byte) error {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	sch, path, err := resolveSchema(name)
+	if err != nil {
+		return err
+	}
+
+	violations, err := validateMessageData(sch, data)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		_, _ = green.Printf("✅ Atende ao schema %s\n", path)
+		return nil
+	}
+
+	_, _ = red.Printf("❌ %d violação(ões) do schema %s:\n", len(violations), path)
+	for _, violation := range violations {
+		_, _ = red.Printf("  ✗ %s\n", violation)
+	}
+	return fmt.Errorf("json não atende ao schema")
+}
+
+// resolveSchema carrega o schema pelo caminho do arquivo ou pelo nome da entidade
+func resolveSchema(name string) (*schema.Schema, string, error) {
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		sch, err := schema.Load(name)
+		return sch, name, err
+	}
+
+	sch, path, err := schema.ForEntity(schema.DefaultDir, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if sch == nil {
+		return nil, "", fmt.Errorf("schema '%s' não encontrado (nem arquivo, nem %s)", name, filepath.Join(schema.DefaultDir, name+".json"))
+	}
+	return sch, path, nil
+}
+
+// validateMessageData valida o body da mensagem; em envelopes o schema se aplica ao campo body
+func validateMessageData(sch *schema.Schema, data []byte) ([]schema.Violation, error) {
+	base := ""
+	if servicebus.IsEnvelope(data) {
+		base = "/body"
+	}
+	return sch.ValidateJSON(data, base)
+}
+
+// completeSchemas completa as entidades com schema na pasta schemas
+func completeSchemas(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	_ = filepath.WalkDir(schema.DefaultDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		relative, err := filepath.Rel(schema.DefaultDir, path)
+		if err == nil {
+			names = append(names, strings.TrimSuffix(filepath.ToSlash(relative), ".json"))
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveDefault
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Cores usadas para diferenciar as entidades acompanhadas
+var tailColors = []color.Attribute{color.FgCyan, color.FgMagenta, color.FgYellow, color.FgGreen, color.FgBlue}
+
+// Comando para acompanhar mensagens em tempo real
+var tailCmd = &cobra.Command{
+	Use:   "tail [queue|topic/subscription...]",
+	Short: "Acompanhar mensagens em tempo real",
+	Long: `Mostra as mensagens que chegam em uma ou mais filas/subscriptions até Ctrl+C.
+
+Por padrão as mensagens são apenas espiadas periodicamente (--peek) e a entidade
+não é alterada; mensagens consumidas entre duas consultas podem não aparecer.
+Com --abandon cada mensagem é bloqueada, mostrada e devolvida em seguida;
+entregas repetidas da mesma mensagem não são mostradas de novo. Cada devolução
+aumenta o delivery count, e uma mensagem que ninguém consome chega à
+dead-letter após o MaxDeliveryCount da entidade. Com --complete as mensagens
+são removidas.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTail,
+}
+
+func runTail(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	yellow := color.New(color.FgYellow)
+
+	var entities []servicebus.Entity
+	for _, arg := range args {
+		entity, err := parseEntityArg(arg)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, entity)
+	}
+
+	filter, err := getMessageFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output != "compact" && output != "full" {
+		return fmt.Errorf("--output inválido '%s': use compact ou full", output)
+	}
+
+	mode, err := getSettleMode(cmd)
+	if err != nil {
+		return err
+	}
+	interval, _ := cmd.Flags().GetDuration("interval")
+	fromStart, _ := cmd.Flags().GetBool("from-start")
+	options := servicebus.TailOptions{
+		Mode:         mode,
+		PollInterval: interval,
+		FromStart:    fromStart,
+	}
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	ctx, stop := context.WithCancel(cmd.Context())
+	defer stop()
+
+	_, _ = blue.Printf("👀 Acompanhando %d entidade(s) no modo %s (Ctrl+C para sair)...\n", len(entities), options.Mode)
+	if options.Mode != servicebus.SettlePeek {
+		_, _ = yellow.Println("⚠️  As mensagens recebidas serão liquidadas e a entidade será alterada")
+	}
+	fmt.Println()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(entities))
+
+	for i, entity := range entities {
+		prefix := color.New(tailColors[i%len(tailColors)]).Sprintf("[%s]", entity)
+
+		wg.Add(1)
+		go func(i int, entity servicebus.Entity) {
+			defer wg.Done()
+			errs[i] = client.Tail(ctx, entity, options, func(message *servicebus.Message) {
+				if !filter.Match(message) {
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				printTailMessage(prefix, message, output)
+			})
+			if errs[i] != nil {
+				// Um erro em uma entidade encerra o acompanhamento das demais
+				stop()
+			}
+		}(i, entity)
+	}
+
+	wg.Wait()
+	fmt.Println()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("erro ao acompanhar '%s': %w", entities[i], err)
+		}
+	}
+
+	_, _ = blue.Println("👋 Acompanhamento encerrado")
+	return nil
+}
+
+// printTailMessage imprime uma mensagem em uma linha (compact) ou com todos os campos (full)
+func printTailMessage(prefix string, message *servicebus.Message, output string) {
+	timestamp := time.Now()
+	if message.EnqueuedTimeUtc != nil {
+		timestamp = message.EnqueuedTimeUtc.Local()
+	}
+
+	if output == "full" {
+		data, _ := json.MarshalIndent(message, "", "  ")
+		fmt.Printf("%s %s\n%s\n", prefix, timestamp.Format("15:04:05.000"), data)
+		return
+	}
+
+	body, _ := json.Marshal(message.Body)
+	fmt.Printf("%s %s #%d %s %s\n", prefix, timestamp.Format("15:04:05.000"), message.SequenceNumber, message.MessageID, body)
+}
+
+func init() {
+	tailCmd.Flags().Bool("peek", true, "Apenas espiar periodicamente, sem alterar a entidade")
+	tailCmd.Flags().Bool("abandon", false, "Bloquear, mostrar e devolver as mensagens (aumenta o delivery count)")
+	tailCmd.Flags().Bool("complete", false, "Bloquear, mostrar e completar (remover) as mensagens")
+	tailCmd.MarkFlagsMutuallyExclusive("abandon", "complete")
+	tailCmd.Flags().StringP("output", "o", "compact", "Formato de saída: compact (uma linha) ou full (JSON completo)")
+	tailCmd.Flags().Duration("interval", time.Second, "Intervalo entre consultas quando não há mensagens novas")
+	tailCmd.Flags().Bool("from-start", false, "No modo peek, mostrar também as mensagens já existentes")
+	addFilterFlags(tailCmd)
+
+	tailCmd.ValidArgsFunction = completeEntities
+}
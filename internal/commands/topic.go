@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para enviar mensagem de arquivo para tópico
+var pushTopicCmd = &cobra.Command{
+	Use:   "push-topic [topic] [file]",
+	Short: "Enviar mensagem para tópico",
+	Long: `Envia uma mensagem de arquivo JSON para um tópico declarado na configuração
+do emulador e mostra quais subscriptions receberão a mensagem.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPushTopic,
+}
+
+// Comando para enviar mensagem de teste para tópico
+var sendTopicCmd = &cobra.Command{
+	Use:   "send-topic [topic] [type]",
+	Short: "Enviar mensagem de teste para tópico",
+	Long: `Envia para o tópico uma mensagem de um tipo do catálogo (pasta catalog). Sem o
+tipo, envia uma mensagem simple. Apenas os tipos compatíveis com o tópico são
+aceitos; veja-os com 'orion-dev catalog list --target <tópico>'.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSendTopic,
+}
+
+func runPushTopic(cmd *cobra.Command, args []string) error {
+	topicName := args[0]
+	jsonFile := args[1]
+
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	_, _ = blue.Printf("📤 Enviando mensagem para tópico: %s\n", topicName)
+	_, _ = blue.Printf("📁 Arquivo: %s\n", jsonFile)
+	fmt.Println()
+
+	// Validar tópico
+	topic, err := loadTopic(topicName)
+	if err != nil {
+		return err
+	}
+
+	// Verificar agendamento
+	enqueueTime, scheduled, err := getScheduleTime(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Carregar mensagem do arquivo JSON
+	_, _ = blue.Println("📄 Carregando mensagem do arquivo...")
+	message, err := loadMessageForSend(cmd, topic.Name, jsonFile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar arquivo: %w", err)
+	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
+	_, _ = green.Println("✅ Mensagem carregada com sucesso")
+
+	return sendToTopic(cmd, topic, message, scheduled, enqueueTime)
+}
+
+func runSendTopic(cmd *cobra.Command, args []string) error {
+	topicName := args[0]
+	messageType := "simple"
+	if len(args) > 1 {
+		messageType = args[1]
+	}
+
+	blue := color.New(color.FgBlue)
+
+	_, _ = blue.Printf("📤 Enviando mensagem de teste para tópico '%s' (tipo: %s)...\n", topicName, messageType)
+	fmt.Println()
+
+	// Validar tópico
+	topic, err := loadTopic(topicName)
+	if err != nil {
+		return err
+	}
+
+	// Verificar agendamento
+	enqueueTime, scheduled, err := getScheduleTime(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Criar mensagem a partir do tipo do catálogo
+	catalogType, err := resolveCatalogType(messageType, topic.Name)
+	if err != nil {
+		return err
+	}
+	message, err := buildCatalogMessage(cmd, catalogType, topic.Name, "send-topic")
+	if err != nil {
+		return err
+	}
+	if err := applySendFlags(cmd, message); err != nil {
+		return err
+	}
+
+	return sendToTopic(cmd, topic, message, scheduled, enqueueTime)
+}
+
+// sendToTopic envia ou agenda a mensagem no tópico e informa as subscriptions de destino
+func sendToTopic(cmd *cobra.Command, topic *emulator.Topic, message *servicebus.Message, scheduled bool, enqueueTime time.Time) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	// Conectar ao Service Bus
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	// Agendar mensagem
+	if scheduled {
+		if err := scheduleMessage(cmd.Context(), client, topic.Name, message, enqueueTime); err != nil {
+			return err
+		}
+		printTopicSubscriptions(topic, message)
+		return nil
+	}
+
+	// Enviar mensagem
+	_, _ = blue.Println("📤 Enviando mensagem...")
+	if err := client.SendMessageToTopic(cmd.Context(), topic.Name, message); err != nil {
+		return fmt.Errorf("erro ao enviar mensagem: %w", err)
+	}
+
+	_, _ = green.Printf("✅ Mensagem enviada para o tópico '%s'\n", topic.Name)
+	printTopicSubscriptions(topic, message)
+	return nil
+}
+
+// loadTopic procura o tópico na configuração do emulador, listando os tópicos válidos quando não existir
+func loadTopic(name string) (*emulator.Topic, error) {
+	blue := color.New(color.FgBlue)
+	red := color.New(color.FgRed)
+
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+
+	topic, err := reg.ValidateTopic(name)
+	if err == nil {
+		return topic, nil
+	}
+
+	_, _ = red.Printf("❌ Tópico '%s' não está declarado em %s\n", name, emulator.DefaultConfigPath)
+	_, _ = blue.Println("Tópicos válidos:")
+	for _, topic := range reg.TopicNames() {
+		_, _ = blue.Printf("  - %s\n", topic)
+	}
+	return nil, fmt.Errorf("tópico inválido")
+}
+
+// printTopicSubscriptions lista as subscriptions que receberão a mensagem, de acordo com as regras de cada uma
+func printTopicSubscriptions(topic *emulator.Topic, message *servicebus.Message) {
+	blue := color.New(color.FgBlue)
+	yellow := color.New(color.FgYellow)
+
+	if len(topic.Subscriptions) == 0 {
+		_, _ = yellow.Printf("⚠️  O tópico '%s' não possui subscriptions: a mensagem será descartada\n", topic.Name)
+		return
+	}
+
+	subscriptions, err := routedSubscriptions(topic, message)
+	if err != nil {
+		_, _ = yellow.Printf("⚠️  Não foi possível avaliar as regras das subscriptions: %v\n", err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		_, _ = yellow.Printf("⚠️  Nenhuma regra das subscriptions de '%s' seleciona a mensagem: ela será descartada\n", topic.Name)
+		_, _ = blue.Printf("💡 Para entender o motivo: orion-dev route-test %s <arquivo> --rules\n", topic.Name)
+		return
+	}
+
+	_, _ = blue.Println("📬 Subscriptions que receberão a mensagem:")
+	for _, subscription := range subscriptions {
+		_, _ = blue.Printf("  - %s/%s\n", topic.Name, subscription)
+	}
+}
+
+func init() {
+	addSendFlags(pushTopicCmd)
+	addTemplateFlags(pushTopicCmd)
+	addSchemaFlags(pushTopicCmd)
+	addSendFlags(sendTopicCmd)
+	addTemplateFlags(sendTopicCmd)
+	addSchemaFlags(sendTopicCmd)
+
+	pushTopicCmd.ValidArgsFunction = completeTopicAndFile
+	sendTopicCmd.ValidArgsFunction = completeTargetAndType(completeTopics)
+}
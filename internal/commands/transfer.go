@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Comando para mover mensagens entre entidades
+var moveCmd = &cobra.Command{
+	Use:   "move [src] [dst]",
+	Short: "Mover mensagens de uma fila ou subscription para outra entidade",
+	Long: `Recebe as mensagens da origem (fila ou tópico/subscription), envia cada uma para
+a fila ou tópico de destino preservando body e propriedades e só então a remove
+da origem.
+
+Com --filter-path/--filter-property as mensagens que atendem ao filtro são
+escolhidas com um peek e só então recebidas; as demais que estão antes da
+última escolhida ficam bloqueadas e voltam para a origem no fim, uma única vez,
+com o DeliveryCount aumentado em 1. Mensagens agendadas não são movidas.
+
+Exemplo, depois de corrigir um erro de roteamento:
+  orion-dev move sbq.pismo.all sbq.pismo.transaction.creation --filter-property eventType=transaction-creation`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMove,
+}
+
+// Comando para copiar mensagens entre entidades
+var copyCmd = &cobra.Command{
+	Use:   "copy [src] [dst]",
+	Short: "Copiar mensagens de uma fila ou subscription para outra entidade",
+	Long: `Espia as mensagens da origem, sem alterá-la, e envia cópias para a fila ou
+tópico de destino preservando body e propriedades. Aceita os mesmos filtros e
+limites do 'move'.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCopy,
+}
+
+// Comando para remover todas as mensagens de uma entidade
+var purgeCmd = &cobra.Command{
+	Use:   "purge [entity]",
+	Short: "Remover todas as mensagens de uma fila ou subscription",
+	Long: `Remove definitivamente as mensagens ativas de uma fila ou subscription.
+Mensagens agendadas não são removidas; para a dead-letter queue use 'dlq purge'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPurge,
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	return runTransfer(cmd, args, true)
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	return runTransfer(cmd, args, false)
+}
+
+// runTransfer executa move (remove da origem) ou copy (apenas espia a origem)
+func runTransfer(cmd *cobra.Command, args []string, move bool) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	source, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+	destination, err := parseDestinationArg(args[1])
+	if err != nil {
+		return err
+	}
+
+	filter, err := getMessageFilter(cmd)
+	if err != nil {
+		return err
+	}
+	maxMessages, _ := cmd.Flags().GetInt("max")
+	deadLetter, _ := cmd.Flags().GetBool("dlq")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if maxMessages < 0 {
+		return fmt.Errorf("--max não pode ser negativo")
+	}
+
+	options := servicebus.TransferOptions{MaxMessages: maxMessages, DeadLetter: deadLetter}
+	if !filter.IsEmpty() {
+		options.Match = filter.Match
+	}
+
+	origin := source.String()
+	if deadLetter {
+		origin += " (dead-letter queue)"
+	}
+	action := "Copiando"
+	if move {
+		action = "Movendo"
+	}
+	_, _ = blue.Printf("🔀 %s mensagens de '%s' para '%s'...\n", action, origin, destination)
+	if deadLetter && source.IsSubscription() && destination == source.Topic {
+		_, _ = yellow.Printf("⚠️  O destino é o tópico '%s': todas as subscriptions receberão as mensagens\n", destination)
+	}
+	fmt.Println()
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	start := time.Now()
+	var result *servicebus.TransferResult
+	if move {
+		result, err = client.MoveMessages(cmd.Context(), source, destination, options)
+	} else {
+		result, err = client.CopyMessages(cmd.Context(), source, destination, options)
+	}
+	if result != nil && !quiet {
+		for _, message := range result.Transferred {
+			_, _ = green.Printf("  ✅ %s (seq %d)\n", message.MessageID, message.SequenceNumber)
+		}
+	}
+
+	if result != nil {
+		fmt.Println()
+		_, _ = blue.Println("📊 Resumo:")
+		if move {
+			fmt.Printf("  Movidas:    %d\n", len(result.Transferred))
+		} else {
+			fmt.Printf("  Copiadas:   %d\n", len(result.Transferred))
+		}
+		if !filter.IsEmpty() {
+			fmt.Printf("  Ignoradas:  %d (não atendem ao filtro)\n", result.Skipped)
+		}
+		fmt.Printf("  Duração:    %s\n", time.Since(start).Round(time.Millisecond))
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao transferir mensagens: %w", err)
+	}
+
+	if len(result.Transferred) == 0 {
+		_, _ = blue.Println("ℹ️  Nenhuma mensagem transferida")
+		return nil
+	}
+	_, _ = green.Println("✅ Transferência concluída!")
+	return nil
+}
+
+func runPurge(cmd *cobra.Command, args []string) error {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+
+	entity, err := parseEntityArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	if !force && !confirmAction(fmt.Sprintf("Remover definitivamente todas as mensagens de '%s'?", entity)) {
+		_, _ = blue.Println("ℹ️  Operação cancelada")
+		return nil
+	}
+
+	_, _ = blue.Printf("🧹 Limpando '%s'...\n", entity)
+
+	client, err := newServiceBusClient(cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao service bus: %w", err)
+	}
+	defer closeClient(cmd, client)
+
+	total, err := client.PurgeMessages(cmd.Context(), entity)
+	if err != nil {
+		return fmt.Errorf("erro ao limpar '%s' (%d mensagem(ns) já removida(s)): %w", entity, total, err)
+	}
+
+	_, _ = green.Printf("✅ %d mensagem(ns) removida(s)\n", total)
+	return nil
+}
+
+// parseDestinationArg valida, contra a configuração do emulador, a fila ou tópico de destino
+func parseDestinationArg(name string) (string, error) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+	if !reg.HasQueue(name) && !reg.HasTopic(name) {
+		return "", fmt.Errorf("destino '%s' não é uma fila nem um tópico declarado na configuração do emulador", name)
+	}
+	return name, nil
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{moveCmd, copyCmd} {
+		addFilterFlags(cmd)
+		cmd.Flags().Int("max", 0, "Número máximo de mensagens transferidas (0 = todas)")
+		cmd.Flags().Bool("dlq", false, "Ler da dead-letter queue da origem")
+		cmd.Flags().BoolP("quiet", "q", false, "Mostrar apenas o resumo")
+		cmd.ValidArgsFunction = completeTransfer
+	}
+
+	purgeCmd.Flags().BoolP("force", "f", false, "Não pedir confirmação")
+	purgeCmd.ValidArgsFunction = completeEntity
+}
@@ -0,0 +1,477 @@
+// Package diff compara documentos JSON decodificados com regras para campos voláteis:
+// caminhos ignorados, expressões regulares e tolerâncias numéricas. Mostra as diferenças
+// como um diff estrutural colorido.
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind é o tipo de uma diferença
+type Kind int
+
+const (
+	// Changed indica valores diferentes no mesmo caminho
+	Changed Kind = iota
+	// Added indica um caminho presente apenas no documento recebido
+	Added
+	// Removed indica um caminho presente apenas no documento esperado
+	Removed
+)
+
+// String retorna o nome do tipo da diferença
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "adicionado"
+	case Removed:
+		return "ausente"
+	default:
+		return "alterado"
+	}
+}
+
+// Difference é uma diferença entre o documento esperado e o recebido
+type Difference struct {
+	Path     string
+	Kind     Kind
+	Expected interface{}
+	Actual   interface{}
+	// Detail explica diferenças de regras (expressão regular ou tolerância)
+	Detail string
+}
+
+// String descreve a diferença em uma linha
+func (d Difference) String() string {
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf("%s: adicionado %s", d.Path, formatValue(d.Actual))
+	case Removed:
+		return fmt.Sprintf("%s: ausente, esperado %s", d.Path, formatValue(d.Expected))
+	}
+	if d.Detail != "" {
+		return fmt.Sprintf("%s: %s %s", d.Path, formatValue(d.Actual), d.Detail)
+	}
+	return fmt.Sprintf("%s: esperado %s, obtido %s", d.Path, formatValue(d.Expected), formatValue(d.Actual))
+}
+
+// Options são as regras da comparação. Os caminhos usam notação de ponto, como nas assertions
+// dos cenários (ex: body.data.items[0].id), e aceitam * para qualquer chave e [*] para qualquer índice.
+type Options struct {
+	// Ignore são os caminhos não comparados (ex: body.data.createdAt, properties.traceId)
+	Ignore []string
+	// Match exige que o valor recebido no caminho corresponda à expressão regular; o valor esperado é desconsiderado
+	Match map[string]string
+	// Tolerance aceita diferenças numéricas de até o valor informado no caminho
+	Tolerance map[string]float64
+}
+
+// Comparer compara documentos com regras já validadas
+type Comparer struct {
+	ignore    []pattern
+	match     []matchRule
+	tolerance []toleranceRule
+}
+
+type matchRule struct {
+	pattern pattern
+	regexp  *regexp.Regexp
+}
+
+type toleranceRule struct {
+	pattern pattern
+	delta   float64
+}
+
+// New valida as regras e cria o Comparer
+func New(options Options) (*Comparer, error) {
+	comparer := &Comparer{}
+	for _, path := range options.Ignore {
+		p, err := parsePattern(path)
+		if err != nil {
+			return nil, err
+		}
+		comparer.ignore = append(comparer.ignore, p)
+	}
+
+	for _, path := range sortedKeys(options.Match) {
+		p, err := parsePattern(path)
+		if err != nil {
+			return nil, err
+		}
+		expression, err := regexp.Compile(options.Match[path])
+		if err != nil {
+			return nil, fmt.Errorf("match '%s': expressão regular inválida: %w", path, err)
+		}
+		comparer.match = append(comparer.match, matchRule{pattern: p, regexp: expression})
+	}
+
+	for _, path := range sortedKeys(options.Tolerance) {
+		p, err := parsePattern(path)
+		if err != nil {
+			return nil, err
+		}
+		delta := options.Tolerance[path]
+		if delta < 0 || math.IsNaN(delta) {
+			return nil, fmt.Errorf("tolerância '%s': deve ser um número maior ou igual a zero", path)
+		}
+		comparer.tolerance = append(comparer.tolerance, toleranceRule{pattern: p, delta: delta})
+	}
+	return comparer, nil
+}
+
+// Compare retorna as diferenças entre o documento esperado e o recebido, na ordem dos caminhos
+func (c *Comparer) Compare(expected, actual interface{}) []Difference {
+	var differences []Difference
+	c.walk(nil, expected, true, actual, true, func(d Difference) { differences = append(differences, d) }, nil)
+	return differences
+}
+
+// Normalize converte um valor serializável nos tipos de encoding/json, com números como json.Number,
+// para que valores de origens diferentes (golden, mensagem recebida) sejam comparáveis
+func Normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter em JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("erro ao converter em JSON: %w", err)
+	}
+	return result, nil
+}
+
+// visitor recebe cada linha do diff estrutural; nil quando só as diferenças interessam
+type visitor func(line line)
+
+// walk compara expected e actual no caminho, reportando diferenças em report e linhas em emit
+func (c *Comparer) walk(path []string, expected interface{}, hasExpected bool, actual interface{}, hasActual bool, report func(Difference), emit visitor) {
+	name := formatPath(path)
+
+	if c.ignored(path) {
+		if emit != nil {
+			value := actual
+			if !hasActual {
+				value = expected
+			}
+			emit(line{path: path, kind: lineIgnored, value: value})
+		}
+		return
+	}
+
+	if rule, ok := c.matchRule(path); ok {
+		text := valueText(actual)
+		if hasActual && rule.regexp.MatchString(text) {
+			if emit != nil {
+				emit(line{path: path, kind: lineMatched, value: actual, note: "/" + rule.regexp.String() + "/"})
+			}
+			return
+		}
+		difference := Difference{Path: name, Kind: Changed, Expected: expected, Actual: actual, Detail: "não corresponde a /" + rule.regexp.String() + "/"}
+		if !hasActual {
+			difference = Difference{Path: name, Kind: Removed, Expected: expected}
+		}
+		report(difference)
+		if emit != nil {
+			emit(line{path: path, kind: lineRemoved, value: "/" + rule.regexp.String() + "/", raw: true})
+			if hasActual {
+				emit(line{path: path, kind: lineAdded, value: actual})
+			}
+		}
+		return
+	}
+
+	switch {
+	case !hasActual:
+		report(Difference{Path: name, Kind: Removed, Expected: expected})
+		if emit != nil {
+			emit(line{path: path, kind: lineRemoved, value: expected})
+		}
+		return
+	case !hasExpected:
+		report(Difference{Path: name, Kind: Added, Actual: actual})
+		if emit != nil {
+			emit(line{path: path, kind: lineAdded, value: actual})
+		}
+		return
+	}
+
+	if rule, ok := c.toleranceRule(path); ok {
+		e, eok := toFloat(expected)
+		a, aok := toFloat(actual)
+		if eok && aok {
+			note := "±" + strconv.FormatFloat(rule.delta, 'f', -1, 64)
+			if math.Abs(e-a) <= rule.delta {
+				if emit != nil {
+					emit(line{path: path, kind: lineMatched, value: actual, note: note})
+				}
+				return
+			}
+			report(Difference{Path: name, Kind: Changed, Expected: expected, Actual: actual, Detail: fmt.Sprintf("fora da tolerância de %s em relação a %s", note, formatValue(expected))})
+			if emit != nil {
+				emit(line{path: path, kind: lineRemoved, value: expected, note: note})
+				emit(line{path: path, kind: lineAdded, value: actual})
+			}
+			return
+		}
+	}
+
+	expectedObject, eObject := expected.(map[string]interface{})
+	actualObject, aObject := actual.(map[string]interface{})
+	if eObject && aObject {
+		if emit != nil && c.equal(path, expected, actual) {
+			emit(line{path: path, kind: lineEqual, value: actual})
+			return
+		}
+		if emit != nil {
+			emit(line{path: path, kind: lineOpen, value: "{"})
+		}
+		for _, key := range unionKeys(expectedObject, actualObject) {
+			e, eok := expectedObject[key]
+			a, aok := actualObject[key]
+			c.walk(appendPath(path, key), e, eok, a, aok, report, emit)
+		}
+		if emit != nil {
+			emit(line{path: path, kind: lineClose, value: "}"})
+		}
+		return
+	}
+
+	expectedArray, eArray := expected.([]interface{})
+	actualArray, aArray := actual.([]interface{})
+	if eArray && aArray {
+		if emit != nil && c.equal(path, expected, actual) {
+			emit(line{path: path, kind: lineEqual, value: actual})
+			return
+		}
+		if emit != nil {
+			emit(line{path: path, kind: lineOpen, value: "["})
+		}
+		for i := 0; i < len(expectedArray) || i < len(actualArray); i++ {
+			var e, a interface{}
+			if i < len(expectedArray) {
+				e = expectedArray[i]
+			}
+			if i < len(actualArray) {
+				a = actualArray[i]
+			}
+			c.walk(appendPath(path, "["+strconv.Itoa(i)+"]"), e, i < len(expectedArray), a, i < len(actualArray), report, emit)
+		}
+		if emit != nil {
+			emit(line{path: path, kind: lineClose, value: "]"})
+		}
+		return
+	}
+
+	if equalLeaves(expected, actual) {
+		if emit != nil {
+			emit(line{path: path, kind: lineEqual, value: actual})
+		}
+		return
+	}
+	report(Difference{Path: name, Kind: Changed, Expected: expected, Actual: actual})
+	if emit != nil {
+		emit(line{path: path, kind: lineRemoved, value: expected})
+		emit(line{path: path, kind: lineAdded, value: actual})
+	}
+}
+
+// equal indica se não há diferenças abaixo do caminho, considerando as regras
+func (c *Comparer) equal(path []string, expected, actual interface{}) bool {
+	equal := true
+	c.walk(path, expected, true, actual, true, func(Difference) { equal = false }, nil)
+	return equal
+}
+
+func (c *Comparer) ignored(path []string) bool {
+	for _, p := range c.ignore {
+		if p.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Comparer) matchRule(path []string) (matchRule, bool) {
+	for _, rule := range c.match {
+		if rule.pattern.matches(path) {
+			return rule, true
+		}
+	}
+	return matchRule{}, false
+}
+
+func (c *Comparer) toleranceRule(path []string) (toleranceRule, bool) {
+	for _, rule := range c.tolerance {
+		if rule.pattern.matches(path) {
+			return rule, true
+		}
+	}
+	return toleranceRule{}, false
+}
+
+// equalLeaves compara valores simples; números são comparados pelo valor (1.0 == 1)
+func equalLeaves(expected, actual interface{}) bool {
+	e, eok := expected.(json.Number)
+	a, aok := actual.(json.Number)
+	if eok && aok {
+		er, erok := new(big.Rat).SetString(e.String())
+		ar, arok := new(big.Rat).SetString(a.String())
+		if erok && arok {
+			return er.Cmp(ar) == 0
+		}
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// toFloat converte números JSON (e números em texto) em float64 para a tolerância
+func toFloat(value interface{}) (float64, bool) {
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case float64:
+		return v, true
+	case string:
+		text = v
+	default:
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	return number, err == nil
+}
+
+// valueText é o texto comparado pelas expressões regulares: strings sem aspas, demais valores como JSON
+func valueText(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return formatValue(value)
+}
+
+// formatValue formata um valor em JSON compacto
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// appendPath cria um novo caminho sem compartilhar o array com o caminho pai
+func appendPath(path []string, segment string) []string {
+	result := make([]string, len(path)+1)
+	copy(result, path)
+	result[len(path)] = segment
+	return result
+}
+
+// formatPath monta o caminho em notação de ponto (ex: body.items[0].id)
+func formatPath(path []string) string {
+	var builder strings.Builder
+	for _, segment := range path {
+		if builder.Len() > 0 && !strings.HasPrefix(segment, "[") {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(segment)
+	}
+	if builder.Len() == 0 {
+		return "$"
+	}
+	return builder.String()
+}
+
+// pattern é um caminho com curingas: "*" aceita qualquer chave e "[*]" qualquer índice
+type pattern []string
+
+// parsePattern interpreta caminhos como "body.data.items[*].id" ou "$.properties.*"
+func parsePattern(path string) (pattern, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	text = strings.TrimPrefix(text, ".")
+	if text == "" {
+		return nil, fmt.Errorf("caminho vazio")
+	}
+
+	var segments pattern
+	for _, part := range strings.Split(text, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("caminho inválido: %s", path)
+		}
+
+		key, rest := part, ""
+		if open := strings.Index(part, "["); open >= 0 {
+			key, rest = part[:open], part[open:]
+		}
+		if key != "" {
+			segments = append(segments, key)
+		}
+
+		for rest != "" {
+			closeIdx := strings.Index(rest, "]")
+			if !strings.HasPrefix(rest, "[") || closeIdx < 0 {
+				return nil, fmt.Errorf("caminho inválido: %s", path)
+			}
+			index := rest[1:closeIdx]
+			if index != "*" {
+				if n, err := strconv.Atoi(index); err != nil || n < 0 {
+					return nil, fmt.Errorf("índice inválido em %s", path)
+				}
+			}
+			segments = append(segments, "["+index+"]")
+			rest = rest[closeIdx+1:]
+		}
+	}
+	return segments, nil
+}
+
+// matches indica se o caminho corresponde ao padrão
+func (p pattern) matches(path []string) bool {
+	if len(p) != len(path) {
+		return false
+	}
+	for i, segment := range p {
+		switch {
+		case segment == path[i]:
+		case segment == "*" && !strings.HasPrefix(path[i], "["):
+		case segment == "[*]" && strings.HasPrefix(path[i], "["):
+		default:
+			return false
+		}
+	}
+	return true
+}
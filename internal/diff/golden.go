@@ -0,0 +1,114 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"fin.orion.dev/internal/servicebus"
+)
+
+// Golden é o arquivo com o body e as propriedades esperados de uma mensagem e as regras para
+// os campos voláteis. Seções ausentes (body ou properties) não são comparadas.
+//
+// Exemplo:
+//
+//	{
+//	  "body": {"data": {"id": "...", "status": "created", "amount": 150.5, "createdAt": "..."}},
+//	  "properties": {"eventType": "transaction-created"},
+//	  "ignore": ["body.data.createdAt", "properties.traceId"],
+//	  "match": {"body.data.id": "^[0-9a-f-]{36}$"},
+//	  "tolerance": {"body.data.amount": 0.01}
+//	}
+type Golden struct {
+	Body       json.RawMessage    `json:"body,omitempty"`
+	Properties json.RawMessage    `json:"properties,omitempty"`
+	Ignore     []string           `json:"ignore,omitempty"`
+	Match      map[string]string  `json:"match,omitempty"`
+	Tolerance  map[string]float64 `json:"tolerance,omitempty"`
+}
+
+// ReadGolden lê um arquivo golden, rejeitando campos desconhecidos
+func ReadGolden(reader io.Reader) (*Golden, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	var golden Golden
+	if err := decoder.Decode(&golden); err != nil {
+		return nil, fmt.Errorf("golden inválido: %w", err)
+	}
+	if golden.Body == nil && golden.Properties == nil {
+		return nil, fmt.Errorf("golden inválido: informe body e/ou properties")
+	}
+	return &golden, nil
+}
+
+// Options retorna as regras do golden
+func (g *Golden) Options() Options {
+	return Options{Ignore: g.Ignore, Match: g.Match, Tolerance: g.Tolerance}
+}
+
+// Expected retorna o documento esperado, com as seções presentes no golden
+func (g *Golden) Expected() (interface{}, error) {
+	document := make(map[string]interface{})
+	for name, raw := range map[string]json.RawMessage{"body": g.Body, "properties": g.Properties} {
+		if raw == nil {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("golden inválido em %s: %w", name, err)
+		}
+		document[name] = value
+	}
+	return document, nil
+}
+
+// Actual retorna o documento recebido com as mesmas seções do golden
+func (g *Golden) Actual(message *servicebus.Message) (interface{}, error) {
+	document := make(map[string]interface{})
+	if g.Body != nil {
+		document["body"] = message.Body
+	}
+	if g.Properties != nil {
+		properties := message.Properties
+		if properties == nil {
+			properties = map[string]interface{}{}
+		}
+		document["properties"] = properties
+	}
+	return Normalize(document)
+}
+
+// Update substitui o body e as propriedades pelos da mensagem recebida, mantendo as regras
+func (g *Golden) Update(message *servicebus.Message) error {
+	body, err := json.Marshal(message.Body)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar body: %w", err)
+	}
+	properties := message.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	encodedProperties, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar propriedades: %w", err)
+	}
+	g.Body, g.Properties = body, encodedProperties
+	return nil
+}
+
+// WriteGolden grava o golden com indentação
+func WriteGolden(w io.Writer, golden *Golden) error {
+	data, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar golden: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("erro ao gravar golden: %w", err)
+	}
+	return nil
+}
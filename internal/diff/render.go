@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// maxInline é o tamanho máximo de um objeto ou array sem diferenças mostrado em uma linha
+const maxInline = 80
+
+type lineKind int
+
+const (
+	lineEqual lineKind = iota
+	lineOpen
+	lineClose
+	lineAdded
+	lineRemoved
+	lineIgnored
+	lineMatched
+)
+
+// line é uma linha do diff estrutural
+type line struct {
+	path  []string
+	kind  lineKind
+	value interface{}
+	// note é a regra aplicada (expressão regular ou tolerância)
+	note string
+	// raw mostra value como texto, sem serializar em JSON
+	raw bool
+}
+
+// Write mostra o diff estrutural entre o documento esperado e o recebido: linhas com "-" trazem o
+// valor esperado, com "+" o recebido. Objetos e arrays sem diferenças aparecem em uma única linha.
+func (c *Comparer) Write(w io.Writer, expected, actual interface{}) {
+	red := color.New(color.FgRed)
+	green := color.New(color.FgGreen)
+	cyan := color.New(color.FgCyan)
+	faint := color.New(color.Faint)
+
+	c.walk(nil, expected, true, actual, true, func(Difference) {}, func(l line) {
+		indent := strings.Repeat("  ", len(l.path))
+		label := ""
+		if n := len(l.path); n > 0 && !strings.HasPrefix(l.path[n-1], "[") && l.kind != lineClose {
+			label = fmt.Sprintf("%q: ", l.path[n-1])
+		}
+
+		value := formatValue(l.value)
+		if l.raw || l.kind == lineOpen || l.kind == lineClose {
+			value = fmt.Sprint(l.value)
+		}
+
+		switch l.kind {
+		case lineAdded:
+			_, _ = green.Fprintf(w, "+ %s%s%s\n", indent, label, value)
+		case lineRemoved:
+			if l.note != "" {
+				value += " (" + l.note + ")"
+			}
+			_, _ = red.Fprintf(w, "- %s%s%s\n", indent, label, value)
+		case lineIgnored:
+			_, _ = faint.Fprintf(w, "  %s%s%s  # ignorado\n", indent, label, truncate(value))
+		case lineMatched:
+			_, _ = fmt.Fprintf(w, "  %s%s%s", indent, label, value)
+			_, _ = cyan.Fprintf(w, "  # %s\n", l.note)
+		case lineEqual:
+			_, _ = fmt.Fprintf(w, "  %s%s%s\n", indent, label, truncate(value))
+		default:
+			_, _ = fmt.Fprintf(w, "  %s%s%s\n", indent, label, value)
+		}
+	})
+}
+
+// truncate encurta valores longos sem diferenças
+func truncate(text string) string {
+	if utf8.RuneCountInString(text) <= maxInline {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxInline-1]) + "…"
+}
@@ -0,0 +1,239 @@
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DefaultConfigPath é o caminho da configuração do Service Bus Emulator montada pelo docker-compose
+const DefaultConfigPath = "docker/service-bus/config.json"
+
+// Config representa o arquivo de configuração do Service Bus Emulator
+type Config struct {
+	UserConfig UserConfig `json:"UserConfig"`
+}
+
+// UserConfig contém os namespaces e as opções de log do emulador
+type UserConfig struct {
+	Namespaces []Namespace `json:"Namespaces"`
+	Logging    Logging     `json:"Logging"`
+}
+
+// Namespace representa um namespace do emulador com suas filas e tópicos
+type Namespace struct {
+	Name   string  `json:"Name"`
+	Queues []Queue `json:"Queues"`
+	Topics []Topic `json:"Topics"`
+}
+
+// Queue representa uma fila do emulador
+type Queue struct {
+	Name       string          `json:"Name"`
+	Properties QueueProperties `json:"Properties"`
+}
+
+// QueueProperties contém as propriedades de uma fila
+type QueueProperties struct {
+	DeadLetteringOnMessageExpiration    bool   `json:"DeadLetteringOnMessageExpiration"`
+	DefaultMessageTimeToLive            string `json:"DefaultMessageTimeToLive"`
+	DuplicateDetectionHistoryTimeWindow string `json:"DuplicateDetectionHistoryTimeWindow"`
+	ForwardDeadLetteredMessagesTo       string `json:"ForwardDeadLetteredMessagesTo"`
+	ForwardTo                           string `json:"ForwardTo"`
+	LockDuration                        string `json:"LockDuration"`
+	MaxDeliveryCount                    int    `json:"MaxDeliveryCount"`
+	RequiresDuplicateDetection          bool   `json:"RequiresDuplicateDetection"`
+	RequiresSession                     bool   `json:"RequiresSession"`
+}
+
+// Topic representa um tópico do emulador com suas subscriptions
+type Topic struct {
+	Name          string          `json:"Name"`
+	Properties    TopicProperties `json:"Properties"`
+	Subscriptions []Subscription  `json:"Subscriptions"`
+}
+
+// TopicProperties contém as propriedades de um tópico
+type TopicProperties struct {
+	DefaultMessageTimeToLive            string `json:"DefaultMessageTimeToLive"`
+	DuplicateDetectionHistoryTimeWindow string `json:"DuplicateDetectionHistoryTimeWindow"`
+	RequiresDuplicateDetection          bool   `json:"RequiresDuplicateDetection"`
+}
+
+// Subscription representa uma subscription de tópico. Sem regras, a subscription recebe todas as mensagens do tópico.
+type Subscription struct {
+	Name       string                 `json:"Name"`
+	Properties SubscriptionProperties `json:"Properties"`
+	Rules      []Rule                 `json:"Rules,omitempty"`
+}
+
+// SubscriptionProperties contém as propriedades de uma subscription
+type SubscriptionProperties struct {
+	DeadLetteringOnMessageExpiration bool   `json:"DeadLetteringOnMessageExpiration"`
+	DefaultMessageTimeToLive         string `json:"DefaultMessageTimeToLive"`
+	LockDuration                     string `json:"LockDuration"`
+	MaxDeliveryCount                 int    `json:"MaxDeliveryCount"`
+	ForwardDeadLetteredMessagesTo    string `json:"ForwardDeadLetteredMessagesTo"`
+	ForwardTo                        string `json:"ForwardTo"`
+	RequiresSession                  bool   `json:"RequiresSession"`
+}
+
+// Tipos de filtro aceitos em RuleProperties.FilterType
+const (
+	FilterTypeSQL         = "Sql"
+	FilterTypeCorrelation = "Correlation"
+)
+
+// DefaultRuleName é o nome da regra que o Service Bus cria com as subscriptions e que aceita todas as mensagens
+const DefaultRuleName = "$Default"
+
+// Rule representa uma regra de subscription: um filtro SQL ou de correlação e uma ação opcional
+type Rule struct {
+	Name       string         `json:"Name"`
+	Properties RuleProperties `json:"Properties"`
+}
+
+// RuleProperties contém o filtro e a ação de uma regra
+type RuleProperties struct {
+	FilterType        string             `json:"FilterType"`
+	SqlFilter         *SqlFilter         `json:"SqlFilter,omitempty"`
+	CorrelationFilter *CorrelationFilter `json:"CorrelationFilter,omitempty"`
+	Action            *SqlAction         `json:"Action,omitempty"`
+}
+
+// SqlFilter contém a expressão de um filtro SQL
+type SqlFilter struct {
+	SqlExpression string `json:"SqlExpression"`
+}
+
+// SqlAction contém a expressão de uma ação SQL (SET/REMOVE)
+type SqlAction struct {
+	SqlExpression string `json:"SqlExpression"`
+}
+
+// CorrelationFilter compara propriedades de sistema e de aplicação por igualdade.
+// Label corresponde ao Subject da mensagem.
+type CorrelationFilter struct {
+	ContentType      string                 `json:"ContentType,omitempty"`
+	CorrelationId    string                 `json:"CorrelationId,omitempty"`
+	Label            string                 `json:"Label,omitempty"`
+	MessageId        string                 `json:"MessageId,omitempty"`
+	ReplyTo          string                 `json:"ReplyTo,omitempty"`
+	ReplyToSessionId string                 `json:"ReplyToSessionId,omitempty"`
+	SessionId        string                 `json:"SessionId,omitempty"`
+	To               string                 `json:"To,omitempty"`
+	Properties       map[string]interface{} `json:"Properties,omitempty"`
+}
+
+// IsEmpty indica se o filtro de correlação não tem nenhuma condição
+func (f *CorrelationFilter) IsEmpty() bool {
+	return f.ContentType == "" && f.CorrelationId == "" && f.Label == "" && f.MessageId == "" &&
+		f.ReplyTo == "" && f.ReplyToSessionId == "" && f.SessionId == "" && f.To == "" && len(f.Properties) == 0
+}
+
+// Logging contém a configuração de log do emulador
+type Logging struct {
+	Type string `json:"Type"`
+}
+
+// LoadConfig lê e interpreta o arquivo de configuração do emulador
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler configuração do emulador: %w", err)
+	}
+
+	return ParseConfig(data)
+}
+
+// ParseConfig interpreta o conteúdo de uma configuração do emulador
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("configuração do emulador inválida: %w", err)
+	}
+	return &config, nil
+}
+
+// Marshal serializa a configuração no mesmo formato do arquivo original
+func (c *Config) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar configuração do emulador: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Save grava a configuração no caminho informado
+func (c *Config) Save(path string) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar configuração do emulador: %w", err)
+	}
+	return nil
+}
+
+// FindQueue procura uma fila em todos os namespaces
+func (c *Config) FindQueue(name string) *Queue {
+	for i := range c.UserConfig.Namespaces {
+		namespace := &c.UserConfig.Namespaces[i]
+		for j := range namespace.Queues {
+			if namespace.Queues[j].Name == name {
+				return &namespace.Queues[j]
+			}
+		}
+	}
+	return nil
+}
+
+// FindTopic procura um tópico em todos os namespaces
+func (c *Config) FindTopic(name string) *Topic {
+	for i := range c.UserConfig.Namespaces {
+		namespace := &c.UserConfig.Namespaces[i]
+		for j := range namespace.Topics {
+			if namespace.Topics[j].Name == name {
+				return &namespace.Topics[j]
+			}
+		}
+	}
+	return nil
+}
+
+// FindSubscription procura uma subscription de um tópico
+func (c *Config) FindSubscription(topicName, subscriptionName string) *Subscription {
+	topic := c.FindTopic(topicName)
+	if topic == nil {
+		return nil
+	}
+
+	for i := range topic.Subscriptions {
+		if topic.Subscriptions[i].Name == subscriptionName {
+			return &topic.Subscriptions[i]
+		}
+	}
+	return nil
+}
+
+// SetQueueRequiresSession liga ou desliga sessões em uma fila
+func (c *Config) SetQueueRequiresSession(queueName string, enabled bool) error {
+	queue := c.FindQueue(queueName)
+	if queue == nil {
+		return fmt.Errorf("fila '%s' não encontrada na configuração do emulador", queueName)
+	}
+	queue.Properties.RequiresSession = enabled
+	return nil
+}
+
+// SetSubscriptionRequiresSession liga ou desliga sessões em uma subscription
+func (c *Config) SetSubscriptionRequiresSession(topicName, subscriptionName string, enabled bool) error {
+	subscription := c.FindSubscription(topicName, subscriptionName)
+	if subscription == nil {
+		return fmt.Errorf("subscription '%s/%s' não encontrada na configuração do emulador", topicName, subscriptionName)
+	}
+	subscription.Properties.RequiresSession = enabled
+	return nil
+}
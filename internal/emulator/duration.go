@@ -0,0 +1,74 @@
+package emulator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration interpreta uma duração ISO-8601 no formato aceito pelo emulador (ex.: PT1H, P1DT12H, PT0.5S).
+// Anos e meses não são aceitos, já que não têm duração fixa. Valores acima do limite de time.Duration,
+// como o TTL máximo do Service Bus (P10675199DT2H48M5.4775807S), são limitados a cerca de 292 anos.
+func ParseDuration(value string) (time.Duration, error) {
+	original := value
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if !strings.HasPrefix(value, "P") || len(value) < 2 {
+		return 0, fmt.Errorf("duração ISO-8601 inválida '%s': use o formato PnDTnHnMnS (ex.: PT1H)", original)
+	}
+	value = value[1:]
+
+	var total float64
+	inTime := false
+	components := 0
+	for value != "" {
+		if value[0] == 'T' {
+			if inTime || len(value) == 1 {
+				return 0, fmt.Errorf("duração ISO-8601 inválida '%s': 'T' sem componentes de hora", original)
+			}
+			inTime = true
+			value = value[1:]
+			continue
+		}
+
+		end := strings.IndexAny(value, "WDHMSY")
+		if end <= 0 {
+			return 0, fmt.Errorf("duração ISO-8601 inválida '%s'", original)
+		}
+		amount, err := strconv.ParseFloat(value[:end], 64)
+		if err != nil || amount < 0 {
+			return 0, fmt.Errorf("duração ISO-8601 inválida '%s': número '%s' inválido", original, value[:end])
+		}
+
+		var unit time.Duration
+		switch designator := value[end]; {
+		case designator == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case designator == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case designator == 'H' && inTime:
+			unit = time.Hour
+		case designator == 'M' && inTime:
+			unit = time.Minute
+		case designator == 'S' && inTime:
+			unit = time.Second
+		case designator == 'Y' || (designator == 'M' && !inTime):
+			return 0, fmt.Errorf("duração ISO-8601 inválida '%s': anos e meses não são suportados", original)
+		default:
+			return 0, fmt.Errorf("duração ISO-8601 inválida '%s': componente '%c' fora de posição", original, designator)
+		}
+
+		total += amount * float64(unit)
+		components++
+		value = value[end+1:]
+	}
+
+	if components == 0 {
+		return 0, fmt.Errorf("duração ISO-8601 inválida '%s': nenhum componente informado", original)
+	}
+	if total >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64), nil
+	}
+	return time.Duration(total), nil
+}
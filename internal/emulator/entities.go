@@ -0,0 +1,465 @@
+package emulator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/sqlfilter"
+)
+
+// Limites documentados do Service Bus Emulator e das propriedades das entidades
+const (
+	MaxNamespaces            = 1
+	MaxEntitiesPerNamespace  = 50
+	MaxSubscriptionsPerTopic = 50
+	MaxSQLFiltersPerTopic    = 20
+	MaxCorrelationFilters    = 1000
+	MaxEntityNameLength      = 260
+	MaxSubscriptionNameLen   = 50
+	MaxLockDuration          = 5 * time.Minute
+	MinDuplicateWindow       = 20 * time.Second
+	MaxDuplicateWindow       = 7 * 24 * time.Hour
+)
+
+// entityNamePattern aceita letras, números, pontos, hífens e sublinhados, começando e terminando
+// com letra ou número. A barra é recusada porque separa tópico e subscription na CLI.
+var entityNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+// DefaultQueueProperties retorna as propriedades usadas pelas filas já declaradas no emulador
+func DefaultQueueProperties() QueueProperties {
+	return QueueProperties{
+		DefaultMessageTimeToLive:            "PT1H",
+		DuplicateDetectionHistoryTimeWindow: "PT20S",
+		LockDuration:                        "PT1M",
+		MaxDeliveryCount:                    3,
+	}
+}
+
+// DefaultTopicProperties retorna as propriedades usadas pelos tópicos já declarados no emulador
+func DefaultTopicProperties() TopicProperties {
+	return TopicProperties{
+		DefaultMessageTimeToLive:            "PT1H",
+		DuplicateDetectionHistoryTimeWindow: "PT20S",
+	}
+}
+
+// DefaultSubscriptionProperties retorna as propriedades usadas pelas subscriptions já declaradas no emulador
+func DefaultSubscriptionProperties() SubscriptionProperties {
+	return SubscriptionProperties{
+		DefaultMessageTimeToLive: "PT1H",
+		LockDuration:             "PT1M",
+		MaxDeliveryCount:         3,
+	}
+}
+
+// namespace retorna o namespace onde novas entidades são criadas; o emulador aceita apenas um
+func (c *Config) namespace() (*Namespace, error) {
+	if len(c.UserConfig.Namespaces) == 0 {
+		return nil, fmt.Errorf("a configuração do emulador não declara nenhum namespace")
+	}
+	return &c.UserConfig.Namespaces[0], nil
+}
+
+// hasEntity indica se já existe fila ou tópico com o nome informado
+func (c *Config) hasEntity(name string) bool {
+	return c.FindQueue(name) != nil || c.FindTopic(name) != nil
+}
+
+// AddQueue adiciona uma fila ao namespace do emulador.
+// As propriedades são verificadas por Validate, que deve ser chamado antes de gravar.
+func (c *Config) AddQueue(queue Queue) error {
+	if err := validateEntityName(queue.Name, MaxEntityNameLength); err != nil {
+		return err
+	}
+	if c.hasEntity(queue.Name) {
+		return fmt.Errorf("já existe uma fila ou tópico chamado '%s'", queue.Name)
+	}
+
+	namespace, err := c.namespace()
+	if err != nil {
+		return err
+	}
+	namespace.Queues = append(namespace.Queues, queue)
+	return nil
+}
+
+// AddTopic adiciona um tópico ao namespace do emulador.
+// As propriedades são verificadas por Validate, que deve ser chamado antes de gravar.
+func (c *Config) AddTopic(topic Topic) error {
+	if err := validateEntityName(topic.Name, MaxEntityNameLength); err != nil {
+		return err
+	}
+	if c.hasEntity(topic.Name) {
+		return fmt.Errorf("já existe uma fila ou tópico chamado '%s'", topic.Name)
+	}
+
+	namespace, err := c.namespace()
+	if err != nil {
+		return err
+	}
+	if topic.Subscriptions == nil {
+		topic.Subscriptions = []Subscription{}
+	}
+	namespace.Topics = append(namespace.Topics, topic)
+	return nil
+}
+
+// AddSubscription adiciona uma subscription a um tópico existente.
+// As propriedades são verificadas por Validate, que deve ser chamado antes de gravar.
+func (c *Config) AddSubscription(topicName string, subscription Subscription) error {
+	if err := validateEntityName(subscription.Name, MaxSubscriptionNameLen); err != nil {
+		return err
+	}
+
+	topic := c.FindTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("tópico '%s' não encontrado na configuração do emulador", topicName)
+	}
+	if c.FindSubscription(topicName, subscription.Name) != nil {
+		return fmt.Errorf("subscription '%s/%s' já existe", topicName, subscription.Name)
+	}
+
+	topic.Subscriptions = append(topic.Subscriptions, subscription)
+	return nil
+}
+
+// RemoveQueue remove uma fila da configuração
+func (c *Config) RemoveQueue(name string) error {
+	for i := range c.UserConfig.Namespaces {
+		namespace := &c.UserConfig.Namespaces[i]
+		for j := range namespace.Queues {
+			if namespace.Queues[j].Name == name {
+				namespace.Queues = append(namespace.Queues[:j], namespace.Queues[j+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("fila '%s' não encontrada na configuração do emulador", name)
+}
+
+// RemoveTopic remove um tópico e todas as suas subscriptions da configuração
+func (c *Config) RemoveTopic(name string) error {
+	for i := range c.UserConfig.Namespaces {
+		namespace := &c.UserConfig.Namespaces[i]
+		for j := range namespace.Topics {
+			if namespace.Topics[j].Name == name {
+				namespace.Topics = append(namespace.Topics[:j], namespace.Topics[j+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("tópico '%s' não encontrado na configuração do emulador", name)
+}
+
+// RemoveSubscription remove uma subscription de um tópico
+func (c *Config) RemoveSubscription(topicName, subscriptionName string) error {
+	topic := c.FindTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("tópico '%s' não encontrado na configuração do emulador", topicName)
+	}
+
+	for i := range topic.Subscriptions {
+		if topic.Subscriptions[i].Name == subscriptionName {
+			topic.Subscriptions = append(topic.Subscriptions[:i], topic.Subscriptions[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("subscription '%s/%s' não encontrada na configuração do emulador", topicName, subscriptionName)
+}
+
+// AddRule adiciona uma regra a uma subscription.
+// O filtro e a ação são verificados por Validate, que deve ser chamado antes de gravar.
+func (c *Config) AddRule(topicName, subscriptionName string, rule Rule) error {
+	if rule.Name != DefaultRuleName {
+		if err := validateEntityName(rule.Name, MaxSubscriptionNameLen); err != nil {
+			return err
+		}
+	}
+
+	subscription := c.FindSubscription(topicName, subscriptionName)
+	if subscription == nil {
+		return fmt.Errorf("subscription '%s/%s' não encontrada na configuração do emulador", topicName, subscriptionName)
+	}
+	for _, existing := range subscription.Rules {
+		if existing.Name == rule.Name {
+			return fmt.Errorf("regra '%s' já existe em '%s/%s'", rule.Name, topicName, subscriptionName)
+		}
+	}
+
+	subscription.Rules = append(subscription.Rules, rule)
+	return nil
+}
+
+// RemoveRule remove uma regra de uma subscription
+func (c *Config) RemoveRule(topicName, subscriptionName, ruleName string) error {
+	subscription := c.FindSubscription(topicName, subscriptionName)
+	if subscription == nil {
+		return fmt.Errorf("subscription '%s/%s' não encontrada na configuração do emulador", topicName, subscriptionName)
+	}
+
+	for i := range subscription.Rules {
+		if subscription.Rules[i].Name == ruleName {
+			subscription.Rules = append(subscription.Rules[:i], subscription.Rules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("regra '%s' não encontrada em '%s/%s'", ruleName, topicName, subscriptionName)
+}
+
+// SetQueueProperty altera uma propriedade de fila pelo nome usado no arquivo (ex.: LockDuration)
+func (c *Config) SetQueueProperty(queueName, property, value string) error {
+	queue := c.FindQueue(queueName)
+	if queue == nil {
+		return fmt.Errorf("fila '%s' não encontrada na configuração do emulador", queueName)
+	}
+	return setProperty(&queue.Properties, property, value)
+}
+
+// SetTopicProperty altera uma propriedade de tópico pelo nome usado no arquivo
+func (c *Config) SetTopicProperty(topicName, property, value string) error {
+	topic := c.FindTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("tópico '%s' não encontrado na configuração do emulador", topicName)
+	}
+	return setProperty(&topic.Properties, property, value)
+}
+
+// SetSubscriptionProperty altera uma propriedade de subscription pelo nome usado no arquivo
+func (c *Config) SetSubscriptionProperty(topicName, subscriptionName, property, value string) error {
+	subscription := c.FindSubscription(topicName, subscriptionName)
+	if subscription == nil {
+		return fmt.Errorf("subscription '%s/%s' não encontrada na configuração do emulador", topicName, subscriptionName)
+	}
+	return setProperty(&subscription.Properties, property, value)
+}
+
+// setProperty altera o campo cujo nome JSON corresponde a property, sem diferenciar maiúsculas
+func setProperty(properties interface{}, property, value string) error {
+	target := reflect.ValueOf(properties).Elem()
+	fields := target.Type()
+
+	var names []string
+	for i := 0; i < fields.NumField(); i++ {
+		name := strings.Split(fields.Field(i).Tag.Get("json"), ",")[0]
+		names = append(names, name)
+		if !strings.EqualFold(name, property) {
+			continue
+		}
+
+		field := target.Field(i)
+		switch field.Kind() {
+		case reflect.Bool:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("valor inválido '%s' para %s: use true ou false", value, name)
+			}
+			field.SetBool(parsed)
+		case reflect.Int:
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("valor inválido '%s' para %s: use um número inteiro", value, name)
+			}
+			field.SetInt(int64(parsed))
+		default:
+			field.SetString(value)
+		}
+		return nil
+	}
+
+	sort.Strings(names)
+	return fmt.Errorf("propriedade '%s' desconhecida; use uma de: %s", property, strings.Join(names, ", "))
+}
+
+// Validate verifica os limites do emulador, as durações ISO-8601 e os destinos de ForwardTo.
+// Todos os problemas encontrados são retornados juntos.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if len(c.UserConfig.Namespaces) > MaxNamespaces {
+		errs = append(errs, fmt.Errorf("o emulador aceita no máximo %d namespace (encontrados %d)", MaxNamespaces, len(c.UserConfig.Namespaces)))
+	}
+
+	for _, namespace := range c.UserConfig.Namespaces {
+		if count := len(namespace.Queues) + len(namespace.Topics); count > MaxEntitiesPerNamespace {
+			errs = append(errs, fmt.Errorf("namespace '%s' tem %d filas e tópicos; o emulador aceita no máximo %d", namespace.Name, count, MaxEntitiesPerNamespace))
+		}
+
+		for _, queue := range namespace.Queues {
+			entity := fmt.Sprintf("fila '%s'", queue.Name)
+			properties := queue.Properties
+			errs = append(errs, validateTimeToLive(entity, properties.DefaultMessageTimeToLive)...)
+			errs = append(errs, validateLockDuration(entity, properties.LockDuration)...)
+			errs = append(errs, validateDuplicateWindow(entity, properties.DuplicateDetectionHistoryTimeWindow)...)
+			errs = append(errs, validateMaxDeliveryCount(entity, properties.MaxDeliveryCount)...)
+			errs = append(errs, c.validateForward(entity, queue.Name, "ForwardTo", properties.ForwardTo)...)
+			errs = append(errs, c.validateForward(entity, queue.Name, "ForwardDeadLetteredMessagesTo", properties.ForwardDeadLetteredMessagesTo)...)
+		}
+
+		for _, topic := range namespace.Topics {
+			entity := fmt.Sprintf("tópico '%s'", topic.Name)
+			errs = append(errs, validateTimeToLive(entity, topic.Properties.DefaultMessageTimeToLive)...)
+			errs = append(errs, validateDuplicateWindow(entity, topic.Properties.DuplicateDetectionHistoryTimeWindow)...)
+			if len(topic.Subscriptions) > MaxSubscriptionsPerTopic {
+				errs = append(errs, fmt.Errorf("%s tem %d subscriptions; o emulador aceita no máximo %d", entity, len(topic.Subscriptions), MaxSubscriptionsPerTopic))
+			}
+			errs = append(errs, validateFilterLimits(entity, topic)...)
+
+			for _, subscription := range topic.Subscriptions {
+				entity := fmt.Sprintf("subscription '%s/%s'", topic.Name, subscription.Name)
+				properties := subscription.Properties
+				errs = append(errs, validateTimeToLive(entity, properties.DefaultMessageTimeToLive)...)
+				errs = append(errs, validateLockDuration(entity, properties.LockDuration)...)
+				errs = append(errs, validateMaxDeliveryCount(entity, properties.MaxDeliveryCount)...)
+				errs = append(errs, c.validateForward(entity, "", "ForwardTo", properties.ForwardTo)...)
+				errs = append(errs, c.validateForward(entity, "", "ForwardDeadLetteredMessagesTo", properties.ForwardDeadLetteredMessagesTo)...)
+				errs = append(errs, validateRules(entity, subscription.Rules)...)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateEntityName verifica o formato e o tamanho do nome de uma entidade
+func validateEntityName(name string, maxLength int) error {
+	if len(name) > maxLength {
+		return fmt.Errorf("nome '%s' tem %d caracteres; o máximo é %d", name, len(name), maxLength)
+	}
+	if !entityNamePattern.MatchString(name) {
+		return fmt.Errorf("nome '%s' inválido: use letras, números, '.', '-' ou '_', começando e terminando com letra ou número", name)
+	}
+	return nil
+}
+
+// validateTimeToLive verifica DefaultMessageTimeToLive, que é obrigatório e positivo
+func validateTimeToLive(entity, value string) []error {
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return []error{fmt.Errorf("%s: DefaultMessageTimeToLive: %w", entity, err)}
+	}
+	if duration <= 0 {
+		return []error{fmt.Errorf("%s: DefaultMessageTimeToLive deve ser maior que zero", entity)}
+	}
+	return nil
+}
+
+// validateLockDuration verifica LockDuration, limitado a 5 minutos
+func validateLockDuration(entity, value string) []error {
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return []error{fmt.Errorf("%s: LockDuration: %w", entity, err)}
+	}
+	if duration <= 0 || duration > MaxLockDuration {
+		return []error{fmt.Errorf("%s: LockDuration '%s' deve estar entre PT1S e PT5M", entity, value)}
+	}
+	return nil
+}
+
+// validateDuplicateWindow verifica DuplicateDetectionHistoryTimeWindow, entre 20 segundos e 7 dias
+func validateDuplicateWindow(entity, value string) []error {
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return []error{fmt.Errorf("%s: DuplicateDetectionHistoryTimeWindow: %w", entity, err)}
+	}
+	if duration < MinDuplicateWindow || duration > MaxDuplicateWindow {
+		return []error{fmt.Errorf("%s: DuplicateDetectionHistoryTimeWindow '%s' deve estar entre PT20S e P7D", entity, value)}
+	}
+	return nil
+}
+
+// validateMaxDeliveryCount verifica se MaxDeliveryCount é positivo
+func validateMaxDeliveryCount(entity string, value int) []error {
+	if value < 1 {
+		return []error{fmt.Errorf("%s: MaxDeliveryCount deve ser maior ou igual a 1", entity)}
+	}
+	return nil
+}
+
+// validateRules verifica nomes, tipos de filtro e a sintaxe das expressões SQL das regras
+func validateRules(entity string, rules []Rule) []error {
+	var errs []error
+	names := map[string]bool{}
+
+	for _, rule := range rules {
+		prefix := fmt.Sprintf("%s: regra '%s'", entity, rule.Name)
+		if rule.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: regra sem nome", entity))
+		} else if names[rule.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicada", prefix))
+		}
+		names[rule.Name] = true
+
+		properties := rule.Properties
+		switch properties.FilterType {
+		case FilterTypeSQL:
+			if properties.SqlFilter == nil {
+				errs = append(errs, fmt.Errorf("%s: FilterType Sql exige SqlFilter", prefix))
+			} else if _, err := sqlfilter.Parse(properties.SqlFilter.SqlExpression); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			}
+			if properties.CorrelationFilter != nil {
+				errs = append(errs, fmt.Errorf("%s: FilterType Sql não aceita CorrelationFilter", prefix))
+			}
+		case FilterTypeCorrelation:
+			if properties.CorrelationFilter == nil || properties.CorrelationFilter.IsEmpty() {
+				errs = append(errs, fmt.Errorf("%s: FilterType Correlation exige ao menos uma condição em CorrelationFilter", prefix))
+			}
+			if properties.SqlFilter != nil {
+				errs = append(errs, fmt.Errorf("%s: FilterType Correlation não aceita SqlFilter", prefix))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: FilterType '%s' inválido; use %s ou %s", prefix, properties.FilterType, FilterTypeSQL, FilterTypeCorrelation))
+		}
+
+		if properties.Action != nil {
+			if _, err := sqlfilter.ParseAction(properties.Action.SqlExpression); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			}
+		}
+	}
+	return errs
+}
+
+// validateFilterLimits verifica o número de filtros SQL e de correlação do tópico
+func validateFilterLimits(entity string, topic Topic) []error {
+	sqlFilters, correlationFilters := 0, 0
+	for _, subscription := range topic.Subscriptions {
+		for _, rule := range subscription.Rules {
+			switch rule.Properties.FilterType {
+			case FilterTypeSQL:
+				sqlFilters++
+			case FilterTypeCorrelation:
+				correlationFilters++
+			}
+		}
+	}
+
+	var errs []error
+	if sqlFilters > MaxSQLFiltersPerTopic {
+		errs = append(errs, fmt.Errorf("%s tem %d filtros SQL; o emulador aceita no máximo %d por tópico", entity, sqlFilters, MaxSQLFiltersPerTopic))
+	}
+	if correlationFilters > MaxCorrelationFilters {
+		errs = append(errs, fmt.Errorf("%s tem %d filtros de correlação; o emulador aceita no máximo %d por tópico", entity, correlationFilters, MaxCorrelationFilters))
+	}
+	return errs
+}
+
+// validateForward verifica se o destino do encaminhamento é uma fila ou tópico declarado
+func (c *Config) validateForward(entity, self, property, target string) []error {
+	if target == "" {
+		return nil
+	}
+	if target == self {
+		return []error{fmt.Errorf("%s: %s não pode apontar para a própria fila", entity, property)}
+	}
+	if !c.hasEntity(target) {
+		return []error{fmt.Errorf("%s: %s aponta para '%s', que não é uma fila nem tópico declarado", entity, property, target)}
+	}
+	return nil
+}
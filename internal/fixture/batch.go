@@ -0,0 +1,89 @@
+package fixture
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxNDJSONLineSize é o tamanho máximo de uma linha NDJSON
+const MaxNDJSONLineSize = 4 * 1024 * 1024
+
+// Source é uma fixture de um lote, ainda sem renderizar, com a sua origem (arquivo ou arquivo:linha)
+type Source struct {
+	Origin string
+	Data   []byte
+}
+
+// LoadBatch carrega as fixtures de um diretório de arquivos JSON ou de um arquivo NDJSON.
+// O conteúdo não é renderizado: cada envio deve renderizar a fixture de novo.
+func LoadBatch(path string) ([]Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return loadDirectory(path)
+	}
+	return loadNDJSON(path)
+}
+
+// loadDirectory carrega todos os arquivos .json de um diretório em ordem alfabética
+func loadDirectory(dir string) ([]Source, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler diretório: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var sources []Source
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %w", name, err)
+		}
+		sources = append(sources, Source{Origin: name, Data: data})
+	}
+
+	return sources, nil
+}
+
+// loadNDJSON carrega uma fixture por linha de um arquivo NDJSON, ignorando linhas vazias
+func loadNDJSON(path string) ([]Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), MaxNDJSONLineSize)
+
+	base := filepath.Base(path)
+	var sources []Source
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		sources = append(sources, Source{Origin: fmt.Sprintf("%s:%d", base, line), Data: []byte(text)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", base, err)
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,75 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+)
+
+// Set é um override de --set: o valor a gravar no caminho do body
+type Set struct {
+	Path  string
+	Value interface{}
+}
+
+// ParseSets interpreta overrides no formato caminho=valor, na ordem informada. O valor é lido como
+// JSON, mantendo a representação original dos números, ou como texto quando não é JSON válido.
+func ParseSets(items []string) ([]Set, error) {
+	sets := make([]Set, 0, len(items))
+	for _, item := range items {
+		path, text, found := strings.Cut(item, "=")
+		if !found || path == "" {
+			return nil, fmt.Errorf("override inválido '%s': use caminho=valor", item)
+		}
+
+		value, err := decodeJSON([]byte(text))
+		if err != nil {
+			value = text
+		}
+		sets = append(sets, Set{Path: path, Value: value})
+	}
+	return sets, nil
+}
+
+// ApplySets altera os caminhos do body na ordem dos overrides, então um override posterior prevalece
+// sobre o anterior (ex: --set data={"a":1} --set data.a=2). Em fixtures no formato envelope os
+// caminhos são relativos a "body". Números que não foram alterados mantêm a representação
+// original, mesmo inteiros maiores que 2^53.
+func ApplySets(data []byte, sets []Set) ([]byte, error) {
+	document, err := decodeJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("erro de sintaxe JSON: %w", err)
+	}
+
+	envelope := servicebus.IsEnvelope(data)
+	for _, set := range sets {
+		target := set.Path
+		if envelope {
+			target = "body." + strings.TrimPrefix(strings.TrimPrefix(set.Path, "$"), ".")
+		}
+		if document, err = utils.SetJSONPath(document, target, set.Value); err != nil {
+			return nil, fmt.Errorf("--set %s: %w", set.Path, err)
+		}
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// decodeJSON decodifica um único valor JSON com os números como json.Number
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("conteúdo após o valor JSON")
+	}
+	return value, nil
+}
@@ -0,0 +1,308 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"fin.orion.dev/internal/generator"
+	"fin.orion.dev/internal/utils"
+)
+
+// Renderer interpreta expressões de template nas fixtures da pasta messages.
+// Os contadores de seq são compartilhados entre todas as renderizações do mesmo Renderer.
+//
+// Exemplo:
+//
+//	{
+//	  "id": "{{uuid}}",
+//	  "createdAt": "{{now "-1h"}}",
+//	  "amount": {{amount 10 500}},
+//	  "order": {{seq "pedido"}},
+//	  "account": "{{.conta}}",
+//	  "tenant": "{{env "TENANT" "orion"}}"
+//	}
+type Renderer struct {
+	// Now fornece o horário usado por now, date e unix (time.Now se nil)
+	Now func() time.Time
+
+	mu        sync.Mutex
+	random    *rand.Rand
+	generator *generator.Generator
+	counters  map[string]int64
+}
+
+// NewRenderer cria um Renderer com gerador aleatório não determinístico
+func NewRenderer() *Renderer {
+	r := &Renderer{counters: make(map[string]int64)}
+	r.Seed(rand.Uint64())
+	return r
+}
+
+// Seed reinicia os geradores aleatórios (amount, randInt e os dados brasileiros) para que a mesma seed
+// produza os mesmos valores. uuid e now não são afetados.
+func (r *Renderer) Seed(seed uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.random = rand.New(rand.NewPCG(seed, seed))
+	r.generator = generator.New(seed)
+}
+
+// IsTemplate indica se o conteúdo possui expressões de template
+func IsTemplate(data []byte) bool {
+	return bytes.Contains(data, []byte("{{"))
+}
+
+// Render interpreta o conteúdo como template. Variáveis ficam acessíveis como {{.nome}} ou {{var "nome"}}.
+// Conteúdo sem expressões é retornado sem alterações.
+func (r *Renderer) Render(name string, data []byte, vars map[string]string) ([]byte, error) {
+	if !IsTemplate(data) {
+		return data, nil
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(r.Funcs(vars)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("template inválido: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return nil, fmt.Errorf("erro ao renderizar template: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// Funcs retorna as funções disponíveis nos templates
+func (r *Renderer) Funcs(vars map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"uuid":    utils.NewUUID,
+		"now":     r.now,
+		"date":    r.date,
+		"unix":    r.unix,
+		"amount":  r.amount,
+		"randInt": r.randInt,
+		"seq":     r.seq,
+		"env":     env,
+		"json":    toJSON,
+		"cpf":     r.cpf,
+		"cnpj":    r.cnpj,
+		"pixKey":  r.pixKey,
+		"branch":  r.gen().Branch,
+		"account": r.gen().AccountNumber,
+		"ispb":    r.gen().ISPB,
+		"brl":     r.brl,
+		"var": func(name string) (string, error) {
+			value, ok := vars[name]
+			if !ok {
+				return "", fmt.Errorf("variável '%s' não definida (use --var %s=valor)", name, name)
+			}
+			return value, nil
+		},
+	}
+}
+
+// now retorna o horário atual em RFC3339 (UTC), com deslocamento e layout opcionais: {{now "-1h"}}, {{now "2d" "02/01/2006"}}
+func (r *Renderer) now(args ...string) (string, error) {
+	if len(args) > 2 {
+		return "", fmt.Errorf("now aceita no máximo deslocamento e layout")
+	}
+	offset := ""
+	if len(args) > 0 {
+		offset = args[0]
+	}
+	t, err := r.at(offset)
+	if err != nil {
+		return "", err
+	}
+	layout := time.RFC3339
+	if len(args) == 2 {
+		layout = args[1]
+	}
+	return t.Format(layout), nil
+}
+
+// date retorna a data (AAAA-MM-DD) com deslocamento opcional: {{date "-1d"}}
+func (r *Renderer) date(args ...string) (string, error) {
+	if len(args) > 1 {
+		return "", fmt.Errorf("date aceita no máximo um deslocamento")
+	}
+	t, err := r.at(strings.Join(args, ""))
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.DateOnly), nil
+}
+
+// unix retorna o timestamp em segundos com deslocamento opcional: {{unix "+5m"}}
+func (r *Renderer) unix(args ...string) (int64, error) {
+	if len(args) > 1 {
+		return 0, fmt.Errorf("unix aceita no máximo um deslocamento")
+	}
+	t, err := r.at(strings.Join(args, ""))
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// at aplica o deslocamento ao horário atual
+func (r *Renderer) at(offset string) (time.Time, error) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	d, err := ParseOffset(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now().UTC().Add(d), nil
+}
+
+// amount retorna um valor aleatório entre min e max com duas casas decimais: {{amount 10 500}}
+func (r *Renderer) amount(min, max float64) (string, error) {
+	if min > max {
+		return "", fmt.Errorf("amount: mínimo %v maior que máximo %v", min, max)
+	}
+	r.mu.Lock()
+	value := min + r.random.Float64()*(max-min)
+	r.mu.Unlock()
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', 2, 64), nil
+}
+
+// randInt retorna um inteiro aleatório entre min e max, inclusive: {{randInt 1 9}}
+func (r *Renderer) randInt(min, max int64) (int64, error) {
+	if min > max {
+		return 0, fmt.Errorf("randInt: mínimo %d maior que máximo %d", min, max)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return min + r.random.Int64N(max-min+1), nil
+}
+
+// seq incrementa e retorna o contador com o nome informado, começando em 1: {{seq "pedido"}}
+func (r *Renderer) seq(name string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[name]++
+	return r.counters[name]
+}
+
+// gen retorna o gerador de dados brasileiros atual
+func (r *Renderer) gen() *generator.Generator {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.generator
+}
+
+// cpf gera um CPF válido; com "mask" aplica a máscara 000.000.000-00: {{cpf}}, {{cpf "mask"}}
+func (r *Renderer) cpf(options ...string) (string, error) {
+	masked, err := maskOption("cpf", options)
+	if err != nil || !masked {
+		return r.gen().CPF(), err
+	}
+	return generator.FormatCPF(r.gen().CPF()), nil
+}
+
+// cnpj gera um CNPJ válido; com "mask" aplica a máscara 00.000.000/0000-00: {{cnpj}}, {{cnpj "mask"}}
+func (r *Renderer) cnpj(options ...string) (string, error) {
+	masked, err := maskOption("cnpj", options)
+	if err != nil || !masked {
+		return r.gen().CNPJ(), err
+	}
+	return generator.FormatCNPJ(r.gen().CNPJ()), nil
+}
+
+// maskOption interpreta o argumento opcional de cpf e cnpj
+func maskOption(name string, options []string) (bool, error) {
+	switch {
+	case len(options) == 0:
+		return false, nil
+	case len(options) == 1 && options[0] == "mask":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s aceita apenas a opção \"mask\"", name)
+	}
+}
+
+// pixKey gera uma chave PIX do tipo cpf, cnpj, email, phone ou evp (aleatório se omitido): {{pixKey "evp"}}
+func (r *Renderer) pixKey(kind ...string) (string, error) {
+	if len(kind) > 1 {
+		return "", fmt.Errorf("pixKey aceita no máximo o tipo da chave")
+	}
+	return r.gen().PixKey(strings.Join(kind, ""))
+}
+
+// brl retorna um valor aleatório formatado em reais: {{brl 10 5000}} → R$ 1.234,56
+func (r *Renderer) brl(min, max float64) (string, error) {
+	value, err := r.gen().Amount(min, max)
+	if err != nil {
+		return "", fmt.Errorf("brl: %w", err)
+	}
+	return generator.FormatBRL(value), nil
+}
+
+// env retorna uma variável de ambiente; sem valor padrão, a variável deve existir: {{env "TENANT" "orion"}}
+func env(name string, fallback ...string) (string, error) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+	if len(fallback) > 0 {
+		return fallback[0], nil
+	}
+	return "", fmt.Errorf("variável de ambiente '%s' não definida", name)
+}
+
+// toJSON codifica o valor como JSON, útil para inserir textos com aspas: {{json (env "DESCRICAO")}}
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParseOffset interpreta deslocamentos como "-1h", "+30m" ou "2d12h" (d = dias). Vazio é zero.
+func ParseOffset(offset string) (time.Duration, error) {
+	text := strings.TrimSpace(offset)
+	if text == "" {
+		return 0, nil
+	}
+
+	sign := time.Duration(1)
+	switch text[0] {
+	case '-':
+		sign = -1
+		text = text[1:]
+	case '+':
+		text = text[1:]
+	}
+
+	var total time.Duration
+	if days, rest, found := strings.Cut(text, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("deslocamento inválido '%s'", offset)
+		}
+		total = time.Duration(n) * 24 * time.Hour
+		text = rest
+	}
+	if text != "" {
+		d, err := time.ParseDuration(text)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("deslocamento inválido '%s' (ex: -1h, +30m, 2d)", offset)
+		}
+		total += d
+	}
+	return sign * total, nil
+}
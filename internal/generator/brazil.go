@@ -0,0 +1,347 @@
+// Package generator gera dados de teste do sistema financeiro brasileiro com dígitos verificadores válidos:
+// CPF, CNPJ, chaves PIX, agência e conta, ISPB e valores em reais.
+package generator
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Tipos de chave PIX
+const (
+	PixKeyCPF   = "cpf"
+	PixKeyCNPJ  = "cnpj"
+	PixKeyEmail = "email"
+	PixKeyPhone = "phone"
+	PixKeyEVP   = "evp"
+)
+
+// PixKeyTypes são os tipos aceitos por PixKey
+var PixKeyTypes = []string{PixKeyCPF, PixKeyCNPJ, PixKeyEmail, PixKeyPhone, PixKeyEVP}
+
+// Bank é uma instituição participante do SPI
+type Bank struct {
+	ISPB string `json:"ispb"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Banks são as instituições usadas em ISPB e Account
+var Banks = []Bank{
+	{ISPB: "00000000", Code: "001", Name: "Banco do Brasil"},
+	{ISPB: "00360305", Code: "104", Name: "Caixa Econômica Federal"},
+	{ISPB: "60701190", Code: "341", Name: "Itaú Unibanco"},
+	{ISPB: "60746948", Code: "237", Name: "Bradesco"},
+	{ISPB: "90400888", Code: "033", Name: "Santander"},
+	{ISPB: "18236120", Code: "260", Name: "Nu Pagamentos"},
+	{ISPB: "00416968", Code: "077", Name: "Banco Inter"},
+	{ISPB: "31872495", Code: "336", Name: "C6 Bank"},
+}
+
+// Account é uma conta bancária gerada
+type Account struct {
+	Bank
+	Branch string `json:"branch"`
+	Number string `json:"account"`
+	Digit  string `json:"digit"`
+}
+
+// String formata a conta como "agência número-dígito"
+func (a Account) String() string {
+	return fmt.Sprintf("%s %s-%s", a.Branch, a.Number, a.Digit)
+}
+
+// Generator gera os valores a partir de uma fonte aleatória própria; é seguro para uso concorrente
+type Generator struct {
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// New cria um Generator determinístico: a mesma seed produz a mesma sequência de valores
+func New(seed uint64) *Generator {
+	return &Generator{random: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// NewRandom cria um Generator com seed aleatória
+func NewRandom() *Generator {
+	return New(rand.Uint64())
+}
+
+// digits retorna n dígitos aleatórios
+func (g *Generator) digits(n int) []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	result := make([]int, n)
+	for i := range result {
+		result[i] = g.random.IntN(10)
+	}
+	return result
+}
+
+// intN retorna um inteiro aleatório em [0, n)
+func (g *Generator) intN(n int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.random.IntN(n)
+}
+
+// CPF gera um CPF válido, apenas dígitos
+func (g *Generator) CPF() string {
+	base := g.digits(9)
+	for allEqual(base) {
+		base = g.digits(9)
+	}
+	return join(cpfDigits(base))
+}
+
+// CNPJ gera um CNPJ válido de matriz (0001), apenas dígitos
+func (g *Generator) CNPJ() string {
+	base := g.digits(8)
+	for allEqual(base) {
+		base = g.digits(8)
+	}
+	return join(cnpjDigits(append(base, 0, 0, 0, 1)))
+}
+
+// Email gera um e-mail fictício
+func (g *Generator) Email() string {
+	first := firstNames[g.intN(len(firstNames))]
+	last := lastNames[g.intN(len(lastNames))]
+	return fmt.Sprintf("%s.%s%d@%s", first, last, g.intN(1000), emailDomains[g.intN(len(emailDomains))])
+}
+
+// Phone gera um celular no formato da chave PIX: +55, DDD e nove dígitos começando em 9
+func (g *Generator) Phone() string {
+	return fmt.Sprintf("+55%d9%s", areaCodes[g.intN(len(areaCodes))], join(g.digits(8)))
+}
+
+// EVP gera uma chave aleatória (UUID v4)
+func (g *Generator) EVP() string {
+	g.mu.Lock()
+	high, low := g.random.Uint64(), g.random.Uint64()
+	g.mu.Unlock()
+	high = (high &^ 0xf000) | 0x4000
+	low = (low &^ (0x3 << 62)) | (0x2 << 62)
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", high>>32, (high>>16)&0xffff, high&0xffff, low>>48, low&0xffffffffffff)
+}
+
+// PixKey gera uma chave PIX do tipo informado; vazio escolhe um tipo aleatório
+func (g *Generator) PixKey(kind string) (string, error) {
+	if kind == "" {
+		kind = PixKeyTypes[g.intN(len(PixKeyTypes))]
+	}
+	switch kind {
+	case PixKeyCPF:
+		return g.CPF(), nil
+	case PixKeyCNPJ:
+		return g.CNPJ(), nil
+	case PixKeyEmail:
+		return g.Email(), nil
+	case PixKeyPhone:
+		return g.Phone(), nil
+	case PixKeyEVP:
+		return g.EVP(), nil
+	default:
+		return "", fmt.Errorf("tipo de chave PIX inválido '%s' (tipos: %s)", kind, strings.Join(PixKeyTypes, ", "))
+	}
+}
+
+// Branch gera uma agência de quatro dígitos, sem dígito verificador
+func (g *Generator) Branch() string {
+	return fmt.Sprintf("%04d", 1+g.intN(9999))
+}
+
+// AccountNumber gera uma conta de oito dígitos com dígito verificador (módulo 11): "12345678-9"
+func (g *Generator) AccountNumber() string {
+	number := join(g.digits(8))
+	return number + "-" + AccountDigit(number)
+}
+
+// ISPB retorna o ISPB de uma instituição aleatória
+func (g *Generator) ISPB() string {
+	return Banks[g.intN(len(Banks))].ISPB
+}
+
+// Account gera agência e conta em uma instituição aleatória
+func (g *Generator) Account() Account {
+	bank := Banks[g.intN(len(Banks))]
+	number := join(g.digits(8))
+	return Account{Bank: bank, Branch: g.Branch(), Number: number, Digit: AccountDigit(number)}
+}
+
+// Amount gera um valor entre min e max com duas casas decimais
+func (g *Generator) Amount(min, max float64) (float64, error) {
+	if min > max {
+		return 0, fmt.Errorf("mínimo %v maior que máximo %v", min, max)
+	}
+	g.mu.Lock()
+	value := min + g.random.Float64()*(max-min)
+	g.mu.Unlock()
+	return math.Round(value*100) / 100, nil
+}
+
+// FormatCPF aplica a máscara 000.000.000-00
+func FormatCPF(cpf string) string {
+	cpf = onlyDigits(cpf)
+	if len(cpf) != 11 {
+		return cpf
+	}
+	return cpf[0:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:]
+}
+
+// FormatCNPJ aplica a máscara 00.000.000/0000-00
+func FormatCNPJ(cnpj string) string {
+	cnpj = onlyDigits(cnpj)
+	if len(cnpj) != 14 {
+		return cnpj
+	}
+	return cnpj[0:2] + "." + cnpj[2:5] + "." + cnpj[5:8] + "/" + cnpj[8:12] + "-" + cnpj[12:]
+}
+
+// FormatBRL formata o valor em reais: R$ 1.234,56
+func FormatBRL(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	cents := int64(math.Round(value * 100))
+	integer := strconv.FormatInt(cents/100, 10)
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+// ValidCPF verifica os dígitos verificadores de um CPF, com ou sem máscara
+func ValidCPF(cpf string) bool {
+	values, ok := parseDigits(cpf, 11)
+	if !ok || allEqual(values) {
+		return false
+	}
+	return join(cpfDigits(values[:9])) == join(values)
+}
+
+// ValidCNPJ verifica os dígitos verificadores de um CNPJ, com ou sem máscara
+func ValidCNPJ(cnpj string) bool {
+	values, ok := parseDigits(cnpj, 14)
+	if !ok || allEqual(values) {
+		return false
+	}
+	return join(cnpjDigits(values[:12])) == join(values)
+}
+
+// AccountDigit calcula o dígito verificador módulo 11 de uma conta (pesos 2 a 9 da direita para a esquerda)
+func AccountDigit(number string) string {
+	sum, weight := 0, 2
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+		sum += int(number[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+	digit := 11 - sum%11
+	if digit >= 10 {
+		digit = 0
+	}
+	return strconv.Itoa(digit)
+}
+
+// cpfDigits acrescenta os dois dígitos verificadores aos nove dígitos base
+func cpfDigits(base []int) []int {
+	result := append([]int(nil), base...)
+	for len(result) < 11 {
+		sum := 0
+		for i, digit := range result {
+			sum += digit * (len(result) + 1 - i)
+		}
+		check := sum * 10 % 11
+		if check == 10 {
+			check = 0
+		}
+		result = append(result, check)
+	}
+	return result
+}
+
+// cnpjDigits acrescenta os dois dígitos verificadores aos doze dígitos base
+func cnpjDigits(base []int) []int {
+	result := append([]int(nil), base...)
+	for len(result) < 14 {
+		sum, weight := 0, 2
+		for i := len(result) - 1; i >= 0; i-- {
+			sum += result[i] * weight
+			if weight++; weight > 9 {
+				weight = 2
+			}
+		}
+		check := 0
+		if rest := sum % 11; rest >= 2 {
+			check = 11 - rest
+		}
+		result = append(result, check)
+	}
+	return result
+}
+
+// parseDigits extrai os dígitos ignorando a máscara; o total deve ser size
+func parseDigits(text string, size int) ([]int, bool) {
+	text = onlyDigits(text)
+	if len(text) != size {
+		return nil, false
+	}
+	values := make([]int, size)
+	for i := range text {
+		if text[i] < '0' || text[i] > '9' {
+			return nil, false
+		}
+		values[i] = int(text[i] - '0')
+	}
+	return values, true
+}
+
+// onlyDigits remove os caracteres de máscara (ponto, hífen, barra e espaço)
+func onlyDigits(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '/' || r == ' ' {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+func allEqual(values []int) bool {
+	for _, value := range values[1:] {
+		if value != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func join(values []int) string {
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteByte(byte('0' + value))
+	}
+	return b.String()
+}
+
+var (
+	firstNames   = []string{"ana", "bruno", "carla", "diego", "eduarda", "felipe", "gabriela", "henrique", "isabela", "joao", "larissa", "marcos"}
+	lastNames    = []string{"silva", "santos", "oliveira", "souza", "lima", "pereira", "costa", "rodrigues", "almeida", "nascimento"}
+	emailDomains = []string{"exemplo.com.br", "teste.com", "orion.dev"}
+	areaCodes    = []int{11, 21, 27, 31, 41, 47, 48, 51, 61, 62, 71, 81, 85, 91, 92}
+)
@@ -0,0 +1,402 @@
+// Package load envia mensagens a uma taxa controlada e mede a latência de envio e, opcionalmente,
+// a latência de ponta a ponta até a resposta correlacionada em outra entidade.
+package load
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fin.orion.dev/internal/scenario"
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/utils"
+)
+
+// Config descreve uma execução de carga
+type Config struct {
+	// Target é a fila ou tópico de destino
+	Target string
+	// Rate é a taxa de envio em mensagens por segundo
+	Rate float64
+	// Duration é o tempo de envio; o total de mensagens é Rate × Duration
+	Duration time.Duration
+	// Concurrency é o número de envios simultâneos
+	Concurrency int
+	// Reply é a entidade onde as respostas correlacionadas são publicadas (opcional)
+	Reply *servicebus.Entity
+	// ReplyTimeout é quanto esperar pelas respostas pendentes depois do último envio
+	ReplyTimeout time.Duration
+	// Build cria a mensagem de cada envio; MessageID e CorrelationID são sempre substituídos
+	Build func(index int) (*servicebus.Message, error)
+	// OnProgress é chamado a cada segundo durante a execução
+	OnProgress func(Progress)
+}
+
+// Progress é a situação parcial da execução
+type Progress struct {
+	Elapsed time.Duration
+	Sent    int
+	Failed  int
+	Replied int
+}
+
+// Sample é a medição de uma mensagem
+type Sample struct {
+	Index         int       `json:"index"`
+	MessageID     string    `json:"messageId"`
+	CorrelationID string    `json:"correlationId"`
+	SentAt        time.Time `json:"sentAt"`
+	SendMs        float64   `json:"sendMs"`
+	Replied       bool      `json:"replied"`
+	ReplyMs       float64   `json:"replyMs,omitempty"`
+	Error         string    `json:"error,omitempty"`
+
+	SendLatency  time.Duration `json:"-"`
+	ReplyLatency time.Duration `json:"-"`
+	sent         bool
+}
+
+// Result é o resultado da execução
+type Result struct {
+	Target       string         `json:"target"`
+	Reply        string         `json:"reply,omitempty"`
+	Rate         float64        `json:"rate"`
+	Concurrency  int            `json:"concurrency"`
+	StartedAt    time.Time      `json:"startedAt"`
+	ElapsedMs    int64          `json:"elapsedMs"`
+	Planned      int            `json:"planned"`
+	Sent         int            `json:"sent"`
+	Failed       int            `json:"failed"`
+	Replied      int            `json:"replied"`
+	Missing      int            `json:"missing"`
+	AchievedRate float64        `json:"achievedRate"`
+	Canceled     bool           `json:"canceled,omitempty"`
+	SendLatency  Stats          `json:"sendLatency"`
+	ReplyLatency *Stats         `json:"replyLatency,omitempty"`
+	Errors       map[string]int `json:"errors,omitempty"`
+	Samples      []Sample       `json:"samples"`
+
+	Elapsed time.Duration `json:"-"`
+}
+
+// ParseRate interpreta taxas como "200/s", "600/m", "10000/h" ou "50" (por segundo)
+func ParseRate(text string) (float64, error) {
+	value, unit, _ := strings.Cut(strings.TrimSpace(text), "/")
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 {
+		return 0, fmt.Errorf("taxa inválida '%s' (ex: 200/s, 600/m)", text)
+	}
+	switch unit {
+	case "", "s":
+		return rate, nil
+	case "m":
+		return rate / 60, nil
+	case "h":
+		return rate / 3600, nil
+	default:
+		return 0, fmt.Errorf("unidade da taxa inválida '%s': use s, m ou h", unit)
+	}
+}
+
+// run guarda o estado compartilhado entre o despachante, os workers e o observador de respostas
+type run struct {
+	config  Config
+	runID   string
+	mu      sync.Mutex
+	samples []Sample
+	pending map[string]int
+	errors  map[string]int
+
+	sent, failed, replied int
+	sendingDone           bool
+	complete              chan struct{}
+	completed             bool
+}
+
+// Run executa a carga usando o mesmo acesso ao Service Bus dos cenários (scenario.Bus).
+// O cancelamento de ctx interrompe os envios e retorna o resultado parcial.
+func Run(ctx context.Context, bus scenario.Bus, config Config) (*Result, error) {
+	if config.Rate <= 0 || config.Duration <= 0 || config.Concurrency <= 0 {
+		return nil, fmt.Errorf("taxa, duração e concorrência devem ser maiores que zero")
+	}
+	if config.Build == nil {
+		return nil, fmt.Errorf("nenhuma mensagem configurada")
+	}
+	total := int(config.Rate*config.Duration.Seconds() + 0.5)
+	if total < 1 {
+		return nil, fmt.Errorf("a taxa %.2f/s por %s não envia nenhuma mensagem", config.Rate, config.Duration)
+	}
+
+	r := &run{
+		config:   config,
+		runID:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		samples:  make([]Sample, total),
+		pending:  make(map[string]int),
+		errors:   make(map[string]int),
+		complete: make(chan struct{}),
+	}
+
+	// Observar a entidade de resposta a partir das mensagens que já existiam
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	watchDone := make(chan error, 1)
+	if config.Reply != nil {
+		last, err := bus.LastSequenceNumber(ctx, *config.Reply)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao consultar '%s': %w", config.Reply, err)
+		}
+		go func() {
+			watchDone <- bus.Watch(watchCtx, *config.Reply, last+1, r.onReply)
+		}()
+	}
+
+	started := time.Now()
+	progressDone := r.reportProgress(started)
+	dispatched := r.dispatch(ctx, bus, started, total)
+	elapsed := time.Since(started)
+
+	// Aguardar as respostas pendentes
+	var watchErr error
+	if config.Reply != nil {
+		r.finishSending()
+		timer := time.NewTimer(config.ReplyTimeout)
+		select {
+		case <-r.complete:
+		case <-timer.C:
+		case <-ctx.Done():
+		case watchErr = <-watchDone:
+		}
+		timer.Stop()
+		stopWatch()
+	}
+	close(progressDone)
+
+	result := r.result(started, elapsed, total, dispatched)
+	result.Canceled = ctx.Err() != nil
+	if watchErr != nil && ctx.Err() == nil {
+		return result, fmt.Errorf("erro ao observar '%s': %w", config.Reply, watchErr)
+	}
+	return result, nil
+}
+
+// dispatch libera os envios no ritmo da taxa para os workers e retorna quantas mensagens foram despachadas
+func (r *run) dispatch(ctx context.Context, bus scenario.Bus, started time.Time, total int) int {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < r.config.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				r.send(ctx, bus, index)
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for index := 0; index < total; index++ {
+		at := started.Add(time.Duration(float64(index) / r.config.Rate * float64(time.Second)))
+		if wait := time.Until(at); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				break dispatch
+			}
+		}
+		// Com todos os workers ocupados o envio atrasa e a taxa obtida fica abaixo da configurada
+		select {
+		case jobs <- index:
+			dispatched++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return dispatched
+}
+
+// send monta e envia a mensagem de índice index
+func (r *run) send(ctx context.Context, bus scenario.Bus, index int) {
+	sample := Sample{Index: index}
+
+	message, err := r.config.Build(index)
+	if err != nil {
+		r.fail(index, sample, fmt.Errorf("erro ao montar mensagem: %w", err))
+		return
+	}
+	message.MessageID = fmt.Sprintf("load-%s-%d", r.runID, index)
+	message.CorrelationID = utils.NewUUID()
+	if r.config.Reply != nil && message.ReplyTo == "" {
+		message.ReplyTo = r.config.Reply.SendTarget()
+	}
+	sample.MessageID = message.MessageID
+	sample.CorrelationID = message.CorrelationID
+
+	// Registrar antes do envio: a resposta pode chegar antes de Send retornar
+	sample.SentAt = time.Now()
+	r.mu.Lock()
+	r.samples[index] = sample
+	if r.config.Reply != nil {
+		r.pending[message.MessageID] = index
+		r.pending[message.CorrelationID] = index
+	}
+	r.mu.Unlock()
+
+	err = bus.Send(ctx, r.config.Target, message)
+	latency := time.Since(sample.SentAt)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[index].SendLatency = latency
+	if err != nil {
+		delete(r.pending, message.MessageID)
+		delete(r.pending, message.CorrelationID)
+		r.samples[index].Error = err.Error()
+		r.errors[err.Error()]++
+		r.failed++
+		return
+	}
+	r.samples[index].sent = true
+	r.sent++
+}
+
+// fail registra uma falha antes do envio
+func (r *run) fail(index int, sample Sample, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sample.SentAt = time.Now()
+	sample.Error = err.Error()
+	r.samples[index] = sample
+	r.errors[err.Error()]++
+	r.failed++
+}
+
+// onReply associa a resposta à mensagem enviada pelo CorrelationID (ou MessageID) e mede a latência.
+// A latência usa o horário de enfileiramento da resposta, sem o atraso do polling, quando disponível.
+func (r *run) onReply(message *servicebus.Message) bool {
+	observed := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, ok := r.pending[message.CorrelationID]
+	if !ok {
+		return r.completed
+	}
+	sample := &r.samples[index]
+	delete(r.pending, sample.MessageID)
+	delete(r.pending, sample.CorrelationID)
+
+	latency := observed.Sub(sample.SentAt)
+	if message.EnqueuedTimeUtc != nil {
+		if enqueued := message.EnqueuedTimeUtc.Sub(sample.SentAt); enqueued > 0 && enqueued < latency {
+			latency = enqueued
+		}
+	}
+	sample.Replied = true
+	sample.ReplyLatency = latency
+	r.replied++
+
+	r.checkComplete()
+	return r.completed
+}
+
+// finishSending indica que não haverá novos envios
+func (r *run) finishSending() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sendingDone = true
+	r.checkComplete()
+}
+
+// checkComplete sinaliza quando todos os envios terminaram e não há respostas pendentes; requer mu
+func (r *run) checkComplete() {
+	if r.sendingDone && len(r.pending) == 0 && !r.completed {
+		r.completed = true
+		close(r.complete)
+	}
+}
+
+// reportProgress chama OnProgress a cada segundo até o canal retornado ser fechado
+func (r *run) reportProgress(started time.Time) chan struct{} {
+	done := make(chan struct{})
+	if r.config.OnProgress == nil {
+		return done
+	}
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				r.mu.Lock()
+				progress := Progress{Elapsed: time.Since(started), Sent: r.sent, Failed: r.failed, Replied: r.replied}
+				r.mu.Unlock()
+				r.config.OnProgress(progress)
+			}
+		}
+	}()
+	return done
+}
+
+// result consolida as medições das mensagens despachadas
+func (r *run) result(started time.Time, elapsed time.Duration, total, dispatched int) *Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := &Result{
+		Target:      r.config.Target,
+		Rate:        r.config.Rate,
+		Concurrency: r.config.Concurrency,
+		StartedAt:   started,
+		Elapsed:     elapsed,
+		ElapsedMs:   elapsed.Milliseconds(),
+		Planned:     total,
+		Sent:        r.sent,
+		Failed:      r.failed,
+		Replied:     r.replied,
+		Samples:     r.samples[:dispatched],
+	}
+	if len(r.errors) > 0 {
+		result.Errors = r.errors
+	}
+	if elapsed > 0 {
+		result.AchievedRate = float64(r.sent) / elapsed.Seconds()
+	}
+
+	var sendLatencies, replyLatencies []time.Duration
+	for i := range result.Samples {
+		sample := &result.Samples[i]
+		sample.SendMs = milliseconds(sample.SendLatency)
+		if sample.sent {
+			sendLatencies = append(sendLatencies, sample.SendLatency)
+		}
+		if sample.Replied {
+			sample.ReplyMs = milliseconds(sample.ReplyLatency)
+			replyLatencies = append(replyLatencies, sample.ReplyLatency)
+		}
+	}
+	result.SendLatency = Summarize(sendLatencies)
+
+	if r.config.Reply != nil {
+		result.Reply = r.config.Reply.String()
+		result.Missing = r.sent - r.replied
+		stats := Summarize(replyLatencies)
+		result.ReplyLatency = &stats
+	}
+	return result
+}
+
+// milliseconds converte a duração em milissegundos com casas decimais
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
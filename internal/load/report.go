@@ -0,0 +1,106 @@
+package load
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Stats resume uma série de latências
+type Stats struct {
+	Count int
+	Min   time.Duration
+	Avg   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// Summarize calcula mínimo, média, máximo e os percentis 50, 95 e 99 (nearest-rank)
+func Summarize(values []time.Duration) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, value := range sorted {
+		total += value
+	}
+	return Stats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Avg:   total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P95:   percentile(sorted, 95),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile retorna o percentil p (0-100) de valores já ordenados pelo método nearest-rank
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// MarshalJSON grava as latências em milissegundos
+func (s Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Count int     `json:"count"`
+		MinMs float64 `json:"minMs"`
+		AvgMs float64 `json:"avgMs"`
+		P50Ms float64 `json:"p50Ms"`
+		P95Ms float64 `json:"p95Ms"`
+		P99Ms float64 `json:"p99Ms"`
+		MaxMs float64 `json:"maxMs"`
+	}{s.Count, milliseconds(s.Min), milliseconds(s.Avg), milliseconds(s.P50), milliseconds(s.P95), milliseconds(s.P99), milliseconds(s.Max)})
+}
+
+// WriteJSON grava o resultado completo, com o resumo e as medições de cada mensagem
+func WriteJSON(w io.Writer, result *Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// WriteCSV grava uma linha por mensagem, para análise em planilhas
+func WriteCSV(w io.Writer, result *Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"index", "message_id", "correlation_id", "sent_at", "send_ms", "replied", "reply_ms", "error"}); err != nil {
+		return err
+	}
+	for _, sample := range result.Samples {
+		replyMs := ""
+		if sample.Replied {
+			replyMs = formatMs(sample.ReplyMs)
+		}
+		record := []string{
+			strconv.Itoa(sample.Index),
+			sample.MessageID,
+			sample.CorrelationID,
+			sample.SentAt.UTC().Format(time.RFC3339Nano),
+			formatMs(sample.SendMs),
+			strconv.FormatBool(sample.Replied),
+			replyMs,
+			sample.Error,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatMs(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/servicebus"
+)
+
+// Registry expõe as filas, tópicos e subscriptions declarados na configuração do emulador
+type Registry struct {
+	config *emulator.Config
+}
+
+// Load lê a configuração do emulador e monta o registro de entidades
+func Load(path string) (*Registry, error) {
+	config, err := emulator.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(config), nil
+}
+
+// Parse interpreta o conteúdo da configuração do emulador e monta o registro de entidades
+func Parse(data []byte) (*Registry, error) {
+	config, err := emulator.ParseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(config), nil
+}
+
+// New monta o registro a partir de uma configuração já carregada
+func New(config *emulator.Config) *Registry {
+	return &Registry{config: config}
+}
+
+// Config retorna a configuração do emulador usada pelo registro
+func (r *Registry) Config() *emulator.Config {
+	return r.config
+}
+
+// Queues retorna as filas de todos os namespaces, na ordem em que foram declaradas
+func (r *Registry) Queues() []emulator.Queue {
+	var queues []emulator.Queue
+	for _, namespace := range r.config.UserConfig.Namespaces {
+		queues = append(queues, namespace.Queues...)
+	}
+	return queues
+}
+
+// Topics retorna os tópicos de todos os namespaces, na ordem em que foram declarados
+func (r *Registry) Topics() []emulator.Topic {
+	var topics []emulator.Topic
+	for _, namespace := range r.config.UserConfig.Namespaces {
+		topics = append(topics, namespace.Topics...)
+	}
+	return topics
+}
+
+// QueueNames retorna os nomes das filas na ordem em que foram declaradas
+func (r *Registry) QueueNames() []string {
+	var names []string
+	for _, queue := range r.Queues() {
+		names = append(names, queue.Name)
+	}
+	return names
+}
+
+// TopicNames retorna os nomes dos tópicos na ordem em que foram declarados
+func (r *Registry) TopicNames() []string {
+	var names []string
+	for _, topic := range r.Topics() {
+		names = append(names, topic.Name)
+	}
+	return names
+}
+
+// SubscriptionNames retorna as subscriptions no formato tópico/subscription
+func (r *Registry) SubscriptionNames() []string {
+	var names []string
+	for _, topic := range r.Topics() {
+		for _, subscription := range topic.Subscriptions {
+			names = append(names, topic.Name+"/"+subscription.Name)
+		}
+	}
+	return names
+}
+
+// EntityNames retorna filas e subscriptions (tópico/subscription) em ordem alfabética
+func (r *Registry) EntityNames() []string {
+	names := append(r.QueueNames(), r.SubscriptionNames()...)
+	sort.Strings(names)
+	return names
+}
+
+// Queue procura uma fila pelo nome
+func (r *Registry) Queue(name string) (*emulator.Queue, bool) {
+	queue := r.config.FindQueue(name)
+	return queue, queue != nil
+}
+
+// Topic procura um tópico pelo nome
+func (r *Registry) Topic(name string) (*emulator.Topic, bool) {
+	topic := r.config.FindTopic(name)
+	return topic, topic != nil
+}
+
+// Subscription procura uma subscription de um tópico
+func (r *Registry) Subscription(topicName, subscriptionName string) (*emulator.Subscription, bool) {
+	subscription := r.config.FindSubscription(topicName, subscriptionName)
+	return subscription, subscription != nil
+}
+
+// HasQueue indica se a fila está declarada
+func (r *Registry) HasQueue(name string) bool {
+	_, ok := r.Queue(name)
+	return ok
+}
+
+// HasTopic indica se o tópico está declarado
+func (r *Registry) HasTopic(name string) bool {
+	_, ok := r.Topic(name)
+	return ok
+}
+
+// ValidateTopic procura o tópico e, se ele não estiver declarado, retorna um erro com os tópicos válidos
+func (r *Registry) ValidateTopic(name string) (*emulator.Topic, error) {
+	if topic, ok := r.Topic(name); ok {
+		return topic, nil
+	}
+
+	names := r.TopicNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("tópico '%s' não está declarado na configuração do emulador (nenhum tópico declarado)", name)
+	}
+	return nil, fmt.Errorf("tópico '%s' não está declarado na configuração do emulador (tópicos válidos: %s)", name, strings.Join(names, ", "))
+}
+
+// ValidateEntity verifica se a fila ou subscription está declarada
+func (r *Registry) ValidateEntity(entity servicebus.Entity) error {
+	if !entity.IsSubscription() {
+		if !r.HasQueue(entity.Queue) {
+			return fmt.Errorf("fila '%s' não está declarada na configuração do emulador", entity.Queue)
+		}
+		return nil
+	}
+
+	if !r.HasTopic(entity.Topic) {
+		return fmt.Errorf("tópico '%s' não está declarado na configuração do emulador", entity.Topic)
+	}
+	if _, ok := r.Subscription(entity.Topic, entity.Subscription); !ok {
+		return fmt.Errorf("subscription '%s' não existe no tópico '%s'", entity.Subscription, entity.Topic)
+	}
+	return nil
+}
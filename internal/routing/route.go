@@ -0,0 +1,158 @@
+package routing
+
+import (
+	"fmt"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/sqlfilter"
+)
+
+// RuleMatch descreve uma regra que selecionou a mensagem e as propriedades depois da sua ação
+type RuleMatch struct {
+	Rule    string
+	Action  string
+	Message *sqlfilter.Message
+}
+
+// Result indica se uma subscription receberia a mensagem e por quais regras
+type Result struct {
+	Subscription string
+	Matches      []RuleMatch
+}
+
+// Matched indica se ao menos uma regra da subscription selecionou a mensagem
+func (r Result) Matched() bool {
+	return len(r.Matches) > 0
+}
+
+// Route avalia a mensagem contra as regras de todas as subscriptions do tópico, sem acessar o emulador
+func Route(topic *emulator.Topic, message *servicebus.Message) ([]Result, error) {
+	var results []Result
+	for _, subscription := range topic.Subscriptions {
+		result, err := RouteSubscription(subscription, message)
+		if err != nil {
+			return nil, fmt.Errorf("subscription '%s/%s': %w", topic.Name, subscription.Name, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// RouteSubscription avalia a mensagem contra as regras de uma subscription
+func RouteSubscription(subscription emulator.Subscription, message *servicebus.Message) (Result, error) {
+	result := Result{Subscription: subscription.Name}
+
+	if len(subscription.Rules) == 0 {
+		result.Matches = append(result.Matches, RuleMatch{Rule: emulator.DefaultRuleName, Message: FilterMessage(message)})
+		return result, nil
+	}
+
+	for _, rule := range subscription.Rules {
+		matched, err := matchRule(rule, message)
+		if err != nil {
+			return result, fmt.Errorf("regra '%s': %w", rule.Name, err)
+		}
+		if !matched {
+			continue
+		}
+
+		match := RuleMatch{Rule: rule.Name, Message: FilterMessage(message)}
+		if rule.Properties.Action != nil {
+			action, err := sqlfilter.ParseAction(rule.Properties.Action.SqlExpression)
+			if err != nil {
+				return result, fmt.Errorf("regra '%s': %w", rule.Name, err)
+			}
+			action.Apply(match.Message)
+			match.Action = action.String()
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// matchRule avalia o filtro SQL ou de correlação da regra
+func matchRule(rule emulator.Rule, message *servicebus.Message) (bool, error) {
+	properties := rule.Properties
+	switch properties.FilterType {
+	case emulator.FilterTypeSQL:
+		if properties.SqlFilter == nil {
+			return false, fmt.Errorf("FilterType Sql sem SqlFilter")
+		}
+		filter, err := sqlfilter.Parse(properties.SqlFilter.SqlExpression)
+		if err != nil {
+			return false, err
+		}
+		return filter.Match(FilterMessage(message)), nil
+
+	case emulator.FilterTypeCorrelation:
+		if properties.CorrelationFilter == nil {
+			return false, fmt.Errorf("FilterType Correlation sem CorrelationFilter")
+		}
+		return matchCorrelation(properties.CorrelationFilter, message), nil
+
+	default:
+		return false, fmt.Errorf("FilterType '%s' inválido", properties.FilterType)
+	}
+}
+
+// matchCorrelation compara cada condição informada no filtro por igualdade exata
+func matchCorrelation(filter *emulator.CorrelationFilter, message *servicebus.Message) bool {
+	conditions := []struct{ expected, actual string }{
+		{filter.ContentType, message.ContentType},
+		{filter.CorrelationId, message.CorrelationID},
+		{filter.Label, message.Subject},
+		{filter.MessageId, message.MessageID},
+		{filter.ReplyTo, message.ReplyTo},
+		{filter.ReplyToSessionId, message.ReplyToSessionID},
+		{filter.SessionId, message.SessionID},
+		{filter.To, message.To},
+	}
+	for _, condition := range conditions {
+		if condition.expected != "" && condition.expected != condition.actual {
+			return false
+		}
+	}
+
+	for name, expected := range filter.Properties {
+		actual, ok := message.Properties[name]
+		if !ok || !sqlfilter.Equal(actual, expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterMessage converte a mensagem nas propriedades avaliadas pelos filtros SQL
+func FilterMessage(message *servicebus.Message) *sqlfilter.Message {
+	system := map[string]interface{}{}
+	values := map[string]string{
+		"MessageId":        message.MessageID,
+		"CorrelationId":    message.CorrelationID,
+		"ContentType":      message.ContentType,
+		"Label":            message.Subject,
+		"To":               message.To,
+		"ReplyTo":          message.ReplyTo,
+		"ReplyToSessionId": message.ReplyToSessionID,
+		"SessionId":        message.SessionID,
+		"PartitionKey":     message.PartitionKey,
+	}
+	for name, value := range values {
+		if value != "" {
+			system[name] = value
+		}
+	}
+	if message.TimeToLive != nil {
+		system["TimeToLive"] = message.TimeToLive.String()
+	}
+	if message.ScheduledEnqueueTime != nil {
+		system["ScheduledEnqueueTimeUtc"] = message.ScheduledEnqueueTime.UTC()
+	}
+
+	properties := map[string]interface{}{}
+	for name, value := range message.Properties {
+		properties[name] = value
+	}
+
+	return &sqlfilter.Message{System: system, Properties: properties}
+}
@@ -0,0 +1,187 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"fin.orion.dev/internal/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Assertion verifica um valor de um documento JSON (mensagem ou resposta HTTP) por caminho.
+// Sem operador, verifica apenas se o caminho existe.
+//
+//   - {path: body.data.status, equals: created}
+//   - {path: properties.eventType, matches: "^transaction\\."}
+//   - {path: body.error, exists: false}
+type Assertion struct {
+	Path     string
+	Equals   interface{}
+	Exists   *bool
+	Matches  string
+	Contains string
+
+	// hasEquals diferencia "equals: null" de equals ausente
+	hasEquals bool
+}
+
+// assertionFields são as chaves aceitas em uma assertion
+var assertionFields = map[string]bool{"path": true, "equals": true, "exists": true, "matches": true, "contains": true}
+
+// UnmarshalYAML lê a assertion rejeitando chaves desconhecidas
+func (a *Assertion) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("linha %d: assertion deve ser um objeto com path e um operador", node.Line)
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if !assertionFields[key] {
+			return fmt.Errorf("linha %d: campo '%s' desconhecido na assertion (use path, equals, exists, matches ou contains)", node.Content[i].Line, key)
+		}
+		if key == "equals" {
+			a.hasEquals = true
+		}
+	}
+
+	var fields struct {
+		Path     string      `yaml:"path"`
+		Equals   interface{} `yaml:"equals"`
+		Exists   *bool       `yaml:"exists"`
+		Matches  string      `yaml:"matches"`
+		Contains string      `yaml:"contains"`
+	}
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	a.Path, a.Equals, a.Exists, a.Matches, a.Contains = fields.Path, fields.Equals, fields.Exists, fields.Matches, fields.Contains
+	return nil
+}
+
+// validate verifica se a assertion tem caminho e no máximo um operador
+func (a Assertion) validate() error {
+	if strings.TrimSpace(a.Path) == "" {
+		return fmt.Errorf("assertion sem 'path'")
+	}
+
+	operators := 0
+	for _, present := range []bool{a.hasEquals, a.Exists != nil, a.Matches != "", a.Contains != ""} {
+		if present {
+			operators++
+		}
+	}
+	if operators > 1 {
+		return fmt.Errorf("assertion '%s': use apenas um operador (equals, exists, matches ou contains)", a.Path)
+	}
+	if a.Matches != "" {
+		if _, err := regexp.Compile(a.Matches); err != nil {
+			return fmt.Errorf("assertion '%s': expressão regular inválida: %w", a.Path, err)
+		}
+	}
+	return nil
+}
+
+// Check verifica a assertion contra um documento JSON decodificado
+func (a Assertion) Check(document interface{}) error {
+	value, found := utils.LookupJSONPath(document, a.Path)
+
+	if a.Exists != nil {
+		switch {
+		case *a.Exists && !found:
+			return fmt.Errorf("%s: caminho não encontrado", a.Path)
+		case !*a.Exists && found:
+			return fmt.Errorf("%s: não deveria existir, obtido %s", a.Path, utils.JSONValueString(value))
+		}
+		return nil
+	}
+
+	if !found {
+		return fmt.Errorf("%s: caminho não encontrado", a.Path)
+	}
+
+	switch {
+	case a.hasEquals:
+		if !equalValues(value, a.Equals) {
+			return fmt.Errorf("%s: esperado %s, obtido %s", a.Path, describeValue(a.Equals), describeValue(value))
+		}
+	case a.Matches != "":
+		pattern, err := regexp.Compile(a.Matches)
+		if err != nil {
+			return fmt.Errorf("%s: expressão regular inválida: %w", a.Path, err)
+		}
+		if !pattern.MatchString(utils.JSONValueString(value)) {
+			return fmt.Errorf("%s: %s não corresponde a /%s/", a.Path, describeValue(value), a.Matches)
+		}
+	case a.Contains != "":
+		if !strings.Contains(utils.JSONValueString(value), a.Contains) {
+			return fmt.Errorf("%s: %s não contém %q", a.Path, describeValue(value), a.Contains)
+		}
+	}
+	return nil
+}
+
+// String descreve a assertion em uma linha
+func (a Assertion) String() string {
+	switch {
+	case a.Exists != nil && !*a.Exists:
+		return a.Path + " não existe"
+	case a.hasEquals:
+		return a.Path + " = " + describeValue(a.Equals)
+	case a.Matches != "":
+		return a.Path + " ~ /" + a.Matches + "/"
+	case a.Contains != "":
+		return fmt.Sprintf("%s contém %q", a.Path, a.Contains)
+	default:
+		return a.Path + " existe"
+	}
+}
+
+// equalValues compara um valor do documento com o esperado no YAML, normalizando ambos como JSON.
+// Um texto esperado também é comparado com a representação textual do valor (ex: equals: "150.5").
+func equalValues(actual, expected interface{}) bool {
+	normalized, err := toJSONValue(expected)
+	if err != nil {
+		return false
+	}
+	if reflect.DeepEqual(actual, normalized) {
+		return true
+	}
+	if text, ok := expected.(string); ok {
+		return utils.JSONValueString(actual) == text
+	}
+	return false
+}
+
+// toJSONValue converte um valor decodificado do YAML nos tipos produzidos por encoding/json
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// describeValue formata um valor para mensagens de falha
+func describeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Document converte uma mensagem, resposta ou qualquer valor serializável no documento JSON usado pelas assertions
+func Document(value interface{}) (interface{}, error) {
+	document, err := toJSONValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter resultado em JSON: %w", err)
+	}
+	return document, nil
+}
@@ -0,0 +1,116 @@
+package scenario
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitSuites é a raiz do relatório JUnit XML
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+// junitSuite representa um cenário
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	File      string      `xml:"file,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+// junitCase representa um passo
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+// junitFailure descreve a falha de um passo
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit grava os relatórios no formato JUnit XML, um testsuite por cenário e um testcase por passo
+func WriteJUnit(w io.Writer, reports []*Report) error {
+	root := junitSuites{}
+	var total float64
+
+	for _, report := range reports {
+		suite := junitSuite{
+			Name:      report.Scenario,
+			Time:      seconds(report.DurationMs),
+			Timestamp: report.StartedAt.UTC().Format("2006-01-02T15:04:05"),
+			File:      report.File,
+		}
+		for i, step := range report.Steps {
+			testCase := junitCase{
+				Name:      fmt.Sprintf("%02d %s", i+1, step.Name),
+				ClassName: report.Scenario,
+				Time:      seconds(step.DurationMs),
+			}
+			switch step.Status {
+			case StatusFailed:
+				details := step.Failures
+				if step.Error != "" {
+					details = append([]string{step.Error}, details...)
+				}
+				testCase.Failure = &junitFailure{Message: details[0], Type: step.Kind, Text: strings.Join(details, "\n")}
+				suite.Failures++
+			case StatusSkipped:
+				testCase.Skipped = &struct{}{}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Skipped += suite.Skipped
+		total += float64(report.DurationMs) / 1000
+		root.Suites = append(root.Suites, suite)
+	}
+	root.Time = fmt.Sprintf("%.3f", total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("erro ao gerar JUnit XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJSON grava os relatórios em JSON
+func WriteJSON(w io.Writer, reports []*Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reports); err != nil {
+		return fmt.Errorf("erro ao gerar relatório JSON: %w", err)
+	}
+	return nil
+}
+
+// seconds formata milissegundos como segundos, o formato dos atributos time do JUnit
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
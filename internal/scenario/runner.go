@@ -0,0 +1,405 @@
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+)
+
+// Bus é o acesso ao Service Bus usado pelo runner
+type Bus interface {
+	// Send envia a mensagem para uma fila ou tópico
+	Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error
+	// LastSequenceNumber retorna o maior sequence number presente na entidade (0 se vazia)
+	LastSequenceNumber(ctx context.Context, entity servicebus.Entity) (int64, error)
+	// Watch espia a entidade a partir de fromSequence, chamando handler para cada mensagem,
+	// até handler retornar true ou ctx terminar
+	Watch(ctx context.Context, entity servicebus.Entity, fromSequence int64, handler func(*servicebus.Message) bool) error
+}
+
+// Runner executa cenários
+type Runner struct {
+	Bus Bus
+	// LoadFixture carrega uma fixture da pasta messages
+	LoadFixture func(name string) (*servicebus.Message, error)
+	// HTTPClient faz as chamadas dos passos http (http.DefaultClient se nil)
+	HTTPClient *http.Client
+	// OnStep é chamado ao fim de cada passo, para mostrar o progresso
+	OnStep func(index int, step *Step, result StepResult)
+}
+
+// Status é o resultado de um passo
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Report é o resultado da execução de um cenário
+type Report struct {
+	Scenario   string       `json:"scenario"`
+	File       string       `json:"file,omitempty"`
+	StartedAt  time.Time    `json:"startedAt"`
+	DurationMs int64        `json:"durationMs"`
+	Passed     bool         `json:"passed"`
+	Steps      []StepResult `json:"steps"`
+
+	Duration time.Duration `json:"-"`
+}
+
+// StepResult é o resultado de um passo
+type StepResult struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`
+	Status     Status   `json:"status"`
+	DurationMs int64    `json:"durationMs"`
+	Error      string   `json:"error,omitempty"`
+	Failures   []string `json:"failures,omitempty"`
+
+	Duration time.Duration `json:"-"`
+	// Output é o documento produzido pelo passo (mensagem enviada ou recebida, resposta HTTP)
+	Output interface{} `json:"-"`
+}
+
+// httpResponse é o documento de um passo http verificado pelas assertions
+type httpResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    interface{}       `json:"body"`
+}
+
+// Run executa os passos em ordem. Depois de uma falha os passos seguintes são ignorados.
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) *Report {
+	report := &Report{Scenario: scenario.Name, File: scenario.Path, StartedAt: time.Now(), Passed: true}
+
+	// Posicionar as esperas depois das mensagens que já estavam nas entidades
+	cursors, err := r.cursors(ctx, scenario)
+
+	outputs := make(map[string]interface{})
+	var last interface{}
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		result := StepResult{Name: step.Title(), Kind: step.Kind(), Status: StatusSkipped}
+
+		if report.Passed {
+			start := time.Now()
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				input := last
+				if step.From != "" {
+					input = outputs[step.From]
+				}
+				r.runStep(ctx, scenario, step, cursors, input, &result)
+			}
+			result.Duration = time.Since(start)
+			result.DurationMs = result.Duration.Milliseconds()
+
+			if result.Error != "" || len(result.Failures) > 0 {
+				result.Status = StatusFailed
+				report.Passed = false
+			} else {
+				result.Status = StatusPassed
+			}
+			if result.Output != nil {
+				last = result.Output
+				if step.ID != "" {
+					outputs[step.ID] = result.Output
+				}
+			}
+		}
+
+		report.Steps = append(report.Steps, result)
+		if r.OnStep != nil {
+			r.OnStep(i, step, result)
+		}
+	}
+
+	report.Duration = time.Since(report.StartedAt)
+	report.DurationMs = report.Duration.Milliseconds()
+	return report
+}
+
+// cursors retorna, para cada entidade dos passos wait, o sequence number a partir do qual esperar
+func (r *Runner) cursors(ctx context.Context, scenario *Scenario) (map[string]int64, error) {
+	cursors := make(map[string]int64)
+	for _, step := range scenario.Steps {
+		if step.Wait == nil {
+			continue
+		}
+		if _, ok := cursors[step.Wait.Entity]; ok {
+			continue
+		}
+
+		entity, err := servicebus.ParseEntity(step.Wait.Entity)
+		if err != nil {
+			return nil, err
+		}
+		last, err := r.Bus.LastSequenceNumber(ctx, entity)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao posicionar a espera em '%s': %w", entity, err)
+		}
+		cursors[step.Wait.Entity] = last + 1
+	}
+	return cursors, nil
+}
+
+// runStep executa a ação do passo e as assertions sobre o seu resultado (ou sobre input)
+func (r *Runner) runStep(ctx context.Context, scenario *Scenario, step *Step, cursors map[string]int64, input interface{}, result *StepResult) {
+	var output interface{}
+	var err error
+
+	switch {
+	case step.Send != nil:
+		output, err = r.send(ctx, step.Send)
+	case step.Wait != nil:
+		output, err = r.wait(ctx, scenario, step.Wait, cursors)
+	case step.HTTP != nil:
+		output, err = r.call(ctx, scenario, step.HTTP)
+	case step.Sleep != 0:
+		err = sleep(ctx, step.Sleep)
+	default:
+		output = input
+		if output == nil {
+			err = fmt.Errorf("nenhum resultado anterior para verificar")
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	result.Output = output
+
+	for _, assertion := range step.Assert {
+		if err := assertion.Check(output); err != nil {
+			result.Failures = append(result.Failures, err.Error())
+		}
+	}
+}
+
+// send carrega a fixture (ou o body inline), aplica os campos do passo e envia
+func (r *Runner) send(ctx context.Context, send *SendStep) (interface{}, error) {
+	var message *servicebus.Message
+	if send.Fixture != "" {
+		if r.LoadFixture == nil {
+			return nil, fmt.Errorf("fixtures não estão disponíveis")
+		}
+		loaded, err := r.LoadFixture(send.Fixture)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar fixture '%s': %w", send.Fixture, err)
+		}
+		message = loaded
+	} else {
+		body, err := toJSONValue(send.Body)
+		if err != nil {
+			return nil, fmt.Errorf("body inválido: %w", err)
+		}
+		message = &servicebus.Message{Body: body, ContentType: "application/json"}
+	}
+
+	if send.MessageID != "" {
+		message.MessageID = send.MessageID
+	}
+	if send.CorrelationID != "" {
+		message.CorrelationID = send.CorrelationID
+	}
+	if send.Subject != "" {
+		message.Subject = send.Subject
+	}
+	if send.Session != "" {
+		message.SessionID = send.Session
+	}
+	if len(send.Properties) > 0 {
+		properties := make(map[string]interface{}, len(message.Properties)+len(send.Properties))
+		for key, value := range message.Properties {
+			properties[key] = value
+		}
+		for key, value := range send.Properties {
+			properties[key] = value
+		}
+		message.Properties = properties
+	}
+
+	if err := r.Bus.Send(ctx, send.To, message); err != nil {
+		return nil, fmt.Errorf("erro ao enviar para '%s': %w", send.To, err)
+	}
+	return Document(message)
+}
+
+// wait espia a entidade até encontrar uma mensagem que atenda a todas as condições de match.
+// Apenas mensagens que chegaram depois do início do cenário e depois da mensagem encontrada
+// pela espera anterior na mesma entidade são consideradas.
+func (r *Runner) wait(ctx context.Context, scenario *Scenario, wait *WaitStep, cursors map[string]int64) (interface{}, error) {
+	entity, err := servicebus.ParseEntity(wait.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := stepTimeout(wait.Timeout, scenario)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	seen := 0
+	var found interface{}
+	var matched int64
+	err = r.Bus.Watch(waitCtx, entity, cursors[wait.Entity], func(message *servicebus.Message) bool {
+		seen++
+		document, err := Document(message)
+		if err != nil {
+			return false
+		}
+		for _, assertion := range wait.Match {
+			if assertion.Check(document) != nil {
+				return false
+			}
+		}
+		found = document
+		matched = message.SequenceNumber
+		return true
+	})
+
+	if found != nil {
+		// A próxima espera na mesma entidade começa depois da mensagem encontrada, para que
+		// duas esperas iguais não aceitem a mesma mensagem
+		if matched >= cursors[wait.Entity] {
+			cursors[wait.Entity] = matched + 1
+		}
+		return found, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("nenhuma mensagem em '%s' atendeu às condições em %s (%d mensagem(ns) nova(s) verificada(s))", entity, timeout, seen)
+}
+
+// call executa a requisição HTTP; status fora do esperado é uma falha
+func (r *Runner) call(ctx context.Context, scenario *Scenario, call *HTTPStep) (interface{}, error) {
+	url := call.URL
+	if scenario.BaseURL != "" && strings.HasPrefix(url, "/") {
+		url = strings.TrimSuffix(scenario.BaseURL, "/") + url
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch value := call.Body.(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(value)
+		contentType = "text/plain"
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("body inválido: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, stepTimeout(call.Timeout, scenario))
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(callCtx, strings.ToUpper(call.method()), url, body)
+	if err != nil {
+		return nil, fmt.Errorf("requisição inválida: %w", err)
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	for key, value := range call.Headers {
+		request.Header.Set(key, value)
+	}
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("erro na chamada %s %s: %w", request.Method, url, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
+	}
+
+	result := httpResponse{Status: response.StatusCode, Headers: make(map[string]string)}
+	for key := range response.Header {
+		result.Headers[key] = response.Header.Get(key)
+	}
+	if err := json.Unmarshal(data, &result.Body); err != nil {
+		result.Body = string(data)
+	}
+
+	document, err := Document(result)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := call.Status
+	if (expected == 0 && (response.StatusCode < 200 || response.StatusCode > 299)) || (expected != 0 && response.StatusCode != expected) {
+		want := "2xx"
+		if expected != 0 {
+			want = fmt.Sprint(expected)
+		}
+		return document, fmt.Errorf("status %d, esperado %s: %s", response.StatusCode, want, truncate(string(data), 200))
+	}
+	return document, nil
+}
+
+// stepTimeout escolhe o timeout do passo, do cenário ou o padrão
+func stepTimeout(timeout time.Duration, scenario *Scenario) time.Duration {
+	switch {
+	case timeout > 0:
+		return timeout
+	case scenario.Timeout > 0:
+		return scenario.Timeout
+	default:
+		return DefaultTimeout
+	}
+}
+
+// sleep aguarda a duração informada ou o cancelamento do contexto
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// truncate limita o tamanho de textos exibidos em mensagens de erro
+func truncate(text string, size int) string {
+	text = strings.TrimSpace(text)
+	if len(text) <= size {
+		return text
+	}
+	return text[:size] + "..."
+}
+
+// Failed indica se algum cenário falhou
+func Failed(reports []*Report) bool {
+	for _, report := range reports {
+		if !report.Passed {
+			return true
+		}
+	}
+	return false
+}
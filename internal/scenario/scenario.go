@@ -0,0 +1,246 @@
+package scenario
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout é o tempo máximo de espera de passos wait e http sem timeout próprio
+const DefaultTimeout = 30 * time.Second
+
+// Scenario é um fluxo de ponta a ponta descrito em YAML.
+//
+// Exemplo:
+//
+//	name: Criação de transação
+//	baseUrl: http://localhost:3000
+//	steps:
+//	  - name: enviar transação
+//	    send: {to: sbq.pismo.transaction.creation, fixture: transacao.json}
+//	  - name: aguardar evento
+//	    wait:
+//	      entity: sbt.orion.core/subscription.orion.core
+//	      match: [{path: body.data.id, equals: "123"}]
+//	  - sleep: 2s
+//	  - name: consultar API
+//	    http: {url: /transactions/123, status: 200}
+//	    assert: [{path: body.status, equals: created}]
+type Scenario struct {
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description,omitempty"`
+	BaseURL     string        `yaml:"baseUrl,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	Steps       []Step        `yaml:"steps"`
+
+	// Path é o arquivo de onde o cenário foi carregado
+	Path string `yaml:"-"`
+}
+
+// Step é um passo do cenário. Cada passo tem no máximo uma ação (send, wait, http ou sleep);
+// um passo só com assert verifica o resultado do passo anterior ou do passo indicado em from.
+type Step struct {
+	Name   string        `yaml:"name,omitempty"`
+	ID     string        `yaml:"id,omitempty"`
+	Send   *SendStep     `yaml:"send,omitempty"`
+	Wait   *WaitStep     `yaml:"wait,omitempty"`
+	HTTP   *HTTPStep     `yaml:"http,omitempty"`
+	Sleep  time.Duration `yaml:"sleep,omitempty"`
+	From   string        `yaml:"from,omitempty"`
+	Assert []Assertion   `yaml:"assert,omitempty"`
+}
+
+// SendStep envia uma fixture da pasta messages (ou um body inline) para uma fila ou tópico
+type SendStep struct {
+	To            string                 `yaml:"to"`
+	Fixture       string                 `yaml:"fixture,omitempty"`
+	Body          interface{}            `yaml:"body,omitempty"`
+	MessageID     string                 `yaml:"messageId,omitempty"`
+	CorrelationID string                 `yaml:"correlationId,omitempty"`
+	Subject       string                 `yaml:"subject,omitempty"`
+	Session       string                 `yaml:"session,omitempty"`
+	Properties    map[string]interface{} `yaml:"properties,omitempty"`
+}
+
+// WaitStep aguarda, em uma fila ou subscription, uma mensagem nova que atenda a todas as condições de match
+type WaitStep struct {
+	Entity  string        `yaml:"entity"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	Match   []Assertion   `yaml:"match,omitempty"`
+}
+
+// HTTPStep chama um endpoint da Orion API ou das Functions
+type HTTPStep struct {
+	Method  string            `yaml:"method,omitempty"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    interface{}       `yaml:"body,omitempty"`
+	// Status é o status esperado; 0 aceita qualquer status 2xx
+	Status  int           `yaml:"status,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Load lê e valida um cenário YAML
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler cenário: %w", err)
+	}
+
+	scenario, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	scenario.Path = path
+	return scenario, nil
+}
+
+// Parse interpreta e valida um cenário YAML
+func Parse(data []byte) (*Scenario, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var scenario Scenario
+	if err := decoder.Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("cenário inválido: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+// Validate verifica se cada passo tem uma única ação válida e se as referências existem
+func (s *Scenario) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, fmt.Errorf("campo 'name' é obrigatório"))
+	}
+	if len(s.Steps) == 0 {
+		errs = append(errs, fmt.Errorf("o cenário não possui passos"))
+	}
+
+	ids := make(map[string]bool)
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		if err := step.validate(ids); err != nil {
+			errs = append(errs, fmt.Errorf("passo %d (%s): %w", i+1, step.Title(), err))
+		}
+		if step.ID != "" {
+			if ids[step.ID] {
+				errs = append(errs, fmt.Errorf("passo %d: id '%s' repetido", i+1, step.ID))
+			}
+			ids[step.ID] = true
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate verifica um passo; ids contém os ids dos passos anteriores
+func (s *Step) validate(ids map[string]bool) error {
+	actions := 0
+	for _, present := range []bool{s.Send != nil, s.Wait != nil, s.HTTP != nil, s.Sleep != 0} {
+		if present {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return fmt.Errorf("use apenas uma ação por passo (send, wait, http ou sleep)")
+	}
+	if actions == 0 && len(s.Assert) == 0 {
+		return fmt.Errorf("passo sem ação nem assert")
+	}
+	if s.From != "" && actions > 0 {
+		return fmt.Errorf("'from' só pode ser usado em passos apenas com assert")
+	}
+	if s.From != "" && !ids[s.From] {
+		return fmt.Errorf("'from' referencia o passo '%s', que não existe antes deste", s.From)
+	}
+	if s.Sleep < 0 {
+		return fmt.Errorf("sleep não pode ser negativo")
+	}
+
+	switch {
+	case s.Send != nil:
+		if s.Send.To == "" {
+			return fmt.Errorf("send: campo 'to' é obrigatório")
+		}
+		if (s.Send.Fixture == "") == (s.Send.Body == nil) {
+			return fmt.Errorf("send: informe 'fixture' ou 'body'")
+		}
+	case s.Wait != nil:
+		if s.Wait.Entity == "" {
+			return fmt.Errorf("wait: campo 'entity' é obrigatório")
+		}
+	case s.HTTP != nil:
+		if s.HTTP.URL == "" {
+			return fmt.Errorf("http: campo 'url' é obrigatório")
+		}
+	}
+
+	for _, assertions := range [][]Assertion{s.matchAssertions(), s.Assert} {
+		for _, assertion := range assertions {
+			if err := assertion.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Kind retorna o tipo da ação do passo
+func (s *Step) Kind() string {
+	switch {
+	case s.Send != nil:
+		return "send"
+	case s.Wait != nil:
+		return "wait"
+	case s.HTTP != nil:
+		return "http"
+	case s.Sleep != 0:
+		return "sleep"
+	default:
+		return "assert"
+	}
+}
+
+// Title retorna o nome do passo ou uma descrição gerada a partir da ação
+func (s *Step) Title() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	switch {
+	case s.Send != nil:
+		return "send " + s.Send.To
+	case s.Wait != nil:
+		return "wait " + s.Wait.Entity
+	case s.HTTP != nil:
+		return s.HTTP.method() + " " + s.HTTP.URL
+	case s.Sleep != 0:
+		return "sleep " + s.Sleep.String()
+	case s.From != "":
+		return "assert " + s.From
+	default:
+		return "assert"
+	}
+}
+
+// matchAssertions retorna as condições de seleção do passo wait
+func (s *Step) matchAssertions() []Assertion {
+	if s.Wait == nil {
+		return nil
+	}
+	return s.Wait.Match
+}
+
+// method retorna o método HTTP, GET por padrão
+func (h *HTTPStep) method() string {
+	if h.Method == "" {
+		return "GET"
+	}
+	return h.Method
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Locate mapeia o JSON pointer de cada valor do documento para a linha (a partir de 1) onde o valor começa.
+// Conteúdo inválido resulta em um mapa parcial.
+func Locate(data []byte) map[string]int {
+	lines := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	_ = locateValue(decoder, data, "", lines)
+	return lines
+}
+
+// locateValue registra a linha do próximo valor e percorre seus filhos
+func locateValue(decoder *json.Decoder, data []byte, pointer string, lines map[string]int) error {
+	lines[pointer] = lineAt(data, valueStart(data, int(decoder.InputOffset())))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return fmt.Errorf("chave inválida em %s", pointer)
+			}
+			if err := locateValue(decoder, data, pointer+"/"+escape(name), lines); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for index := 0; decoder.More(); index++ {
+			if err := locateValue(decoder, data, pointer+"/"+strconv.Itoa(index), lines); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consumir o delimitador de fechamento
+	_, err = decoder.Token()
+	return err
+}
+
+// valueStart avança sobre espaços e separadores até o início do próximo valor
+func valueStart(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineAt retorna a linha (a partir de 1) da posição no conteúdo
+func lineAt(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,357 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultDir é a pasta dos schemas por fila ou tópico: schemas/<entidade>.json
+const DefaultDir = "schemas"
+
+// Schema é um JSON Schema compilado. Suporta o subconjunto usado nas mensagens:
+// type, enum, const, properties, required, additionalProperties, items, minItems, maxItems,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, minLength, maxLength,
+// pattern, format (date-time, date, uuid, email), allOf, anyOf, oneOf, not e $ref locais
+// (#/definitions/... e #/$defs/...). Palavras-chave desconhecidas são ignoradas.
+type Schema struct {
+	// always é o resultado de schemas booleanos (true aceita tudo, false rejeita tudo)
+	always *bool
+
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	items                *Schema
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	minimum, maximum     *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	multipleOf           *float64
+	pattern              *regexp.Regexp
+	format               string
+	allOf, anyOf, oneOf  []*Schema
+	not                  *Schema
+	ref                  string
+
+	compiler *compiler
+}
+
+// compiler guarda o documento raiz para resolver $ref
+type compiler struct {
+	root interface{}
+	refs map[string]*Schema
+}
+
+// Load lê e compila um schema de um arquivo
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler schema: %w", err)
+	}
+	schema, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// ForEntity carrega o schema declarado para a fila ou tópico em dir. Retorna nil (sem erro) se não houver schema.
+func ForEntity(dir, entity string) (*Schema, string, error) {
+	path := filepath.Join(dir, entity+".json")
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	schema, err := Load(path)
+	return schema, path, err
+}
+
+// Parse compila um JSON Schema
+func Parse(data []byte) (*Schema, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("schema inválido: %w", err)
+	}
+
+	c := &compiler{root: root, refs: make(map[string]*Schema)}
+	schema, err := c.compile(root, "#")
+	if err != nil {
+		return nil, fmt.Errorf("schema inválido: %w", err)
+	}
+	c.refs["#"] = schema
+
+	// Resolver todas as referências agora, para que erros apareçam ao carregar o schema
+	visited := make(map[*Schema]bool)
+	if err := c.resolveAll(schema, visited); err != nil {
+		return nil, fmt.Errorf("schema inválido: %w", err)
+	}
+	// Ciclos de $ref que não descem no documento fariam a validação recursar para sempre
+	state := make(map[*Schema]int)
+	for node := range visited {
+		if err := c.checkCycle(node, state, nil); err != nil {
+			return nil, fmt.Errorf("schema inválido: %w", err)
+		}
+	}
+	return schema, nil
+}
+
+// compile converte um nó do documento em Schema
+func (c *compiler) compile(node interface{}, location string) (*Schema, error) {
+	schema := &Schema{compiler: c}
+
+	if value, ok := node.(bool); ok {
+		schema.always = &value
+		return schema, nil
+	}
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: o schema deve ser um objeto ou booleano", location)
+	}
+
+	var err error
+	if schema.types, err = stringList(object["type"], location+"/type"); err != nil {
+		return nil, err
+	}
+	for _, name := range schema.types {
+		if !validTypes[name] {
+			return nil, fmt.Errorf("%s/type: tipo desconhecido '%s'", location, name)
+		}
+	}
+
+	if value, ok := object["enum"]; ok {
+		if schema.enum, ok = value.([]interface{}); !ok {
+			return nil, fmt.Errorf("%s/enum: deve ser um array", location)
+		}
+	}
+	schema.constValue, schema.hasConst = object["const"]
+
+	if value, ok := object["properties"]; ok {
+		properties, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s/properties: deve ser um objeto", location)
+		}
+		schema.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			if schema.properties[name], err = c.compile(property, location+"/properties/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if schema.required, err = stringList(object["required"], location+"/required"); err != nil {
+		return nil, err
+	}
+
+	for key, target := range map[string]**Schema{"additionalProperties": &schema.additionalProperties, "items": &schema.items, "not": &schema.not} {
+		if value, ok := object[key]; ok {
+			if *target, err = c.compile(value, location+"/"+key); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for key, target := range map[string]*[]*Schema{"allOf": &schema.allOf, "anyOf": &schema.anyOf, "oneOf": &schema.oneOf} {
+		value, ok := object[key]
+		if !ok {
+			continue
+		}
+		items, ok := value.([]interface{})
+		if !ok || len(items) == 0 {
+			return nil, fmt.Errorf("%s/%s: deve ser um array não vazio", location, key)
+		}
+		for i, item := range items {
+			compiled, err := c.compile(item, fmt.Sprintf("%s/%s/%d", location, key, i))
+			if err != nil {
+				return nil, err
+			}
+			*target = append(*target, compiled)
+		}
+	}
+
+	for key, target := range map[string]**float64{
+		"minimum": &schema.minimum, "maximum": &schema.maximum,
+		"exclusiveMinimum": &schema.exclusiveMinimum, "exclusiveMaximum": &schema.exclusiveMaximum,
+		"multipleOf": &schema.multipleOf,
+	} {
+		if value, ok := object[key]; ok {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s/%s: deve ser um número", location, key)
+			}
+			*target = &number
+		}
+	}
+	if schema.multipleOf != nil && *schema.multipleOf <= 0 {
+		return nil, fmt.Errorf("%s/multipleOf: deve ser maior que zero", location)
+	}
+
+	for key, target := range map[string]**int{
+		"minLength": &schema.minLength, "maxLength": &schema.maxLength,
+		"minItems": &schema.minItems, "maxItems": &schema.maxItems,
+	} {
+		if value, ok := object[key]; ok {
+			number, ok := value.(float64)
+			if !ok || number < 0 || number != float64(int(number)) {
+				return nil, fmt.Errorf("%s/%s: deve ser um inteiro não negativo", location, key)
+			}
+			n := int(number)
+			*target = &n
+		}
+	}
+
+	if value, ok := object["pattern"]; ok {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s/pattern: deve ser um texto", location)
+		}
+		if schema.pattern, err = regexp.Compile(text); err != nil {
+			return nil, fmt.Errorf("%s/pattern: expressão regular inválida: %w", location, err)
+		}
+	}
+	if value, ok := object["format"]; ok {
+		schema.format, _ = value.(string)
+	}
+	if value, ok := object["$ref"]; ok {
+		if schema.ref, ok = value.(string); !ok || !strings.HasPrefix(schema.ref, "#") {
+			return nil, fmt.Errorf("%s/$ref: apenas referências locais (#/...) são suportadas", location)
+		}
+	}
+
+	return schema, nil
+}
+
+// resolve compila, uma única vez, o schema apontado por uma $ref local
+func (c *compiler) resolve(ref string) (*Schema, error) {
+	if schema, ok := c.refs[ref]; ok {
+		return schema, nil
+	}
+
+	node := c.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("$ref '%s' não encontrada", ref)
+		}
+		if node, ok = object[unescape(token)]; !ok {
+			return nil, fmt.Errorf("$ref '%s' não encontrada", ref)
+		}
+	}
+
+	schema, err := c.compile(node, ref)
+	if err != nil {
+		return nil, err
+	}
+	c.refs[ref] = schema
+	return schema, nil
+}
+
+// resolveAll percorre o schema resolvendo as referências
+func (c *compiler) resolveAll(schema *Schema, visited map[*Schema]bool) error {
+	if schema == nil || visited[schema] {
+		return nil
+	}
+	visited[schema] = true
+
+	if schema.ref != "" {
+		target, err := c.resolve(schema.ref)
+		if err != nil {
+			return err
+		}
+		if err := c.resolveAll(target, visited); err != nil {
+			return err
+		}
+	}
+
+	children := []*Schema{schema.additionalProperties, schema.items, schema.not}
+	for _, property := range schema.properties {
+		children = append(children, property)
+	}
+	children = append(children, schema.allOf...)
+	children = append(children, schema.anyOf...)
+	children = append(children, schema.oneOf...)
+	for _, child := range children {
+		if err := c.resolveAll(child, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCycle procura ciclos entre schemas aplicados ao mesmo valor ($ref, allOf, anyOf, oneOf e not).
+// state marca os schemas em análise (1) e já verificados (2); refs é o caminho de referências seguido.
+func (c *compiler) checkCycle(schema *Schema, state map[*Schema]int, refs []string) error {
+	switch state[schema] {
+	case 1:
+		return fmt.Errorf("ciclo de $ref: %s", strings.Join(refs, " -> "))
+	case 2:
+		return nil
+	}
+	state[schema] = 1
+
+	if schema.ref != "" {
+		if err := c.checkCycle(c.refs[schema.ref], state, append(refs, schema.ref)); err != nil {
+			return err
+		}
+	}
+	var children []*Schema
+	children = append(children, schema.allOf...)
+	children = append(children, schema.anyOf...)
+	children = append(children, schema.oneOf...)
+	if schema.not != nil {
+		children = append(children, schema.not)
+	}
+	for _, child := range children {
+		if err := c.checkCycle(child, state, refs); err != nil {
+			return err
+		}
+	}
+
+	state[schema] = 2
+	return nil
+}
+
+// validTypes são os tipos aceitos em "type"
+var validTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// stringList lê um texto ou array de textos
+func stringList(value interface{}, location string) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: deve conter apenas textos", location)
+			}
+			result = append(result, text)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s: deve ser um texto ou array de textos", location)
+	}
+}
+
+// escape codifica um token de JSON pointer (RFC 6901)
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescape decodifica um token de JSON pointer (RFC 6901)
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
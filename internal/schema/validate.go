@@ -0,0 +1,312 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation é uma violação do schema, localizada por JSON pointer e, quando disponível, pela linha
+type Violation struct {
+	Pointer string `json:"pointer"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// String descreve a violação em uma linha
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	if v.Line > 0 {
+		return fmt.Sprintf("%s (linha %d): %s", pointer, v.Line, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", pointer, v.Message)
+}
+
+// Validate valida um documento JSON decodificado
+func (s *Schema) Validate(document interface{}) []Violation {
+	var violations []Violation
+	s.validate(document, "", &violations)
+	return violations
+}
+
+// ValidateJSON valida o conteúdo JSON a partir do valor apontado por base (ex: "/body" em envelopes;
+// vazio valida o documento inteiro). As violações recebem a linha correspondente no conteúdo e seguem a ordem do arquivo.
+func (s *Schema) ValidateJSON(data []byte, base string) ([]Violation, error) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("json inválido: %w", err)
+	}
+
+	value, ok := lookupPointer(document, base)
+	if !ok {
+		return nil, fmt.Errorf("caminho %s não encontrado no documento", base)
+	}
+
+	var violations []Violation
+	s.validate(value, base, &violations)
+
+	lines := Locate(data)
+	for i := range violations {
+		violations[i].Line = lines[violations[i].Pointer]
+	}
+	// Apresentar na ordem do arquivo
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Line < violations[j].Line })
+	return violations, nil
+}
+
+// validate acumula as violações de value, localizado em pointer
+func (s *Schema) validate(value interface{}, pointer string, violations *[]Violation) {
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			add("nenhum valor é permitido")
+		}
+		return
+	}
+
+	if s.ref != "" {
+		if target, err := s.compiler.resolve(s.ref); err == nil {
+			target.validate(value, pointer, violations)
+		} else {
+			add("%v", err)
+		}
+	}
+
+	if len(s.types) > 0 && !matchesType(value, s.types) {
+		add("tipo inválido: esperado %s, obtido %s", strings.Join(s.types, " ou "), typeName(value))
+		return
+	}
+	if len(s.enum) > 0 && !containsValue(s.enum, value) {
+		add("valor %s não está entre os permitidos: %s", describe(value), describe(s.enum))
+	}
+	if s.hasConst && !reflect.DeepEqual(value, s.constValue) {
+		add("valor %s diferente do esperado %s", describe(value), describe(s.constValue))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(v, pointer, violations)
+	case []interface{}:
+		s.validateArray(v, pointer, violations)
+	case string:
+		s.validateString(v, add)
+	case float64:
+		s.validateNumber(v, add)
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(value, pointer, violations)
+	}
+	if len(s.anyOf) > 0 && countMatches(s.anyOf, value, pointer) == 0 {
+		add("não atende a nenhuma das alternativas de anyOf")
+	}
+	if len(s.oneOf) > 0 {
+		if matches := countMatches(s.oneOf, value, pointer); matches != 1 {
+			add("deve atender a exatamente uma alternativa de oneOf, atende a %d", matches)
+		}
+	}
+	if s.not != nil && len(s.not.Validate(value)) == 0 {
+		add("não deve atender ao schema de not")
+	}
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, pointer string, violations *[]Violation) {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			*violations = append(*violations, Violation{Pointer: pointer, Message: fmt.Sprintf("campo obrigatório '%s' ausente", name)})
+		}
+	}
+
+	// Ordenar as chaves para mensagens estáveis
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := pointer + "/" + escape(key)
+		if property, ok := s.properties[key]; ok {
+			property.validate(object[key], child, violations)
+			continue
+		}
+		if s.additionalProperties != nil {
+			if s.additionalProperties.always != nil && !*s.additionalProperties.always {
+				*violations = append(*violations, Violation{Pointer: child, Message: fmt.Sprintf("campo '%s' não permitido", key)})
+				continue
+			}
+			s.additionalProperties.validate(object[key], child, violations)
+		}
+	}
+}
+
+func (s *Schema) validateArray(items []interface{}, pointer string, violations *[]Violation) {
+	if s.minItems != nil && len(items) < *s.minItems {
+		*violations = append(*violations, Violation{Pointer: pointer, Message: fmt.Sprintf("array com %d item(ns), mínimo %d", len(items), *s.minItems)})
+	}
+	if s.maxItems != nil && len(items) > *s.maxItems {
+		*violations = append(*violations, Violation{Pointer: pointer, Message: fmt.Sprintf("array com %d item(ns), máximo %d", len(items), *s.maxItems)})
+	}
+	if s.items != nil {
+		for i, item := range items {
+			s.items.validate(item, fmt.Sprintf("%s/%d", pointer, i), violations)
+		}
+	}
+}
+
+func (s *Schema) validateString(text string, add func(string, ...interface{})) {
+	length := utf8.RuneCountInString(text)
+	if s.minLength != nil && length < *s.minLength {
+		add("texto com %d caractere(s), mínimo %d", length, *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		add("texto com %d caractere(s), máximo %d", length, *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(text) {
+		add("%q não corresponde ao padrão /%s/", text, s.pattern)
+	}
+	if check, ok := formats[s.format]; ok && !check(text) {
+		add("%q não está no formato %s", text, s.format)
+	}
+}
+
+func (s *Schema) validateNumber(number float64, add func(string, ...interface{})) {
+	if s.minimum != nil && number < *s.minimum {
+		add("%v menor que o mínimo %v", number, *s.minimum)
+	}
+	if s.maximum != nil && number > *s.maximum {
+		add("%v maior que o máximo %v", number, *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && number <= *s.exclusiveMinimum {
+		add("%v deve ser maior que %v", number, *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && number >= *s.exclusiveMaximum {
+		add("%v deve ser menor que %v", number, *s.exclusiveMaximum)
+	}
+	if s.multipleOf != nil {
+		quotient := number / *s.multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			add("%v não é múltiplo de %v", number, *s.multipleOf)
+		}
+	}
+}
+
+// countMatches conta quantas alternativas aceitam o valor
+func countMatches(alternatives []*Schema, value interface{}, pointer string) int {
+	matches := 0
+	for _, alternative := range alternatives {
+		var violations []Violation
+		alternative.validate(value, pointer, &violations)
+		if len(violations) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// formats são os formatos verificados em "format"; formatos desconhecidos são ignorados
+var formats = map[string]func(string) bool{
+	"date-time": func(text string) bool {
+		_, err := time.Parse(time.RFC3339, text)
+		return err == nil
+	},
+	"date": func(text string) bool {
+		_, err := time.Parse(time.DateOnly, text)
+		return err == nil
+	},
+	"uuid":  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"email": regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`).MatchString,
+}
+
+// matchesType verifica se o valor é de algum dos tipos
+func matchesType(value interface{}, types []string) bool {
+	actual := typeName(value)
+	for _, expected := range types {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName retorna o tipo JSON do valor; números sem parte fracionária são integer
+func typeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// containsValue verifica se o valor está na lista
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe formata um valor para mensagens
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// lookupPointer busca o valor apontado por um JSON pointer; vazio aponta para o documento
+func lookupPointer(document interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return document, true
+	}
+	current := document
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescape(token)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			var index int
+			if _, err := fmt.Sscanf(token, "%d", &index); err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,109 @@
+package servicebus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// cachedReceiver serializa o uso de um receiver compartilhado, já que o SDK
+// não aceita chamadas concorrentes de ReceiveMessages no mesmo receiver
+type cachedReceiver struct {
+	mu       sync.Mutex
+	receiver *azservicebus.Receiver
+}
+
+// sender retorna o sender da fila ou tópico, reaproveitando o sender em cache.
+// A função release deve ser chamada ao final do uso; ela só fecha o sender quando o cache está desligado.
+func (c *Client) sender(queueOrTopic string) (*azservicebus.Sender, func(), error) {
+	if !c.cacheEntities {
+		sender, err := c.client.NewSender(queueOrTopic, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao criar sender: %w", err)
+		}
+		return sender, func() { c.closeSender(sender) }, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sender, ok := c.senders[queueOrTopic]; ok {
+		return sender, func() {}, nil
+	}
+
+	sender, err := c.client.NewSender(queueOrTopic, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao criar sender: %w", err)
+	}
+	c.senders[queueOrTopic] = sender
+	c.logger.Printf("sender de '%s' criado e mantido em cache", queueOrTopic)
+
+	return sender, func() {}, nil
+}
+
+// withReceiver executa fn com o receiver da entidade, reaproveitando o receiver em cache.
+// Chamadas concorrentes para a mesma entidade e opções são executadas uma de cada vez.
+func (c *Client) withReceiver(entity Entity, options *azservicebus.ReceiverOptions, fn func(*azservicebus.Receiver) error) error {
+	if !c.cacheEntities {
+		receiver, err := c.newReceiver(entity, options)
+		if err != nil {
+			return err
+		}
+		defer c.closeReceiver(receiver)
+		return fn(receiver)
+	}
+
+	key := receiverKey(entity, options)
+
+	c.mu.Lock()
+	cached, ok := c.receivers[key]
+	if !ok {
+		receiver, err := c.newReceiver(entity, options)
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		cached = &cachedReceiver{receiver: receiver}
+		c.receivers[key] = cached
+		c.logger.Printf("receiver de '%s' criado e mantido em cache", key)
+	}
+	c.mu.Unlock()
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	return fn(cached.receiver)
+}
+
+// receiverKey identifica um receiver pela entidade, sub-fila e modo de recebimento
+func receiverKey(entity Entity, options *azservicebus.ReceiverOptions) string {
+	key := entity.String()
+	if options == nil {
+		return key
+	}
+	if options.SubQueue == azservicebus.SubQueueDeadLetter {
+		key += "/$DeadLetterQueue"
+	}
+	if options.ReceiveMode == azservicebus.ReceiveModeReceiveAndDelete {
+		key += "#receive-and-delete"
+	}
+	return key
+}
+
+// closeCachedEntities fecha todos os senders e receivers em cache
+func (c *Client) closeCachedEntities(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, sender := range c.senders {
+		_ = sender.Close(ctx)
+		delete(c.senders, name)
+	}
+	for key, cached := range c.receivers {
+		cached.mu.Lock()
+		_ = cached.receiver.Close(ctx)
+		cached.mu.Unlock()
+		delete(c.receivers, key)
+	}
+}
@@ -2,19 +2,29 @@ package servicebus
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
-	"github.com/joho/godotenv"
 )
 
-// Client representa o cliente do Service Bus
+// Client representa o cliente do Service Bus. Senders e receivers são mantidos em cache
+// por entidade durante a vida do cliente e os métodos de envio podem ser usados por
+// várias goroutines ao mesmo tempo.
 type Client struct {
-	client *azservicebus.Client
+	client        *azservicebus.Client
+	logger        Logger
+	timeouts      Timeouts
+	cacheEntities bool
+
+	mu        sync.Mutex
+	senders   map[string]*azservicebus.Sender
+	receivers map[string]*cachedReceiver
 }
 
 // Message representa uma mensagem do Service Bus
@@ -26,212 +36,438 @@ type Message struct {
 	Properties      map[string]interface{} `json:"properties,omitempty"`
 	EnqueuedTimeUtc *time.Time             `json:"enqueuedTimeUtc,omitempty"`
 	DeliveryCount   int32                  `json:"deliveryCount,omitempty"`
+	SequenceNumber  int64                  `json:"sequenceNumber,omitempty"`
+	SessionID       string                 `json:"sessionId,omitempty"`
+
+	Subject              string         `json:"subject,omitempty"`
+	To                   string         `json:"to,omitempty"`
+	ReplyTo              string         `json:"replyTo,omitempty"`
+	ReplyToSessionID     string         `json:"replyToSessionId,omitempty"`
+	PartitionKey         string         `json:"partitionKey,omitempty"`
+	TimeToLive           *time.Duration `json:"timeToLive,omitempty"`
+	ScheduledEnqueueTime *time.Time     `json:"scheduledEnqueueTime,omitempty"`
+
+	DeadLetterReason           string `json:"deadLetterReason,omitempty"`
+	DeadLetterErrorDescription string `json:"deadLetterErrorDescription,omitempty"`
+	DeadLetterSource           string `json:"deadLetterSource,omitempty"`
+
+	// RawBody são os bytes do body recebido ou importado. Quando presente é enviado no lugar de
+	// Body, para que bodies de texto e binários sejam reenviados sem alterações.
+	RawBody []byte `json:"-"`
 }
 
-// NewClient cria um novo cliente do Service Bus
-func NewClient() (*Client, error) {
-	// Carregar variáveis de ambiente
-	if err := godotenv.Load(); err != nil {
-		fmt.Printf("⚠️  Aviso: erro ao carregar .env: %v\n", err)
+// MarshalJSON serializa a mensagem com as tags de Message, gravando TimeToLive como duração Go
+// (ex: 1h0m0s), o mesmo formato dos envelopes e das linhas do export
+func (m Message) MarshalJSON() ([]byte, error) {
+	output := struct {
+		*messageFields
+		TimeToLive string `json:"timeToLive,omitempty"`
+	}{messageFields: (*messageFields)(&m)}
+	if m.TimeToLive != nil {
+		output.TimeToLive = m.TimeToLive.String()
 	}
+	return json.Marshal(output)
+}
+
+// SettleMode define o que acontece com as mensagens depois de lidas
+type SettleMode string
+
+const (
+	// SettlePeek apenas espia as mensagens, sem alterar a entidade
+	SettlePeek SettleMode = "peek"
+	// SettleComplete remove as mensagens da entidade
+	SettleComplete SettleMode = "complete"
+	// SettleAbandon devolve as mensagens para a entidade, incrementando o delivery count
+	SettleAbandon SettleMode = "abandon"
+	// SettleDeadLetter move as mensagens para a dead-letter queue
+	SettleDeadLetter SettleMode = "dead-letter"
+)
 
-	connectionString := os.Getenv("SB_CNT_STR")
-	if connectionString == "" {
-		// Fallback para connection string padrão do emulador
-		connectionString = "Endpoint=sb://localhost;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=FAKE-SAS-KEY-VALUE"
+// ParseSettleMode escolhe o modo de liquidação a partir das flags de leitura (--peek, --complete,
+// --abandon e --dead-letter). Sem flags o modo é peek; --peek=false sem outro modo é recusado,
+// já que não existe leitura que não espie nem liquide as mensagens.
+func ParseSettleMode(peek, complete, abandon, deadLetter bool) (SettleMode, error) {
+	var modes []SettleMode
+	if complete {
+		modes = append(modes, SettleComplete)
+	}
+	if abandon {
+		modes = append(modes, SettleAbandon)
+	}
+	if deadLetter {
+		modes = append(modes, SettleDeadLetter)
 	}
 
-	fmt.Printf("🔍 Conectando ao Service Bus...\n")
+	switch {
+	case len(modes) > 1:
+		return "", fmt.Errorf("use apenas um modo de liquidação (--complete, --abandon ou --dead-letter)")
+	case len(modes) == 1:
+		return modes[0], nil
+	case !peek:
+		return "", fmt.Errorf("--peek=false exige --complete, --abandon ou --dead-letter")
+	}
+	return SettlePeek, nil
+}
+
+// CheckPaging valida a paginação de uma leitura: --from-sequence só existe no modo peek
+func CheckPaging(mode SettleMode, maxMessages int, fromSequence int64) error {
+	if maxMessages <= 0 {
+		return fmt.Errorf("--max deve ser maior que zero")
+	}
+	if fromSequence < 0 {
+		return fmt.Errorf("--from-sequence inválido: %d", fromSequence)
+	}
+	if fromSequence > 0 && mode != SettlePeek {
+		return fmt.Errorf("--from-sequence só pode ser usado no modo --peek")
+	}
+	return nil
+}
+
+// NextPageSequence retorna o sequence number inicial da próxima página de peek, quando a página
+// atual veio cheia (pode haver mais mensagens depois dela)
+func NextPageSequence(messages []*Message, maxMessages int) (int64, bool) {
+	if len(messages) == 0 || len(messages) < maxMessages {
+		return 0, false
+	}
+	return messages[len(messages)-1].SequenceNumber + 1, true
+}
+
+// messageReceiver abstrai os receivers comuns e de sessão do Azure Service Bus
+type messageReceiver interface {
+	ReceiveMessages(ctx context.Context, maxMessages int, options *azservicebus.ReceiveMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+	PeekMessages(ctx context.Context, maxMessageCount int, options *azservicebus.PeekMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+	CompleteMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.CompleteMessageOptions) error
+	AbandonMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.AbandonMessageOptions) error
+	DeadLetterMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.DeadLetterOptions) error
+	Close(ctx context.Context) error
+}
+
+// Peeker espia mensagens de uma entidade sem bloqueá-las. *azservicebus.Receiver implementa Peeker.
+type Peeker interface {
+	PeekMessages(ctx context.Context, maxMessageCount int, options *azservicebus.PeekMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+}
+
+// messageSettler liquida mensagens recebidas
+type messageSettler interface {
+	CompleteMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.CompleteMessageOptions) error
+	AbandonMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.AbandonMessageOptions) error
+	DeadLetterMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.DeadLetterOptions) error
+}
+
+// peekPageSize é o número máximo de mensagens solicitadas por chamada de peek
+const peekPageSize = 100
+
+// NewClient cria um novo cliente do Service Bus. Sem opções, conecta ao emulador local
+// com a connection string padrão.
+func NewClient(opts ...Option) (*Client, error) {
+	options := defaultClientOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.connectionString == "" {
+		options.connectionString = DefaultConnectionString
+	}
+	if options.logger == nil {
+		options.logger = nopLogger{}
+	}
 
-	// Configurar opções do cliente para usar AMQP sem TLS
 	clientOptions := &azservicebus.ClientOptions{
-		TLSConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
-		RetryOptions: azservicebus.RetryOptions{
-			MaxRetries: 3,
-		},
+		TLSConfig:    options.tlsConfig,
+		RetryOptions: options.retryOptions,
 	}
 
-	client, err := azservicebus.NewClientFromConnectionString(connectionString, clientOptions)
+	client, err := azservicebus.NewClientFromConnectionString(options.connectionString, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente: %w", err)
 	}
 
-	fmt.Printf("✅ Cliente criado com sucesso\n")
-	return &Client{client: client}, nil
+	options.logger.Printf("cliente do Service Bus criado")
+	return &Client{
+		client:        client,
+		logger:        options.logger,
+		timeouts:      options.timeouts,
+		cacheEntities: options.cacheEntities,
+		senders:       make(map[string]*azservicebus.Sender),
+		receivers:     make(map[string]*cachedReceiver),
+	}, nil
 }
 
-// Close fecha a conexão do cliente
-func (c *Client) Close() error {
+// Close fecha os senders e receivers em cache e a conexão do cliente
+func (c *Client) Close(ctx context.Context) error {
 	if c.client != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, c.timeouts.Close)
 		defer cancel()
+		c.closeCachedEntities(ctx)
 		return c.client.Close(ctx)
 	}
 	return nil
 }
 
-// TestConnection testa a conectividade básica com o Service Bus
-func (c *Client) TestConnection() error {
-	// Tentar criar um sender temporário para testar a conexão
-	sender, err := c.client.NewSender("test-queue", nil)
+// TestConnection testa a conectividade básica com o Service Bus criando um sender para a fila informada
+func (c *Client) TestConnection(ctx context.Context, queueName string) error {
+	sender, release, err := c.sender(queueName)
 	if err != nil {
 		return fmt.Errorf("erro ao testar conexão: %w", err)
 	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		_ = sender.Close(ctx)
-	}()
+	defer release()
+
+	// Criar um lote obriga o SDK a abrir o link AMQP com a entidade
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+	defer cancel()
+	if _, err := sender.NewMessageBatch(ctx, nil); err != nil {
+		return fmt.Errorf("erro ao testar conexão: %w", err)
+	}
 
-	fmt.Printf("✅ Conexão com Service Bus estabelecida com sucesso\n")
+	c.logger.Printf("conexão com '%s' estabelecida", queueName)
 	return nil
 }
 
 // SendMessageToQueue envia uma mensagem para uma fila
-func (c *Client) SendMessageToQueue(queueName string, message *Message) error {
-	fmt.Printf("🔧 Criando sender para fila: %s\n", queueName)
+func (c *Client) SendMessageToQueue(ctx context.Context, queueName string, message *Message) error {
+	return c.SendMessage(ctx, queueName, message)
+}
 
-	// Converter mensagem para o formato do Azure Service Bus
-	body, err := json.Marshal(message.Body)
+// SendMessage envia uma mensagem para uma fila ou tópico usando o sender em cache da entidade.
+// Pode ser chamado por várias goroutines ao mesmo tempo.
+func (c *Client) SendMessage(ctx context.Context, queueOrTopic string, message *Message) error {
+	sbMessage, err := toServiceBusMessage(message)
 	if err != nil {
-		return fmt.Errorf("erro ao serializar mensagem: %w", err)
+		return err
 	}
-	fmt.Printf("✅ Mensagem serializada (%d bytes)\n", len(body))
+	c.logger.Printf("mensagem %s serializada (%d bytes)", message.MessageID, len(sbMessage.Body))
 
-	sender, err := c.client.NewSender(queueName, nil)
+	sender, release, err := c.sender(queueOrTopic)
 	if err != nil {
-		return fmt.Errorf("erro ao criar sender: %w", err)
+		return err
 	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		_ = sender.Close(ctx)
-	}()
-	fmt.Printf("✅ Sender criado com sucesso\n")
+	defer release()
 
-	sbMessage := &azservicebus.Message{
-		Body: body,
+	c.logger.Printf("enviando mensagem %s para '%s'", message.MessageID, queueOrTopic)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
+	defer cancel()
+	if err := sender.SendMessage(ctx, sbMessage, nil); err != nil {
+		return fmt.Errorf("erro ao enviar mensagem: %w", err)
 	}
 
-	// Adicionar MessageID se não estiver vazio
-	if message.MessageID != "" {
-		sbMessage.MessageID = &message.MessageID
-		fmt.Printf("📝 MessageID definido: %s\n", message.MessageID)
+	return nil
+}
+
+// ReceiveMessagesFromQueue recebe mensagens de uma fila aplicando o modo de liquidação informado
+func (c *Client) ReceiveMessagesFromQueue(ctx context.Context, queueName string, maxMessages int, mode SettleMode) ([]*Message, error) {
+	if mode == SettlePeek {
+		return c.PeekMessagesFromQueue(ctx, queueName, maxMessages, 0)
 	}
 
-	// Adicionar CorrelationID se não estiver vazio
-	if message.CorrelationID != "" {
-		sbMessage.CorrelationID = &message.CorrelationID
-		fmt.Printf("🔗 CorrelationID definido: %s\n", message.CorrelationID)
+	var messages []*Message
+	err := c.withReceiver(Entity{Queue: queueName}, nil, func(receiver *azservicebus.Receiver) error {
+		var err error
+		messages, err = c.receiveMessages(ctx, receiver, maxMessages, mode)
+		return err
+	})
+	return messages, err
+}
+
+// PeekMessagesFromQueue lê mensagens de uma fila sem bloqueá-las ou removê-las.
+// Se fromSequence for maior que zero, a leitura começa nesse sequence number.
+func (c *Client) PeekMessagesFromQueue(ctx context.Context, queueName string, maxMessages int, fromSequence int64) ([]*Message, error) {
+	var messages []*Message
+	err := c.withReceiver(Entity{Queue: queueName}, nil, func(receiver *azservicebus.Receiver) error {
+		var err error
+		messages, err = c.peekMessages(ctx, receiver, maxMessages, fromSequence)
+		return err
+	})
+	return messages, err
+}
+
+// SendMessageToTopic envia uma mensagem para um tópico
+func (c *Client) SendMessageToTopic(ctx context.Context, topicName string, message *Message) error {
+	return c.SendMessage(ctx, topicName, message)
+}
+
+// ParseScheduleTime interpreta as opções --at (horário RFC3339) e --in (duração a partir de now)
+// e indica se a mensagem deve ser agendada. Um horário no passado é aceito: o Service Bus
+// enfileira a mensagem imediatamente.
+func ParseScheduleTime(at string, in time.Duration, now time.Time) (time.Time, bool, error) {
+	switch {
+	case at != "" && in != 0:
+		return time.Time{}, false, fmt.Errorf("use apenas uma das opções --at e --in")
+	case at != "":
+		enqueueTime, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("--at inválido (use RFC3339, ex: 2025-01-31T10:00:00-03:00): %w", err)
+		}
+		return enqueueTime, true, nil
+	case in > 0:
+		return now.Add(in), true, nil
+	case in < 0:
+		return time.Time{}, false, fmt.Errorf("--in deve ser uma duração positiva")
 	}
 
-	// Adicionar ContentType se não estiver vazio
-	if message.ContentType != "" {
-		sbMessage.ContentType = &message.ContentType
-		fmt.Printf("📋 ContentType definido: %s\n", message.ContentType)
+	return time.Time{}, false, nil
+}
+
+// ParseSequenceNumbers converte os sequence numbers informados como argumentos
+func ParseSequenceNumbers(args []string) ([]int64, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("informe ao menos um sequence number")
 	}
 
-	// Adicionar propriedades se existirem
-	if message.Properties != nil {
-		sbMessage.ApplicationProperties = message.Properties
-		fmt.Printf("🏷️  Propriedades adicionadas: %d\n", len(message.Properties))
+	sequenceNumbers := make([]int64, 0, len(args))
+	for _, arg := range args {
+		seq, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || seq < 0 {
+			return nil, fmt.Errorf("sequence number inválido: %s", arg)
+		}
+		sequenceNumbers = append(sequenceNumbers, seq)
 	}
+	return sequenceNumbers, nil
+}
 
-	fmt.Printf("📤 Enviando mensagem para Service Bus...\n")
+// ScheduleMessage agenda uma mensagem em uma fila ou tópico para ser enfileirada em enqueueTime
+// e retorna o sequence number necessário para cancelar o agendamento
+func (c *Client) ScheduleMessage(ctx context.Context, queueOrTopic string, message *Message, enqueueTime time.Time) (int64, error) {
+	sender, release, err := c.sender(queueOrTopic)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	err = sender.SendMessage(ctx, sbMessage, nil)
-	cancel()
+	sbMessage, err := toServiceBusMessage(message)
+	if err != nil {
+		return 0, err
+	}
 
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
+	defer cancel()
+	sequenceNumbers, err := sender.ScheduleMessages(ctx, []*azservicebus.Message{sbMessage}, enqueueTime, nil)
 	if err != nil {
-		return fmt.Errorf("erro ao enviar mensagem: %w", err)
+		return 0, fmt.Errorf("erro ao agendar mensagem: %w", err)
+	}
+	if len(sequenceNumbers) == 0 {
+		return 0, fmt.Errorf("service bus não retornou o sequence number do agendamento")
 	}
 
-	return nil
+	return sequenceNumbers[0], nil
 }
 
-// ReceiveMessagesFromQueue recebe mensagens de uma fila
-func (c *Client) ReceiveMessagesFromQueue(queueName string, maxMessages int) ([]*Message, error) {
-	receiver, err := c.client.NewReceiverForQueue(queueName, nil)
+// CancelScheduledMessages cancela mensagens agendadas em uma fila ou tópico
+func (c *Client) CancelScheduledMessages(ctx context.Context, queueOrTopic string, sequenceNumbers ...int64) error {
+	sender, release, err := c.sender(queueOrTopic)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao criar receiver: %w", err)
+		return err
 	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		_ = receiver.Close(ctx)
-	}()
+	defer release()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
 	defer cancel()
-	messages, err := receiver.ReceiveMessages(ctx, maxMessages, nil)
+	if err := sender.CancelScheduledMessages(ctx, sequenceNumbers, nil); err != nil {
+		return fmt.Errorf("erro ao cancelar mensagens agendadas: %w", err)
+	}
+
+	return nil
+}
+
+// BatchResult indica o resultado do envio de uma mensagem em lote
+type BatchResult struct {
+	Index     int
+	MessageID string
+	Batch     int
+	Err       error
+}
+
+// SendMessageBatch envia mensagens para uma fila ou tópico usando lotes do Azure Service Bus.
+// Os lotes são divididos automaticamente quando o limite de tamanho é atingido e o resultado
+// de cada mensagem reflete o envio do lote em que ela foi incluída.
+func (c *Client) SendMessageBatch(ctx context.Context, queueOrTopic string, messages []*Message) ([]BatchResult, error) {
+	sender, release, err := c.sender(queueOrTopic)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao receber mensagens: %w", err)
+		return nil, err
 	}
+	defer release()
 
-	var result []*Message
-	for _, msg := range messages {
-		message := &Message{
-			MessageID:       msg.MessageID,
-			EnqueuedTimeUtc: msg.EnqueuedTime,
-			DeliveryCount:   int32(msg.DeliveryCount),
+	results := make([]BatchResult, len(messages))
+	for i, message := range messages {
+		results[i] = BatchResult{Index: i, MessageID: message.MessageID}
+	}
+
+	batchNumber := 0
+	var pending []int
+
+	newBatch := func() (*azservicebus.MessageBatch, error) {
+		ctx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+		defer cancel()
+		batch, err := sender.NewMessageBatch(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar lote: %w", err)
 		}
+		batchNumber++
+		return batch, nil
+	}
 
-		// Adicionar CorrelationID se não for nil
-		if msg.CorrelationID != nil {
-			message.CorrelationID = *msg.CorrelationID
+	flush := func(batch *azservicebus.MessageBatch) {
+		if batch.NumMessages() == 0 {
+			return
 		}
 
-		// Adicionar ContentType se não for nil
-		if msg.ContentType != nil {
-			message.ContentType = *msg.ContentType
+		ctx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
+		err := sender.SendMessageBatch(ctx, batch, nil)
+		cancel()
+		if err != nil {
+			err = fmt.Errorf("erro ao enviar lote %d: %w", batchNumber, err)
+		} else {
+			c.logger.Printf("lote %d enviado para '%s' (%d mensagens)", batchNumber, queueOrTopic, batch.NumMessages())
 		}
 
-		// Deserializar body
-		if err := json.Unmarshal(msg.Body, &message.Body); err != nil {
-			message.Body = string(msg.Body)
+		for _, index := range pending {
+			results[index].Batch = batchNumber
+			results[index].Err = err
 		}
+		pending = nil
+	}
 
-		// Adicionar propriedades
-		if msg.ApplicationProperties != nil {
-			message.Properties = make(map[string]interface{})
-			for k, v := range msg.ApplicationProperties {
-				message.Properties[k] = v
-			}
+	batch, err := newBatch()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, message := range messages {
+		sbMessage, err := toServiceBusMessage(message)
+		if err != nil {
+			results[i].Err = err
+			continue
 		}
 
-		result = append(result, message)
+		err = batch.AddMessage(sbMessage, nil)
+		if errors.Is(err, azservicebus.ErrMessageTooLarge) && batch.NumMessages() > 0 {
+			// Lote cheio: enviar o atual e tentar novamente em um lote novo
+			flush(batch)
+			if batch, err = newBatch(); err != nil {
+				return results, err
+			}
+			err = batch.AddMessage(sbMessage, nil)
+		}
 
-		// Complete a mensagem
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		if err := receiver.CompleteMessage(ctx, msg, nil); err != nil {
-			cancel()
-			return nil, fmt.Errorf("erro ao completar mensagem: %w", err)
+		if errors.Is(err, azservicebus.ErrMessageTooLarge) {
+			results[i].Err = fmt.Errorf("mensagem excede o tamanho máximo de um lote")
+			continue
 		}
-		cancel()
+		if err != nil {
+			results[i].Err = fmt.Errorf("erro ao adicionar mensagem ao lote: %w", err)
+			continue
+		}
+
+		pending = append(pending, i)
 	}
 
-	return result, nil
+	flush(batch)
+	return results, nil
 }
 
-// SendMessageToTopic envia uma mensagem para um tópico
-func (c *Client) SendMessageToTopic(topicName string, message *Message) error {
-	sender, err := c.client.NewSender(topicName, nil)
+// toServiceBusMessage converte uma mensagem para o formato do Azure Service Bus
+func toServiceBusMessage(message *Message) (*azservicebus.Message, error) {
+	body, err := encodeBody(message)
 	if err != nil {
-		return fmt.Errorf("erro ao criar sender: %w", err)
-	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		_ = sender.Close(ctx)
-	}()
-
-	// Converter mensagem para o formato do Azure Service Bus
-	body, err := json.Marshal(message.Body)
-	if err != nil {
-		return fmt.Errorf("erro ao serializar mensagem: %w", err)
+		return nil, err
 	}
 
 	sbMessage := &azservicebus.Message{
@@ -253,76 +489,305 @@ func (c *Client) SendMessageToTopic(topicName string, message *Message) error {
 		sbMessage.ContentType = &message.ContentType
 	}
 
+	// Adicionar SessionID se não estiver vazio
+	if message.SessionID != "" {
+		sbMessage.SessionID = &message.SessionID
+	}
+
+	// Adicionar propriedades do envelope se não estiverem vazias
+	if message.Subject != "" {
+		sbMessage.Subject = &message.Subject
+	}
+	if message.To != "" {
+		sbMessage.To = &message.To
+	}
+	if message.ReplyTo != "" {
+		sbMessage.ReplyTo = &message.ReplyTo
+	}
+	if message.ReplyToSessionID != "" {
+		sbMessage.ReplyToSessionID = &message.ReplyToSessionID
+	}
+	if message.PartitionKey != "" {
+		sbMessage.PartitionKey = &message.PartitionKey
+	}
+	sbMessage.TimeToLive = message.TimeToLive
+	sbMessage.ScheduledEnqueueTime = message.ScheduledEnqueueTime
+
 	// Adicionar propriedades se existirem
 	if message.Properties != nil {
 		sbMessage.ApplicationProperties = message.Properties
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	return sender.SendMessage(ctx, sbMessage, nil)
+	return sbMessage, nil
 }
 
-// ReceiveMessagesFromTopic recebe mensagens de uma subscription de tópico
-func (c *Client) ReceiveMessagesFromTopic(topicName, subscriptionName string, maxMessages int) ([]*Message, error) {
-	receiver, err := c.client.NewReceiverForSubscription(topicName, subscriptionName, nil)
+// encodeBody retorna os bytes do body: RawBody, se presente, ou Body serializado como JSON.
+// Bodies de texto com ContentType que não é JSON (ex: text/plain) são enviados como estão.
+func encodeBody(message *Message) ([]byte, error) {
+	if message.RawBody != nil {
+		return message.RawBody, nil
+	}
+	if text, ok := message.Body.(string); ok && message.ContentType != "" && !strings.Contains(strings.ToLower(message.ContentType), "json") {
+		return []byte(text), nil
+	}
+
+	body, err := json.Marshal(message.Body)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao criar receiver: %w", err)
+		return nil, fmt.Errorf("erro ao serializar mensagem: %w", err)
 	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		_ = receiver.Close(ctx)
-	}()
+	return body, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// closeSender fecha um sender ignorando erros
+func (c *Client) closeSender(sender *azservicebus.Sender) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeouts.Close)
 	defer cancel()
-	messages, err := receiver.ReceiveMessages(ctx, maxMessages, nil)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao receber mensagens: %w", err)
+	_ = sender.Close(ctx)
+}
+
+// ReceiveMessagesFromTopic recebe mensagens de uma subscription de tópico aplicando o modo de liquidação informado
+func (c *Client) ReceiveMessagesFromTopic(ctx context.Context, topicName, subscriptionName string, maxMessages int, mode SettleMode) ([]*Message, error) {
+	if mode == SettlePeek {
+		return c.PeekMessagesFromTopic(ctx, topicName, subscriptionName, maxMessages, 0)
 	}
 
+	var messages []*Message
+	err := c.withReceiver(Entity{Topic: topicName, Subscription: subscriptionName}, nil, func(receiver *azservicebus.Receiver) error {
+		var err error
+		messages, err = c.receiveMessages(ctx, receiver, maxMessages, mode)
+		return err
+	})
+	return messages, err
+}
+
+// PeekMessagesFromTopic lê mensagens de uma subscription sem bloqueá-las ou removê-las.
+// Se fromSequence for maior que zero, a leitura começa nesse sequence number.
+func (c *Client) PeekMessagesFromTopic(ctx context.Context, topicName, subscriptionName string, maxMessages int, fromSequence int64) ([]*Message, error) {
+	var messages []*Message
+	err := c.withReceiver(Entity{Topic: topicName, Subscription: subscriptionName}, nil, func(receiver *azservicebus.Receiver) error {
+		var err error
+		messages, err = c.peekMessages(ctx, receiver, maxMessages, fromSequence)
+		return err
+	})
+	return messages, err
+}
+
+// peekMessages espia mensagens em páginas até atingir maxMessages ou esgotar a entidade
+func (c *Client) peekMessages(ctx context.Context, receiver messageReceiver, maxMessages int, fromSequence int64) ([]*Message, error) {
 	var result []*Message
-	for _, msg := range messages {
-		message := &Message{
-			MessageID:       msg.MessageID,
-			EnqueuedTimeUtc: msg.EnqueuedTime,
-			DeliveryCount:   int32(msg.DeliveryCount),
+	_, err := c.scanMessages(ctx, receiver, maxMessages, fromSequence, func(msg *azservicebus.ReceivedMessage) error {
+		result = append(result, convertReceivedMessage(msg))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// errStopScan é retornado por visit para encerrar scanMessages antes de esgotar a entidade
+var errStopScan = errors.New("leitura interrompida")
+
+// scanMessages espia até maxMessages mensagens em páginas, chamando visit para cada uma.
+// Retorna true quando a entidade tinha mais mensagens que o limite (ou visit retornou errStopScan).
+func (c *Client) scanMessages(ctx context.Context, receiver Peeker, maxMessages int, fromSequence int64, visit func(*azservicebus.ReceivedMessage) error) (bool, error) {
+	// O cursor é controlado aqui, e não pelo receiver, para que receivers em cache
+	// sempre comecem do sequence number pedido
+	nextSequence := fromSequence
+	if nextSequence <= 0 {
+		nextSequence = 1
+	}
+
+	for visited := 0; visited < maxMessages; {
+		pageSize := maxMessages - visited
+		if pageSize > peekPageSize {
+			pageSize = peekPageSize
+		}
+
+		peekCtx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+		options := &azservicebus.PeekMessagesOptions{FromSequenceNumber: &nextSequence}
+		messages, err := receiver.PeekMessages(peekCtx, pageSize, options)
+		cancel()
+		if err != nil {
+			return false, fmt.Errorf("erro ao espiar mensagens: %w", err)
+		}
+
+		if len(messages) == 0 {
+			return false, nil
 		}
 
-		// Adicionar CorrelationID se não for nil
-		if msg.CorrelationID != nil {
-			message.CorrelationID = *msg.CorrelationID
+		for _, msg := range messages {
+			if err := visit(msg); err != nil {
+				if errors.Is(err, errStopScan) {
+					return true, nil
+				}
+				return false, err
+			}
+		}
+		visited += len(messages)
+
+		// A próxima página continua depois do último sequence number lido
+		last := messages[len(messages)-1]
+		if last.SequenceNumber == nil {
+			return false, nil
 		}
+		nextSequence = *last.SequenceNumber + 1
+	}
+
+	// O limite foi atingido: só há mais mensagens se um novo peek retornar alguma
+	peekCtx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+	defer cancel()
+	messages, err := receiver.PeekMessages(peekCtx, 1, &azservicebus.PeekMessagesOptions{FromSequenceNumber: &nextSequence})
+	if err != nil {
+		return false, fmt.Errorf("erro ao espiar mensagens: %w", err)
+	}
+	return len(messages) > 0, nil
+}
 
-		// Adicionar ContentType se não for nil
-		if msg.ContentType != nil {
-			message.ContentType = *msg.ContentType
+// receiveMessages recebe até maxMessages mensagens e as liquida conforme o modo informado
+func (c *Client) receiveMessages(ctx context.Context, receiver messageReceiver, maxMessages int, mode SettleMode) ([]*Message, error) {
+	var result []*Message
+
+	timeout := c.timeouts.Receive
+	for len(result) < maxMessages {
+		receiveCtx, cancel := context.WithTimeout(ctx, timeout)
+		messages, err := receiver.ReceiveMessages(receiveCtx, maxMessages-len(result), nil)
+		cancel()
+		if err != nil {
+			// Timeout sem mensagens significa que a entidade está vazia
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return nil, fmt.Errorf("erro ao receber mensagens: %w", err)
 		}
 
-		// Deserializar body
-		if err := json.Unmarshal(msg.Body, &message.Body); err != nil {
-			message.Body = string(msg.Body)
+		if len(messages) == 0 {
+			break
 		}
 
-		// Adicionar propriedades
-		if msg.ApplicationProperties != nil {
-			message.Properties = make(map[string]interface{})
-			for k, v := range msg.ApplicationProperties {
-				message.Properties[k] = v
+		for _, msg := range messages {
+			if err := c.settleMessage(ctx, receiver, msg, mode); err != nil {
+				return nil, err
 			}
+			result = append(result, convertReceivedMessage(msg))
 		}
 
-		result = append(result, message)
+		// Depois do primeiro lote, apenas drenar o que já está disponível
+		timeout = c.timeouts.Drain
+	}
+
+	return result, nil
+}
+
+// settleMessage aplica o modo de liquidação a uma mensagem recebida
+func (c *Client) settleMessage(ctx context.Context, receiver messageSettler, msg *azservicebus.ReceivedMessage, mode SettleMode) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+	defer cancel()
 
-		// Complete a mensagem
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	switch mode {
+	case SettleComplete:
 		if err := receiver.CompleteMessage(ctx, msg, nil); err != nil {
-			cancel()
-			return nil, fmt.Errorf("erro ao completar mensagem: %w", err)
+			return fmt.Errorf("erro ao completar mensagem: %w", err)
 		}
-		cancel()
+	case SettleAbandon:
+		if err := receiver.AbandonMessage(ctx, msg, nil); err != nil {
+			return fmt.Errorf("erro ao abandonar mensagem: %w", err)
+		}
+	case SettleDeadLetter:
+		reason := "orion-dev"
+		description := "Mensagem movida para a dead-letter queue pelo orion-dev"
+		options := &azservicebus.DeadLetterOptions{
+			Reason:           &reason,
+			ErrorDescription: &description,
+		}
+		if err := receiver.DeadLetterMessage(ctx, msg, options); err != nil {
+			return fmt.Errorf("erro ao mover mensagem para dead-letter: %w", err)
+		}
+	default:
+		return fmt.Errorf("modo de liquidação inválido: %s", mode)
 	}
 
-	return result, nil
+	return nil
+}
+
+// convertReceivedMessage converte uma mensagem recebida do Azure Service Bus
+func convertReceivedMessage(msg *azservicebus.ReceivedMessage) *Message {
+	message := &Message{
+		MessageID:       msg.MessageID,
+		EnqueuedTimeUtc: msg.EnqueuedTime,
+		DeliveryCount:   int32(msg.DeliveryCount),
+	}
+
+	// Adicionar SequenceNumber se não for nil
+	if msg.SequenceNumber != nil {
+		message.SequenceNumber = *msg.SequenceNumber
+	}
+
+	// Adicionar CorrelationID se não for nil
+	if msg.CorrelationID != nil {
+		message.CorrelationID = *msg.CorrelationID
+	}
+
+	// Adicionar ContentType se não for nil
+	if msg.ContentType != nil {
+		message.ContentType = *msg.ContentType
+	}
+
+	// Adicionar SessionID se não for nil
+	if msg.SessionID != nil {
+		message.SessionID = *msg.SessionID
+	}
+
+	// Adicionar propriedades do envelope se existirem
+	if msg.Subject != nil {
+		message.Subject = *msg.Subject
+	}
+	if msg.To != nil {
+		message.To = *msg.To
+	}
+	if msg.ReplyTo != nil {
+		message.ReplyTo = *msg.ReplyTo
+	}
+	if msg.ReplyToSessionID != nil {
+		message.ReplyToSessionID = *msg.ReplyToSessionID
+	}
+	if msg.PartitionKey != nil {
+		message.PartitionKey = *msg.PartitionKey
+	}
+	message.TimeToLive = msg.TimeToLive
+	message.ScheduledEnqueueTime = msg.ScheduledEnqueueTime
+
+	// Adicionar informações de dead-letter se existirem
+	if msg.DeadLetterReason != nil {
+		message.DeadLetterReason = *msg.DeadLetterReason
+	}
+	if msg.DeadLetterErrorDescription != nil {
+		message.DeadLetterErrorDescription = *msg.DeadLetterErrorDescription
+	}
+	if msg.DeadLetterSource != nil {
+		message.DeadLetterSource = *msg.DeadLetterSource
+	}
+
+	// Deserializar body, mantendo os bytes originais para reenvio
+	message.RawBody = append([]byte{}, msg.Body...)
+	if err := json.Unmarshal(msg.Body, &message.Body); err != nil {
+		message.Body = string(msg.Body)
+	}
+
+	// Adicionar propriedades
+	if msg.ApplicationProperties != nil {
+		message.Properties = make(map[string]interface{})
+		for k, v := range msg.ApplicationProperties {
+			message.Properties[k] = v
+		}
+	}
+
+	return message
+}
+
+// closeReceiver fecha um receiver ignorando erros
+func (c *Client) closeReceiver(receiver messageReceiver) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeouts.Close)
+	defer cancel()
+	_ = receiver.Close(ctx)
 }
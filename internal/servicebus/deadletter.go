@@ -0,0 +1,167 @@
+package servicebus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// Propriedades adicionadas pelo Service Bus quando uma mensagem vai para a dead-letter queue
+var deadLetterProperties = []string{"DeadLetterReason", "DeadLetterErrorDescription"}
+
+// ResubmitOptions controla o reenvio de mensagens da dead-letter queue
+type ResubmitOptions struct {
+	// MaxMessages limita o número de mensagens reenviadas
+	MaxMessages int
+	// SequenceNumbers restringe o reenvio a mensagens específicas (vazio = todas)
+	SequenceNumbers []int64
+	// Properties sobrescreve ou adiciona propriedades de aplicação
+	Properties map[string]interface{}
+	// Body substitui o corpo original quando não for nil
+	Body interface{}
+}
+
+// newReceiver cria um receiver para uma fila ou subscription
+func (c *Client) newReceiver(entity Entity, options *azservicebus.ReceiverOptions) (*azservicebus.Receiver, error) {
+	var receiver *azservicebus.Receiver
+	var err error
+
+	if entity.IsSubscription() {
+		receiver, err = c.client.NewReceiverForSubscription(entity.Topic, entity.Subscription, options)
+	} else {
+		receiver, err = c.client.NewReceiverForQueue(entity.Queue, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar receiver: %w", err)
+	}
+
+	return receiver, nil
+}
+
+// PeekDeadLetterMessages lê mensagens da dead-letter queue sem removê-las
+func (c *Client) PeekDeadLetterMessages(ctx context.Context, entity Entity, maxMessages int, fromSequence int64) ([]*Message, error) {
+	var messages []*Message
+	err := c.withReceiver(entity, &azservicebus.ReceiverOptions{SubQueue: azservicebus.SubQueueDeadLetter}, func(receiver *azservicebus.Receiver) error {
+		var err error
+		messages, err = c.peekMessages(ctx, receiver, maxMessages, fromSequence)
+		return err
+	})
+	return messages, err
+}
+
+// ResubmitDeadLetterMessages reenvia mensagens da dead-letter queue para a entidade de origem.
+// As propriedades originais são preservadas; para subscriptions o reenvio é feito no tópico,
+// portanto todas as subscriptions do tópico recebem a mensagem novamente.
+// A mensagem só é removida da dead-letter queue depois que o reenvio é concluído.
+func (c *Client) ResubmitDeadLetterMessages(ctx context.Context, entity Entity, options ResubmitOptions) ([]*Message, error) {
+	sender, release, err := c.sender(entity.SendTarget())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var result []*Message
+	err = c.withReceiver(entity, &azservicebus.ReceiverOptions{SubQueue: azservicebus.SubQueueDeadLetter}, func(receiver *azservicebus.Receiver) error {
+		var err error
+		result, err = c.ResubmitFrom(ctx, receiver, sender, options)
+		return err
+	})
+	return result, err
+}
+
+// ResubmitFrom reenvia pelo sender as mensagens selecionadas do receiver (uma dead-letter queue).
+// As mensagens de --sequence são localizadas com um peek e só então recebidas, portanto podem
+// estar em qualquer posição da dead-letter queue; as que vêm antes delas ficam bloqueadas até o
+// fim e depois voltam para a dead-letter queue.
+func (c *Client) ResubmitFrom(ctx context.Context, receiver Receiver, sender Sender, options ResubmitOptions) ([]*Message, error) {
+	var replacedBody []byte
+	if options.Body != nil {
+		var err error
+		replacedBody, err = json.Marshal(options.Body)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar novo body: %w", err)
+		}
+	}
+
+	wanted := make(map[int64]bool)
+	for _, seq := range options.SequenceNumbers {
+		wanted[seq] = true
+	}
+	choose := func(msg *azservicebus.ReceivedMessage) bool {
+		return len(wanted) == 0 || wanted[*msg.SequenceNumber]
+	}
+
+	var result []*Message
+	_, err := c.receiveSelected(ctx, receiver, options.MaxMessages, choose, func(msg *azservicebus.ReceivedMessage) error {
+		outgoing := msg.Message()
+		outgoing.ApplicationProperties = copyProperties(msg.ApplicationProperties)
+		for _, key := range deadLetterProperties {
+			delete(outgoing.ApplicationProperties, key)
+		}
+		for key, value := range options.Properties {
+			outgoing.ApplicationProperties[key] = value
+		}
+		if replacedBody != nil {
+			outgoing.Body = replacedBody
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
+		err := sender.SendMessage(sendCtx, outgoing, nil)
+		cancel()
+		if err != nil {
+			_ = c.settleMessage(ctx, receiver, msg, SettleAbandon)
+			return fmt.Errorf("erro ao reenviar mensagem %s: %w", msg.MessageID, err)
+		}
+
+		if err := c.settleMessage(ctx, receiver, msg, SettleComplete); err != nil {
+			return err
+		}
+		result = append(result, convertReceivedMessage(msg))
+		return nil
+	})
+	return result, err
+}
+
+// PurgeDeadLetterMessages remove todas as mensagens da dead-letter queue e retorna quantas foram removidas
+func (c *Client) PurgeDeadLetterMessages(ctx context.Context, entity Entity) (int, error) {
+	return c.purge(ctx, entity, &azservicebus.ReceiverOptions{
+		SubQueue:    azservicebus.SubQueueDeadLetter,
+		ReceiveMode: azservicebus.ReceiveModeReceiveAndDelete,
+	})
+}
+
+// purge recebe e descarta mensagens até esvaziar a sub-fila escolhida nas opções
+func (c *Client) purge(ctx context.Context, entity Entity, options *azservicebus.ReceiverOptions) (int, error) {
+	total := 0
+	err := c.withReceiver(entity, options, func(receiver *azservicebus.Receiver) error {
+		for {
+			receiveCtx, cancel := context.WithTimeout(ctx, c.timeouts.Drain)
+			messages, err := receiver.ReceiveMessages(receiveCtx, peekPageSize, nil)
+			cancel()
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+					return nil
+				}
+				return fmt.Errorf("erro ao remover mensagens: %w", err)
+			}
+			if len(messages) == 0 {
+				return nil
+			}
+			total += len(messages)
+		}
+	})
+
+	return total, err
+}
+
+// copyProperties copia um mapa de propriedades de aplicação
+func copyProperties(properties map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(properties))
+	for k, v := range properties {
+		result[k] = v
+	}
+	return result
+}
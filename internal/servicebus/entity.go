@@ -0,0 +1,53 @@
+package servicebus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Entity identifica uma fila ou uma subscription de tópico
+type Entity struct {
+	Queue        string
+	Topic        string
+	Subscription string
+}
+
+// ParseEntity interpreta um nome de entidade no formato "fila" ou "tópico/subscription"
+func ParseEntity(name string) (Entity, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Entity{}, fmt.Errorf("nome de entidade vazio")
+	}
+
+	if !strings.Contains(name, "/") {
+		return Entity{Queue: name}, nil
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Entity{}, fmt.Errorf("entidade inválida '%s': use 'fila' ou 'tópico/subscription'", name)
+	}
+
+	return Entity{Topic: parts[0], Subscription: parts[1]}, nil
+}
+
+// IsSubscription indica se a entidade é uma subscription de tópico
+func (e Entity) IsSubscription() bool {
+	return e.Topic != ""
+}
+
+// SendTarget retorna a fila ou tópico para onde mensagens desta entidade devem ser enviadas
+func (e Entity) SendTarget() string {
+	if e.IsSubscription() {
+		return e.Topic
+	}
+	return e.Queue
+}
+
+// String retorna a entidade no mesmo formato aceito por ParseEntity
+func (e Entity) String() string {
+	if e.IsSubscription() {
+		return e.Topic + "/" + e.Subscription
+	}
+	return e.Queue
+}
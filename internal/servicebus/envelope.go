@@ -0,0 +1,116 @@
+package servicebus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnvelopeKey é a chave que identifica um arquivo de mensagem no formato envelope
+const EnvelopeKey = "$envelope"
+
+// Envelope descreve uma mensagem completa do Service Bus em arquivos de fixture.
+//
+// Exemplo:
+//
+//	{
+//	  "$envelope": 1,
+//	  "subject": "transaction.created",
+//	  "applicationProperties": {"eventType": "transaction-created"},
+//	  "timeToLive": "10m",
+//	  "body": {"id": "123"}
+//	}
+type Envelope struct {
+	Version               interface{}            `json:"$envelope"`
+	Body                  json.RawMessage        `json:"body"`
+	MessageID             string                 `json:"messageId,omitempty"`
+	CorrelationID         string                 `json:"correlationId,omitempty"`
+	ContentType           string                 `json:"contentType,omitempty"`
+	ApplicationProperties map[string]interface{} `json:"applicationProperties,omitempty"`
+	Subject               string                 `json:"subject,omitempty"`
+	To                    string                 `json:"to,omitempty"`
+	ReplyTo               string                 `json:"replyTo,omitempty"`
+	ReplyToSessionID      string                 `json:"replyToSessionId,omitempty"`
+	SessionID             string                 `json:"sessionId,omitempty"`
+	PartitionKey          string                 `json:"partitionKey,omitempty"`
+	TimeToLive            string                 `json:"timeToLive,omitempty"`
+	ScheduledEnqueueTime  string                 `json:"scheduledEnqueueTime,omitempty"`
+}
+
+// IsEnvelope indica se o JSON é um objeto com a chave $envelope
+func IsEnvelope(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+
+	_, found := fields[EnvelopeKey]
+	return found
+}
+
+// ParseEnvelope converte um envelope JSON em mensagem. TimeToLive usa durações Go (ex: 10m, 1h30m)
+// e ScheduledEnqueueTime usa RFC3339.
+func ParseEnvelope(data []byte) (*Message, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var envelope Envelope
+	if err := decoder.Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("envelope inválido: %w", err)
+	}
+
+	if len(envelope.Body) == 0 {
+		return nil, fmt.Errorf("envelope inválido: campo 'body' é obrigatório")
+	}
+
+	message := &Message{
+		MessageID:        envelope.MessageID,
+		CorrelationID:    envelope.CorrelationID,
+		ContentType:      envelope.ContentType,
+		Properties:       envelope.ApplicationProperties,
+		Subject:          envelope.Subject,
+		To:               envelope.To,
+		ReplyTo:          envelope.ReplyTo,
+		ReplyToSessionID: envelope.ReplyToSessionID,
+		SessionID:        envelope.SessionID,
+		PartitionKey:     envelope.PartitionKey,
+	}
+
+	bodyDecoder := json.NewDecoder(bytes.NewReader(envelope.Body))
+	bodyDecoder.UseNumber()
+	if err := bodyDecoder.Decode(&message.Body); err != nil {
+		return nil, fmt.Errorf("envelope inválido: body: %w", err)
+	}
+
+	if message.ContentType == "" {
+		message.ContentType = "application/json"
+	}
+
+	if envelope.TimeToLive != "" {
+		ttl, err := time.ParseDuration(envelope.TimeToLive)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("envelope inválido: timeToLive '%s' não é uma duração positiva", envelope.TimeToLive)
+		}
+		message.TimeToLive = &ttl
+	}
+
+	if envelope.ScheduledEnqueueTime != "" {
+		scheduled, err := time.Parse(time.RFC3339, envelope.ScheduledEnqueueTime)
+		if err != nil {
+			return nil, fmt.Errorf("envelope inválido: scheduledEnqueueTime '%s' não está em RFC3339", envelope.ScheduledEnqueueTime)
+		}
+		message.ScheduledEnqueueTime = &scheduled
+	}
+
+	if message.SessionID != "" && message.PartitionKey != "" && message.SessionID != message.PartitionKey {
+		return nil, fmt.Errorf("envelope inválido: partitionKey deve ser igual ao sessionId")
+	}
+
+	return message, nil
+}
@@ -0,0 +1,164 @@
+package servicebus
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BodyEncodingBase64 indica, no campo bodyEncoding das linhas do export, que o body está em base64
+const BodyEncodingBase64 = "base64"
+
+// messageFields tem os campos de Message sem os métodos, para ser embutido em exportedMessage
+type messageFields Message
+
+// exportedMessage é uma linha do export. Body e TimeToLive substituem os campos de Message:
+// o body guarda os bytes originais (JSON compacto sem alterações ou base64) e o TTL usa a
+// duração Go, como nos envelopes (ex: 10m0s).
+type exportedMessage struct {
+	*messageFields
+	Body         json.RawMessage `json:"body"`
+	BodyEncoding string          `json:"bodyEncoding,omitempty"`
+	TimeToLive   string          `json:"timeToLive,omitempty"`
+}
+
+// EncodeMessage serializa a mensagem completa (body, propriedades de sistema e de aplicação)
+// em uma linha JSON, usando as tags de Message. É o formato das linhas do export. Bodies JSON
+// compactos são gravados como estão; os demais (texto, binário, JSON com espaços) em base64,
+// para que o import envie exatamente os mesmos bytes.
+func EncodeMessage(message *Message) ([]byte, error) {
+	raw, err := encodeBody(message)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedMessage{messageFields: (*messageFields)(message)}
+	if isCompactJSON(raw) {
+		exported.Body = raw
+	} else {
+		encoded, _ := json.Marshal(base64.StdEncoding.EncodeToString(raw))
+		exported.Body = encoded
+		exported.BodyEncoding = BodyEncodingBase64
+	}
+	if message.TimeToLive != nil {
+		exported.TimeToLive = message.TimeToLive.String()
+	}
+
+	// Sem escape de HTML, para que o body JSON seja gravado byte a byte
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(exported); err != nil {
+		return nil, fmt.Errorf("erro ao serializar mensagem: %w", err)
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// DecodeMessage lê uma mensagem gerada por EncodeMessage. Números inteiros das propriedades de
+// aplicação voltam como int64 e os bytes do body são preservados em RawBody.
+func DecodeMessage(data []byte) (*Message, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+
+	var message Message
+	exported := exportedMessage{messageFields: (*messageFields)(&message)}
+	if err := decoder.Decode(&exported); err != nil {
+		return nil, fmt.Errorf("mensagem exportada inválida: %w", err)
+	}
+
+	switch exported.BodyEncoding {
+	case "":
+		if len(exported.Body) == 0 {
+			return nil, fmt.Errorf("mensagem exportada inválida: campo 'body' é obrigatório")
+		}
+		message.RawBody = []byte(exported.Body)
+	case BodyEncodingBase64:
+		var text string
+		if err := json.Unmarshal(exported.Body, &text); err != nil {
+			return nil, fmt.Errorf("mensagem exportada inválida: body em base64 deve ser uma string")
+		}
+		raw, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("mensagem exportada inválida: body: %w", err)
+		}
+		message.RawBody = raw
+	default:
+		return nil, fmt.Errorf("mensagem exportada inválida: bodyEncoding '%s' desconhecido", exported.BodyEncoding)
+	}
+	message.Body = decodeExportedBody(message.RawBody)
+
+	if exported.TimeToLive != "" {
+		ttl, err := time.ParseDuration(exported.TimeToLive)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("mensagem exportada inválida: timeToLive '%s' não é uma duração positiva", exported.TimeToLive)
+		}
+		message.TimeToLive = &ttl
+	}
+
+	for key, value := range message.Properties {
+		property, err := decodeProperty(value)
+		if err != nil {
+			return nil, fmt.Errorf("propriedade '%s': %w", key, err)
+		}
+		message.Properties[key] = property
+	}
+	return &message, nil
+}
+
+// decodeExportedBody interpreta o body como JSON, mantendo a representação original dos números,
+// ou como texto se não for JSON
+func decodeExportedBody(raw []byte) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil || decoder.More() {
+		return string(raw)
+	}
+	return body
+}
+
+// isCompactJSON indica se os bytes são JSON válido que json.Marshal gravaria sem alterações
+func isCompactJSON(data []byte) bool {
+	if !json.Valid(data) {
+		return false
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return false
+	}
+	return bytes.Equal(compact.Bytes(), data)
+}
+
+// decodeProperty converte um json.Number no tipo numérico aceito pelo Service Bus
+func decodeProperty(value interface{}) (interface{}, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value, nil
+	}
+	if !strings.ContainsAny(number.String(), ".eE") {
+		if integer, err := number.Int64(); err == nil {
+			return integer, nil
+		}
+	}
+	return number.Float64()
+}
+
+// PrepareForImport remove os campos atribuídos pelo broker (sequence number, horário de
+// enfileiramento, entregas e dados de dead-letter) para que a mensagem possa ser reenviada.
+// Agendamentos já vencidos são descartados e a mensagem é enviada imediatamente.
+func (m *Message) PrepareForImport(now time.Time) {
+	m.SequenceNumber = 0
+	m.EnqueuedTimeUtc = nil
+	m.DeliveryCount = 0
+	m.DeadLetterReason = ""
+	m.DeadLetterErrorDescription = ""
+	m.DeadLetterSource = ""
+
+	if m.ScheduledEnqueueTime != nil && !m.ScheduledEnqueueTime.After(now) {
+		m.ScheduledEnqueueTime = nil
+	}
+}
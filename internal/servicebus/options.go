@@ -0,0 +1,154 @@
+package servicebus
+
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// DefaultConnectionString é a connection string do Service Bus Emulator usada quando nenhuma é informada
+const DefaultConnectionString = "Endpoint=sb://localhost;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=FAKE-SAS-KEY-VALUE"
+
+// Logger recebe as mensagens de diagnóstico do cliente
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Timeouts define o tempo máximo de cada tipo de operação. Os timeouts são aplicados
+// sobre o contexto recebido, que continua podendo cancelar a operação antes.
+type Timeouts struct {
+	// Send limita envios, agendamentos e cancelamentos
+	Send time.Duration
+	// Receive é o tempo de espera pela primeira mensagem de um recebimento
+	Receive time.Duration
+	// Drain é o tempo de espera pelas mensagens seguintes, depois do primeiro lote
+	Drain time.Duration
+	// Operation limita peeks, liquidações e aceite de sessões
+	Operation time.Duration
+	// Close limita o fechamento do cliente, senders e receivers
+	Close time.Duration
+}
+
+// DefaultTimeouts retorna os timeouts padrão do cliente
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Send:      30 * time.Second,
+		Receive:   30 * time.Second,
+		Drain:     5 * time.Second,
+		Operation: 30 * time.Second,
+		Close:     30 * time.Second,
+	}
+}
+
+// Option configura o cliente criado por NewClient
+type Option func(*clientOptions)
+
+// clientOptions reúne a configuração do cliente
+type clientOptions struct {
+	connectionString string
+	logger           Logger
+	retryOptions     azservicebus.RetryOptions
+	tlsConfig        *tls.Config
+	timeouts         Timeouts
+	cacheEntities    bool
+}
+
+// WithConnectionString define a connection string do Service Bus
+func WithConnectionString(connectionString string) Option {
+	return func(o *clientOptions) {
+		o.connectionString = connectionString
+	}
+}
+
+// WithLogger define o logger de diagnóstico; por padrão nada é registrado
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithRetryOptions define a política de retentativas do SDK
+func WithRetryOptions(retryOptions azservicebus.RetryOptions) Option {
+	return func(o *clientOptions) {
+		o.retryOptions = retryOptions
+	}
+}
+
+// WithTLSConfig define a configuração TLS da conexão AMQP
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithTimeouts define os timeouts das operações; valores zerados mantêm o padrão
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(o *clientOptions) {
+		if timeouts.Send > 0 {
+			o.timeouts.Send = timeouts.Send
+		}
+		if timeouts.Receive > 0 {
+			o.timeouts.Receive = timeouts.Receive
+		}
+		if timeouts.Drain > 0 {
+			o.timeouts.Drain = timeouts.Drain
+		}
+		if timeouts.Operation > 0 {
+			o.timeouts.Operation = timeouts.Operation
+		}
+		if timeouts.Close > 0 {
+			o.timeouts.Close = timeouts.Close
+		}
+	}
+}
+
+// WithEntityCache liga ou desliga o cache de senders e receivers por entidade.
+// Sem cache, cada operação cria e fecha seus próprios links AMQP.
+func WithEntityCache(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.cacheEntities = enabled
+	}
+}
+
+// defaultClientOptions retorna a configuração padrão, compatível com o emulador
+func defaultClientOptions() clientOptions {
+	return clientOptions{
+		connectionString: DefaultConnectionString,
+		logger:           nopLogger{},
+		retryOptions: azservicebus.RetryOptions{
+			MaxRetries: 3,
+		},
+		// O emulador usa um certificado autoassinado atrás do proxy TLS
+		tlsConfig: &tls.Config{
+			InsecureSkipVerify: true,
+		},
+		timeouts:      DefaultTimeouts(),
+		cacheEntities: true,
+	}
+}
+
+// nopLogger descarta as mensagens de diagnóstico
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// SetDevelopmentEmulator liga ou desliga UseDevelopmentEmulator na connection string.
+// Com o emulador ligado o SDK conecta via AMQP sem TLS na porta 5672; desligado, via
+// AMQPS na porta 5671 (atendida localmente pelo proxy TLS).
+func SetDevelopmentEmulator(connectionString string, enabled bool) string {
+	var parts []string
+	for _, part := range strings.Split(connectionString, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" || strings.HasPrefix(strings.ToLower(part), "usedevelopmentemulator=") {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	if enabled {
+		parts = append(parts, "UseDevelopmentEmulator=true")
+	}
+	return strings.Join(parts, ";")
+}
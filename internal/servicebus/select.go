@@ -0,0 +1,148 @@
+package servicebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// Receiver são as operações do receiver do Azure Service Bus usadas para escolher, receber e
+// liquidar mensagens. *azservicebus.Receiver implementa Receiver.
+type Receiver interface {
+	PeekMessages(ctx context.Context, maxMessageCount int, options *azservicebus.PeekMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+	ReceiveMessages(ctx context.Context, maxMessages int, options *azservicebus.ReceiveMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
+	CompleteMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.CompleteMessageOptions) error
+	AbandonMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.AbandonMessageOptions) error
+	DeadLetterMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.DeadLetterOptions) error
+	RenewMessageLock(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.RenewMessageLockOptions) error
+}
+
+// Sender envia mensagens a uma fila ou tópico. *azservicebus.Sender implementa Sender.
+type Sender interface {
+	SendMessage(ctx context.Context, message *azservicebus.Message, options *azservicebus.SendMessageOptions) error
+}
+
+// lockRenewInterval é o intervalo de renovação dos locks das mensagens mantidas bloqueadas,
+// bem abaixo do LockDuration de um minuto das entidades do emulador
+const lockRenewInterval = 20 * time.Second
+
+// receiveSelected escolhe as mensagens com um peek da entidade (choose, até maxMessages; 0 = sem
+// limite) e depois recebe apenas até a última escolhida, chamando process para cada uma. process
+// liquida a mensagem. As demais mensagens recebidas no caminho ficam bloqueadas, com o lock
+// renovado, e são abandonadas uma única vez ao final. Retorna quantas mensagens espiadas não
+// foram escolhidas.
+func (c *Client) receiveSelected(ctx context.Context, receiver Receiver, maxMessages int, choose func(*azservicebus.ReceivedMessage) bool, process func(*azservicebus.ReceivedMessage) error) (int, error) {
+	targets := make(map[int64]bool)
+	var last int64
+	skipped := 0
+	// span é o número de mensagens até a última escolhida, o máximo a receber
+	span, peeked := 0, 0
+	_, err := c.scanMessages(ctx, receiver, math.MaxInt, 0, func(msg *azservicebus.ReceivedMessage) error {
+		if maxMessages > 0 && len(targets) >= maxMessages {
+			return errStopScan
+		}
+		// Mensagens agendadas não podem ser recebidas e não são escolhidas nem contadas
+		if msg.State == azservicebus.MessageStateScheduled {
+			return nil
+		}
+		peeked++
+		if msg.SequenceNumber == nil || !choose(msg) {
+			skipped++
+			return nil
+		}
+		targets[*msg.SequenceNumber] = true
+		last = *msg.SequenceNumber
+		span = peeked
+		return nil
+	})
+	if err != nil || len(targets) == 0 {
+		return skipped, err
+	}
+
+	held := make(map[int64]*azservicebus.ReceivedMessage)
+	defer func() {
+		// Devolver as mensagens bloqueadas mesmo se a operação foi cancelada
+		releaseCtx := context.WithoutCancel(ctx)
+		for _, msg := range held {
+			_ = c.settleMessage(releaseCtx, receiver, msg, SettleAbandon)
+		}
+	}()
+
+	received := make(map[int64]bool)
+	renewed := time.Now()
+	timeout := c.timeouts.Receive
+	for len(targets) > 0 {
+		if time.Since(renewed) >= lockRenewInterval {
+			c.renewLocks(ctx, receiver, held)
+			renewed = time.Now()
+		}
+
+		batchSize := span - len(received)
+		if batchSize > peekPageSize {
+			batchSize = peekPageSize
+		}
+		if batchSize < 1 {
+			batchSize = 1
+		}
+
+		receiveCtx, cancel := context.WithTimeout(ctx, timeout)
+		messages, err := receiver.ReceiveMessages(receiveCtx, batchSize, nil)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				return skipped, nil
+			}
+			return skipped, fmt.Errorf("erro ao receber mensagens: %w", err)
+		}
+		if len(messages) == 0 {
+			return skipped, nil
+		}
+		timeout = c.timeouts.Drain
+
+		for _, msg := range messages {
+			if msg.SequenceNumber == nil {
+				// Sem sequence number não é possível reconhecer a mensagem depois
+				if err := c.settleMessage(ctx, receiver, msg, SettleAbandon); err != nil {
+					return skipped, err
+				}
+				continue
+			}
+			seq := *msg.SequenceNumber
+			received[seq] = true
+
+			if targets[seq] {
+				delete(targets, seq)
+				if err := process(msg); err != nil {
+					return skipped, err
+				}
+				continue
+			}
+			// Uma mensagem entregue de novo (lock expirado) substitui a anterior e não é contada duas vezes
+			held[seq] = msg
+		}
+
+		// Depois da última escolhida, as que faltam foram consumidas por outro receiver
+		if seq := messages[len(messages)-1].SequenceNumber; seq != nil && *seq > last {
+			return skipped, nil
+		}
+	}
+	return skipped, nil
+}
+
+// renewLocks renova os locks das mensagens bloqueadas. Uma mensagem cujo lock não pôde ser
+// renovado já voltou para a entidade e deixa de ser abandonada ao final.
+func (c *Client) renewLocks(ctx context.Context, receiver Receiver, held map[int64]*azservicebus.ReceivedMessage) {
+	for seq, msg := range held {
+		renewCtx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+		err := receiver.RenewMessageLock(renewCtx, msg, nil)
+		cancel()
+		if err != nil {
+			c.logger.Printf("lock da mensagem %d não renovado: %v", seq, err)
+			delete(held, seq)
+		}
+	}
+}
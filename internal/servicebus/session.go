@@ -0,0 +1,70 @@
+package servicebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// acceptSession abre um receiver de sessão para uma fila ou subscription.
+// Se sessionID estiver vazio, aguarda a próxima sessão disponível.
+func (c *Client) acceptSession(ctx context.Context, entity Entity, sessionID string) (*azservicebus.SessionReceiver, error) {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Operation)
+	defer cancel()
+
+	var receiver *azservicebus.SessionReceiver
+	var err error
+
+	switch {
+	case sessionID == "" && entity.IsSubscription():
+		receiver, err = c.client.AcceptNextSessionForSubscription(ctx, entity.Topic, entity.Subscription, nil)
+	case sessionID == "":
+		receiver, err = c.client.AcceptNextSessionForQueue(ctx, entity.Queue, nil)
+	case entity.IsSubscription():
+		receiver, err = c.client.AcceptSessionForSubscription(ctx, entity.Topic, entity.Subscription, sessionID, nil)
+	default:
+		receiver, err = c.client.AcceptSessionForQueue(ctx, entity.Queue, sessionID, nil)
+	}
+
+	if err != nil {
+		if sessionID == "" && errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+			return nil, fmt.Errorf("nenhuma sessão disponível em '%s'", entity)
+		}
+		return nil, fmt.Errorf("erro ao aceitar sessão: %w", err)
+	}
+
+	return receiver, nil
+}
+
+// ReceiveSessionMessages recebe mensagens de uma sessão aplicando o modo de liquidação informado.
+// Se sessionID estiver vazio, usa a próxima sessão disponível. Retorna o ID da sessão utilizada.
+func (c *Client) ReceiveSessionMessages(ctx context.Context, entity Entity, sessionID string, maxMessages int, mode SettleMode) ([]*Message, string, error) {
+	if mode == SettlePeek {
+		return c.PeekSessionMessages(ctx, entity, sessionID, maxMessages, 0)
+	}
+
+	receiver, err := c.acceptSession(ctx, entity, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer c.closeReceiver(receiver)
+
+	messages, err := c.receiveMessages(ctx, receiver, maxMessages, mode)
+	return messages, receiver.SessionID(), err
+}
+
+// PeekSessionMessages lê mensagens de uma sessão sem removê-las.
+// Se sessionID estiver vazio, usa a próxima sessão disponível. Retorna o ID da sessão utilizada.
+func (c *Client) PeekSessionMessages(ctx context.Context, entity Entity, sessionID string, maxMessages int, fromSequence int64) ([]*Message, string, error) {
+	receiver, err := c.acceptSession(ctx, entity, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer c.closeReceiver(receiver)
+
+	messages, err := c.peekMessages(ctx, receiver, maxMessages, fromSequence)
+	return messages, receiver.SessionID(), err
+}
@@ -0,0 +1,178 @@
+package servicebus
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// TailOptions controla o acompanhamento contínuo de uma entidade
+type TailOptions struct {
+	// Mode define a liquidação: SettlePeek (polling sem alterar a entidade), SettleAbandon ou SettleComplete
+	Mode SettleMode
+	// PollInterval é o intervalo entre consultas quando não há mensagens novas
+	PollInterval time.Duration
+	// FromStart inclui, no modo peek, as mensagens que já estavam na entidade
+	FromStart bool
+	// FromSequence, no modo peek, começa a partir deste sequence number (ignora FromStart)
+	FromSequence int64
+}
+
+// Tail acompanha uma fila ou subscription chamando handler para cada mensagem nova,
+// até que ctx seja cancelado. No modo peek mensagens consumidas entre duas consultas
+// por outro receiver podem não ser vistas.
+func (c *Client) Tail(ctx context.Context, entity Entity, options TailOptions, handler func(*Message)) error {
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Second
+	}
+
+	receiver, err := c.newReceiver(entity, nil)
+	if err != nil {
+		return err
+	}
+	defer c.closeReceiver(receiver)
+
+	if options.Mode == SettlePeek {
+		return tailPeek(ctx, receiver, options, handler)
+	}
+	return c.TailFrom(ctx, receiver, options, handler)
+}
+
+// tailPeek consulta a entidade periodicamente com peek, a partir do último sequence number visto
+func tailPeek(ctx context.Context, receiver *azservicebus.Receiver, options TailOptions, handler func(*Message)) error {
+	// Com FromSequence o cursor é controlado aqui; caso contrário pelo próprio receiver
+	nextSequence := options.FromSequence
+
+	// Avançar o cursor do receiver até o fim para mostrar apenas mensagens novas
+	if nextSequence <= 0 && !options.FromStart {
+		for {
+			messages, err := receiver.PeekMessages(ctx, peekPageSize, nil)
+			if err != nil {
+				return ignoreCancel(ctx, err)
+			}
+			if len(messages) == 0 {
+				break
+			}
+		}
+	}
+
+	for {
+		var peekOptions *azservicebus.PeekMessagesOptions
+		if nextSequence > 0 {
+			peekOptions = &azservicebus.PeekMessagesOptions{FromSequenceNumber: &nextSequence}
+		}
+		messages, err := receiver.PeekMessages(ctx, peekPageSize, peekOptions)
+		if err != nil {
+			return ignoreCancel(ctx, err)
+		}
+
+		for _, msg := range messages {
+			handler(convertReceivedMessage(msg))
+		}
+		if nextSequence > 0 && len(messages) > 0 {
+			if last := messages[len(messages)-1].SequenceNumber; last != nil {
+				nextSequence = *last + 1
+			}
+		}
+
+		if len(messages) == 0 && !sleepContext(ctx, options.PollInterval) {
+			return nil
+		}
+	}
+}
+
+// tailSeenLimit é quantos sequence numbers o tail lembra para não mostrar de novo mensagens devolvidas
+const tailSeenLimit = 10000
+
+// TailFrom recebe mensagens do receiver com peek-lock e liquida cada uma logo após o handler,
+// até que ctx seja cancelado. No modo abandon a mensagem volta para a entidade (o delivery count
+// aumenta a cada recebimento) e, quando entregue de novo, é devolvida sem ser mostrada outra vez.
+// Se um recebimento não trouxer mensagens novas, a próxima consulta espera PollInterval.
+func (c *Client) TailFrom(ctx context.Context, receiver Receiver, options TailOptions, handler func(*Message)) error {
+	seen := newRecentSequences(tailSeenLimit)
+	for {
+		messages, err := receiver.ReceiveMessages(ctx, 10, nil)
+		if err != nil {
+			return ignoreCancel(ctx, err)
+		}
+
+		shown := 0
+		for _, msg := range messages {
+			if msg.SequenceNumber == nil || seen.add(*msg.SequenceNumber) {
+				handler(convertReceivedMessage(msg))
+				shown++
+			}
+			if err := c.settleMessage(ctx, receiver, msg, options.Mode); err != nil {
+				return ignoreCancel(ctx, err)
+			}
+		}
+
+		if shown == 0 && !sleepContext(ctx, options.PollInterval) {
+			return nil
+		}
+	}
+}
+
+// recentSequences guarda os últimos sequence numbers vistos, descartando os mais antigos após o limite
+type recentSequences struct {
+	limit int
+	order []int64
+	set   map[int64]bool
+}
+
+func newRecentSequences(limit int) *recentSequences {
+	return &recentSequences{limit: limit, set: make(map[int64]bool)}
+}
+
+// add registra o sequence number e retorna false se ele já tinha sido visto
+func (r *recentSequences) add(seq int64) bool {
+	if r.set[seq] {
+		return false
+	}
+	if len(r.order) == r.limit {
+		delete(r.set, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.order = append(r.order, seq)
+	r.set[seq] = true
+	return true
+}
+
+// LastSequenceNumber espia a fila ou subscription inteira e retorna o maior sequence number
+// presente (0 se estiver vazia). Serve de ponto de partida para acompanhar apenas mensagens novas.
+func (c *Client) LastSequenceNumber(ctx context.Context, entity Entity) (int64, error) {
+	var last int64
+	err := c.withReceiver(entity, nil, func(receiver *azservicebus.Receiver) error {
+		_, err := c.scanMessages(ctx, receiver, math.MaxInt, 0, func(msg *azservicebus.ReceivedMessage) error {
+			if msg.SequenceNumber != nil && *msg.SequenceNumber > last {
+				last = *msg.SequenceNumber
+			}
+			return nil
+		})
+		return err
+	})
+	return last, err
+}
+
+// ignoreCancel descarta o erro quando ele foi causado pelo cancelamento do contexto
+func ignoreCancel(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// sleepContext aguarda a duração informada e retorna false se o contexto for cancelado antes
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
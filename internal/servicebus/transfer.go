@@ -0,0 +1,155 @@
+package servicebus
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+)
+
+// TransferOptions controla a cópia e a movimentação de mensagens entre entidades
+type TransferOptions struct {
+	// MaxMessages limita o número de mensagens transferidas (0 = todas)
+	MaxMessages int
+	// DeadLetter lê da dead-letter queue da origem em vez da sub-fila ativa
+	DeadLetter bool
+	// Match seleciona as mensagens transferidas (nil = todas)
+	Match func(*Message) bool
+}
+
+// TransferResult resume uma cópia ou movimentação
+type TransferResult struct {
+	// Transferred são as mensagens enviadas ao destino, na ordem da origem
+	Transferred []*Message
+	// Skipped é o número de mensagens lidas que não atenderam ao filtro
+	Skipped int
+}
+
+// CopyMessages espia as mensagens da origem e envia cópias para a fila ou tópico de destino.
+// A origem não é alterada; mensagens agendadas são copiadas com o mesmo agendamento.
+func (c *Client) CopyMessages(ctx context.Context, source Entity, destination string, options TransferOptions) (*TransferResult, error) {
+	if err := checkTransfer(source, destination, options); err != nil {
+		return nil, err
+	}
+
+	sender, release, err := c.sender(destination)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result := &TransferResult{}
+	err = c.withReceiver(source, transferReceiverOptions(options), func(receiver *azservicebus.Receiver) error {
+		_, err := c.scanMessages(ctx, receiver, math.MaxInt, 0, func(msg *azservicebus.ReceivedMessage) error {
+			message := convertReceivedMessage(msg)
+			if options.Match != nil && !options.Match(message) {
+				result.Skipped++
+				return nil
+			}
+
+			if err := c.sendTransfer(ctx, sender, msg, options); err != nil {
+				return err
+			}
+			result.Transferred = append(result.Transferred, message)
+
+			if options.MaxMessages > 0 && len(result.Transferred) >= options.MaxMessages {
+				return errStopScan
+			}
+			return nil
+		})
+		return err
+	})
+	return result, err
+}
+
+// MoveMessages recebe as mensagens da origem, envia para a fila ou tópico de destino e só então
+// as remove da origem. As mensagens que não atendem ao filtro e estão antes da última escolhida
+// ficam bloqueadas até o fim da operação e depois são abandonadas uma única vez (o DeliveryCount
+// delas aumenta em 1). Mensagens agendadas não podem ser recebidas e permanecem na origem.
+func (c *Client) MoveMessages(ctx context.Context, source Entity, destination string, options TransferOptions) (*TransferResult, error) {
+	if err := checkTransfer(source, destination, options); err != nil {
+		return nil, err
+	}
+
+	sender, release, err := c.sender(destination)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result := &TransferResult{}
+	err = c.withReceiver(source, transferReceiverOptions(options), func(receiver *azservicebus.Receiver) error {
+		var err error
+		result, err = c.MoveFrom(ctx, receiver, sender, options)
+		return err
+	})
+	return result, err
+}
+
+// MoveFrom move pelo sender as mensagens do receiver que atendem ao filtro. As mensagens são
+// escolhidas com um peek e só então recebidas, portanto cada mensagem ignorada é contada uma
+// única vez em Skipped, mesmo que seja entregue de novo.
+func (c *Client) MoveFrom(ctx context.Context, receiver Receiver, sender Sender, options TransferOptions) (*TransferResult, error) {
+	result := &TransferResult{}
+	choose := func(msg *azservicebus.ReceivedMessage) bool {
+		return options.Match == nil || options.Match(convertReceivedMessage(msg))
+	}
+
+	skipped, err := c.receiveSelected(ctx, receiver, options.MaxMessages, choose, func(msg *azservicebus.ReceivedMessage) error {
+		if err := c.sendTransfer(ctx, sender, msg, options); err != nil {
+			_ = c.settleMessage(ctx, receiver, msg, SettleAbandon)
+			return err
+		}
+		if err := c.settleMessage(ctx, receiver, msg, SettleComplete); err != nil {
+			return fmt.Errorf("mensagem %s enviada ao destino, mas não removida da origem: %w", msg.MessageID, err)
+		}
+		result.Transferred = append(result.Transferred, convertReceivedMessage(msg))
+		return nil
+	})
+	result.Skipped = skipped
+	return result, err
+}
+
+// sendTransfer envia ao destino uma cópia fiel da mensagem recebida
+func (c *Client) sendTransfer(ctx context.Context, sender Sender, msg *azservicebus.ReceivedMessage, options TransferOptions) error {
+	outgoing := msg.Message()
+	outgoing.ApplicationProperties = copyProperties(msg.ApplicationProperties)
+	if options.DeadLetter {
+		for _, key := range deadLetterProperties {
+			delete(outgoing.ApplicationProperties, key)
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, c.timeouts.Send)
+	defer cancel()
+	if err := sender.SendMessage(sendCtx, outgoing, nil); err != nil {
+		return fmt.Errorf("erro ao enviar mensagem %s: %w", msg.MessageID, err)
+	}
+	return nil
+}
+
+// PurgeMessages remove todas as mensagens ativas da entidade e retorna quantas foram removidas.
+// Mensagens agendadas e a dead-letter queue não são afetadas.
+func (c *Client) PurgeMessages(ctx context.Context, entity Entity) (int, error) {
+	return c.purge(ctx, entity, &azservicebus.ReceiverOptions{ReceiveMode: azservicebus.ReceiveModeReceiveAndDelete})
+}
+
+// checkTransfer impede que a origem receba de volta as mensagens enviadas, o que nunca terminaria
+func checkTransfer(source Entity, destination string, options TransferOptions) error {
+	if options.MaxMessages < 0 {
+		return fmt.Errorf("número máximo de mensagens inválido: %d", options.MaxMessages)
+	}
+	if !options.DeadLetter && source.SendTarget() == destination {
+		return fmt.Errorf("origem '%s' e destino '%s' são a mesma entidade", source, destination)
+	}
+	return nil
+}
+
+// transferReceiverOptions escolhe a sub-fila lida na origem
+func transferReceiverOptions(options TransferOptions) *azservicebus.ReceiverOptions {
+	if options.DeadLetter {
+		return &azservicebus.ReceiverOptions{SubQueue: azservicebus.SubQueueDeadLetter}
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package sqlfilter
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// systemProperties são as propriedades de sistema aceitas com o prefixo sys.
+var systemProperties = []string{
+	"MessageId", "CorrelationId", "ContentType", "Label", "To", "ReplyTo", "ReplyToSessionId",
+	"SessionId", "PartitionKey", "TimeToLive", "ScheduledEnqueueTimeUtc", "EnqueuedTimeUtc",
+	"SequenceNumber", "DeliveryCount", "Size",
+}
+
+// Message contém as propriedades avaliadas por filtros e alteradas por ações.
+// System usa os nomes de systemProperties (ex.: "Label"); Properties são as propriedades de aplicação.
+type Message struct {
+	System     map[string]interface{}
+	Properties map[string]interface{}
+}
+
+// Match indica se a mensagem satisfaz o filtro. Comparações com propriedades
+// ausentes resultam em desconhecido (NULL), que não seleciona a mensagem.
+func (f *Filter) Match(message *Message) bool {
+	result, ok := f.root.eval(message).(bool)
+	return ok && result
+}
+
+// Apply executa os comandos SET e REMOVE da ação sobre a mensagem
+func (a *Action) Apply(message *Message) {
+	for _, statement := range a.statements {
+		values := message.values(statement.target)
+		if statement.remove {
+			if key, ok := lookupKey(values, statement.target.name); ok {
+				delete(values, key)
+			}
+			continue
+		}
+
+		value := statement.value.eval(message)
+		if key, ok := lookupKey(values, statement.target.name); ok {
+			values[key] = value
+		} else {
+			values[statement.target.name] = value
+		}
+	}
+}
+
+// Equal compara dois valores de propriedade com a mesma regra do operador = dos filtros
+func Equal(left, right interface{}) bool {
+	equal, ok := compare("=", normalize(left), normalize(right)).(bool)
+	return ok && equal
+}
+
+// values retorna o mapa onde a propriedade fica, criando-o se necessário
+func (m *Message) values(target property) map[string]interface{} {
+	if target.system {
+		if m.System == nil {
+			m.System = map[string]interface{}{}
+		}
+		return m.System
+	}
+	if m.Properties == nil {
+		m.Properties = map[string]interface{}{}
+	}
+	return m.Properties
+}
+
+// canonicalSystemProperty normaliza o nome de uma propriedade de sistema; Subject é aceito como Label
+func canonicalSystemProperty(name string) (string, bool) {
+	if strings.EqualFold(name, "Subject") {
+		return "Label", true
+	}
+	for _, known := range systemProperties {
+		if strings.EqualFold(known, name) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// lookupKey procura a chave pelo nome exato e, em seguida, sem diferenciar maiúsculas
+func lookupKey(values map[string]interface{}, name string) (string, bool) {
+	if _, ok := values[name]; ok {
+		return name, true
+	}
+	for key := range values {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// node é um nó da árvore de uma expressão; nil representa NULL (desconhecido)
+type node interface {
+	eval(message *Message) interface{}
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n literal) eval(*Message) interface{} {
+	return n.value
+}
+
+type property struct {
+	system bool
+	name   string
+}
+
+func (n property) eval(message *Message) interface{} {
+	values := message.Properties
+	if n.system {
+		values = message.System
+	}
+	key, ok := lookupKey(values, n.name)
+	if !ok {
+		return nil
+	}
+	return normalize(values[key])
+}
+
+type exists struct {
+	target property
+}
+
+func (n exists) eval(message *Message) interface{} {
+	values := message.Properties
+	if n.target.system {
+		values = message.System
+	}
+	_, ok := lookupKey(values, n.target.name)
+	return ok
+}
+
+type logical struct {
+	or          bool
+	left, right node
+}
+
+func (n logical) eval(message *Message) interface{} {
+	left, leftKnown := n.left.eval(message).(bool)
+	right, rightKnown := n.right.eval(message).(bool)
+
+	if n.or {
+		if (leftKnown && left) || (rightKnown && right) {
+			return true
+		}
+		if leftKnown && rightKnown {
+			return false
+		}
+		return nil
+	}
+
+	if (leftKnown && !left) || (rightKnown && !right) {
+		return false
+	}
+	if leftKnown && rightKnown {
+		return true
+	}
+	return nil
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) eval(message *Message) interface{} {
+	value, ok := n.operand.eval(message).(bool)
+	if !ok {
+		return nil
+	}
+	return !value
+}
+
+type isNull struct {
+	operand node
+	negate  bool
+}
+
+func (n isNull) eval(message *Message) interface{} {
+	return (n.operand.eval(message) == nil) != n.negate
+}
+
+type like struct {
+	operand node
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+func (n like) eval(message *Message) interface{} {
+	value, ok := n.operand.eval(message).(string)
+	if !ok {
+		return nil
+	}
+	return n.pattern.MatchString(value) != n.negate
+}
+
+type in struct {
+	operand node
+	values  []node
+	negate  bool
+}
+
+func (n in) eval(message *Message) interface{} {
+	value := n.operand.eval(message)
+	if value == nil {
+		return nil
+	}
+	for _, candidate := range n.values {
+		if equal, ok := compare("=", value, candidate.eval(message)).(bool); ok && equal {
+			return !n.negate
+		}
+	}
+	return n.negate
+}
+
+type comparison struct {
+	operator    string
+	left, right node
+}
+
+func (n comparison) eval(message *Message) interface{} {
+	return compare(n.operator, n.left.eval(message), n.right.eval(message))
+}
+
+type arithmetic struct {
+	operator    string
+	left, right node
+}
+
+func (n arithmetic) eval(message *Message) interface{} {
+	left, right := n.left.eval(message), n.right.eval(message)
+
+	// + também concatena textos
+	if leftText, ok := left.(string); ok && n.operator == "+" {
+		if rightText, ok := right.(string); ok {
+			return leftText + rightText
+		}
+		return nil
+	}
+
+	leftInt, leftIsInt := left.(int64)
+	rightInt, rightIsInt := right.(int64)
+	if leftIsInt && rightIsInt {
+		switch n.operator {
+		case "+":
+			return leftInt + rightInt
+		case "-":
+			return leftInt - rightInt
+		case "*":
+			return leftInt * rightInt
+		case "/":
+			if rightInt == 0 {
+				return nil
+			}
+			return leftInt / rightInt
+		case "%":
+			if rightInt == 0 {
+				return nil
+			}
+			return leftInt % rightInt
+		}
+	}
+
+	leftNumber, leftOk := toFloat(left)
+	rightNumber, rightOk := toFloat(right)
+	if !leftOk || !rightOk {
+		return nil
+	}
+	switch n.operator {
+	case "+":
+		return leftNumber + rightNumber
+	case "-":
+		return leftNumber - rightNumber
+	case "*":
+		return leftNumber * rightNumber
+	case "/":
+		if rightNumber == 0 {
+			return nil
+		}
+		return leftNumber / rightNumber
+	case "%":
+		if rightNumber == 0 {
+			return nil
+		}
+		return math.Mod(leftNumber, rightNumber)
+	}
+	return nil
+}
+
+// compare aplica um operador de comparação; tipos incompatíveis resultam em NULL
+func compare(operator string, left, right interface{}) interface{} {
+	if left == nil || right == nil {
+		return nil
+	}
+
+	var order int
+	switch leftValue := left.(type) {
+	case string:
+		rightValue, ok := right.(string)
+		if !ok {
+			return nil
+		}
+		order = strings.Compare(leftValue, rightValue)
+	case bool:
+		rightValue, ok := right.(bool)
+		if !ok || (operator != "=" && operator != "<>" && operator != "!=") {
+			return nil
+		}
+		if leftValue != rightValue {
+			order = 1
+		}
+	default:
+		leftNumber, leftOk := toFloat(left)
+		rightNumber, rightOk := toFloat(right)
+		if !leftOk || !rightOk {
+			return nil
+		}
+		switch {
+		case leftNumber < rightNumber:
+			order = -1
+		case leftNumber > rightNumber:
+			order = 1
+		}
+	}
+
+	switch operator {
+	case "=":
+		return order == 0
+	case "<>", "!=":
+		return order != 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	}
+	return nil
+}
+
+// normalize converte os tipos numéricos de Go para int64 ou float64
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// toFloat converte valores numéricos para float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,146 @@
+package sqlfilter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind classifica os tokens da gramática SQL dos filtros do Service Bus
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenKeyword
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// token é um elemento léxico com a posição (1-based) em que começa na expressão
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+// keywords são as palavras reservadas reconhecidas, sem diferenciar maiúsculas
+var keywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IS": true, "NULL": true, "LIKE": true, "ESCAPE": true,
+	"IN": true, "EXISTS": true, "TRUE": true, "FALSE": true, "SET": true, "REMOVE": true,
+}
+
+// operators são os operadores e pontuações aceitos
+var operators = map[string]bool{
+	"=": true, "<>": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
+	"+": true, "-": true, "*": true, "/": true, "%": true, "(": true, ")": true, ",": true, ";": true,
+}
+
+// tokenize separa a expressão em tokens
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '\'':
+			var value strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("posição %d: texto sem aspas de fechamento", start)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[start-1 : i]), value: value.String(), pos: start})
+
+		case r == '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("posição %d: identificador sem ']' de fechamento", start)
+			}
+			name := string(runes[i+1 : end])
+			i = end + 1
+			// Permite o prefixo sys. ou user. antes do identificador entre colchetes
+			if n := len(tokens); n > 0 && tokens[n-1].kind == tokenIdentifier && strings.HasSuffix(tokens[n-1].value, ".") {
+				tokens[n-1].value += name
+				tokens[n-1].text += "[" + name + "]"
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: "[" + name + "]", value: name, pos: start})
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			if end < len(runes) && (runes[end] == 'e' || runes[end] == 'E') {
+				end++
+				if end < len(runes) && (runes[end] == '+' || runes[end] == '-') {
+					end++
+				}
+				for end < len(runes) && unicode.IsDigit(runes[end]) {
+					end++
+				}
+			}
+			text := string(runes[i:end])
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: text, pos: start})
+			i = end
+
+		case unicode.IsLetter(r) || r == '_' || r == '$' || r == '@':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_$@.", runes[end])) {
+				end++
+			}
+			text := string(runes[i:end])
+			kind := tokenIdentifier
+			value := text
+			if keywords[strings.ToUpper(text)] {
+				kind = tokenKeyword
+				value = strings.ToUpper(text)
+			}
+			tokens = append(tokens, token{kind: kind, text: text, value: value, pos: start})
+			i = end
+
+		default:
+			operator := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "<>", "!=", ">=", "<=":
+					operator = two
+				}
+			}
+			if !operators[operator] {
+				return nil, fmt.Errorf("posição %d: caractere inesperado '%c'", start, r)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, value: operator, pos: start})
+			i += len([]rune(operator))
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
@@ -0,0 +1,475 @@
+package sqlfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Filter é uma expressão de filtro SQL já interpretada
+type Filter struct {
+	expression string
+	root       node
+}
+
+// Action é uma ação SQL (SET e REMOVE) já interpretada
+type Action struct {
+	expression string
+	statements []statement
+}
+
+// statement é um comando de uma ação: SET propriedade = expressão ou REMOVE propriedade
+type statement struct {
+	remove bool
+	target property
+	value  node
+}
+
+// parser percorre os tokens de uma expressão
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse interpreta uma expressão de filtro SQL do Service Bus (ex.: "sys.Label = 'pix' AND valor > 100")
+func Parse(expression string) (*Filter, error) {
+	p, err := newParser(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("filtro SQL inválido: %w", err)
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("filtro SQL inválido: posição %d: '%s' inesperado", next.pos, next.text)
+	}
+
+	return &Filter{expression: expression, root: root}, nil
+}
+
+// ParseAction interpreta uma ação SQL do Service Bus (ex.: "SET prioridade = 'alta'; REMOVE temporario")
+func ParseAction(expression string) (*Action, error) {
+	p, err := newParser(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &Action{expression: expression}
+	for p.peek().kind != tokenEOF {
+		statement, err := p.parseStatement()
+		if err != nil {
+			return nil, fmt.Errorf("ação SQL inválida: %w", err)
+		}
+		action.statements = append(action.statements, statement)
+
+		if next := p.peek(); next.kind != tokenEOF && !p.acceptOperator(";") {
+			return nil, fmt.Errorf("ação SQL inválida: posição %d: esperado ';' antes de '%s'", next.pos, next.text)
+		}
+	}
+
+	if len(action.statements) == 0 {
+		return nil, fmt.Errorf("ação SQL vazia")
+	}
+	return action, nil
+}
+
+// String retorna a expressão original do filtro
+func (f *Filter) String() string {
+	return f.expression
+}
+
+// String retorna a expressão original da ação
+func (a *Action) String() string {
+	return a.expression
+}
+
+func newParser(expression string) (*parser, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("expressão SQL vazia")
+	}
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, fmt.Errorf("expressão SQL inválida: %w", err)
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// acceptKeyword consome a palavra reservada, se ela for o próximo token
+func (p *parser) acceptKeyword(keyword string) bool {
+	if t := p.peek(); t.kind == tokenKeyword && t.value == keyword {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// acceptOperator consome o operador, se ele for o próximo token
+func (p *parser) acceptOperator(operator string) bool {
+	if t := p.peek(); t.kind == tokenOperator && t.value == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expectOperator consome o operador ou retorna erro
+func (p *parser) expectOperator(operator string) error {
+	if !p.acceptOperator(operator) {
+		t := p.peek()
+		return fmt.Errorf("posição %d: esperado '%s', encontrado '%s'", t.pos, operator, describe(t))
+	}
+	return nil
+}
+
+func (p *parser) parseStatement() (statement, error) {
+	switch {
+	case p.acceptKeyword("SET"):
+		target, err := p.parseProperty()
+		if err != nil {
+			return statement{}, err
+		}
+		if err := p.expectOperator("="); err != nil {
+			return statement{}, err
+		}
+		value, err := p.parseAdditive()
+		if err != nil {
+			return statement{}, err
+		}
+		return statement{target: target, value: value}, nil
+
+	case p.acceptKeyword("REMOVE"):
+		target, err := p.parseProperty()
+		if err != nil {
+			return statement{}, err
+		}
+		return statement{remove: true, target: target}, nil
+
+	default:
+		t := p.peek()
+		return statement{}, fmt.Errorf("posição %d: esperado SET ou REMOVE, encontrado '%s'", t.pos, describe(t))
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.acceptKeyword("NOT") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not{operand: operand}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (node, error) {
+	if p.acceptKeyword("EXISTS") {
+		if err := p.expectOperator("("); err != nil {
+			return nil, err
+		}
+		target, err := p.parseProperty()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return exists{target: target}, nil
+	}
+
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == tokenOperator {
+		switch t.value {
+		case "=", "<>", "!=", ">", ">=", "<", "<=":
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return comparison{operator: t.value, left: left, right: right}, nil
+		}
+	}
+
+	if p.acceptKeyword("IS") {
+		negate := p.acceptKeyword("NOT")
+		if !p.acceptKeyword("NULL") {
+			t := p.peek()
+			return nil, fmt.Errorf("posição %d: esperado NULL, encontrado '%s'", t.pos, describe(t))
+		}
+		return isNull{operand: left, negate: negate}, nil
+	}
+
+	negate := false
+	if t := p.peek(); t.kind == tokenKeyword && t.value == "NOT" {
+		if after := p.tokens[p.pos+1]; after.kind == tokenKeyword && (after.value == "LIKE" || after.value == "IN") {
+			p.next()
+			negate = true
+		}
+	}
+
+	switch {
+	case p.acceptKeyword("LIKE"):
+		return p.parseLike(left, negate)
+	case p.acceptKeyword("IN"):
+		return p.parseIn(left, negate)
+	}
+	return left, nil
+}
+
+func (p *parser) parseLike(operand node, negate bool) (node, error) {
+	pattern := p.next()
+	if pattern.kind != tokenString {
+		return nil, fmt.Errorf("posição %d: LIKE exige um texto entre aspas", pattern.pos)
+	}
+
+	escape := ""
+	if p.acceptKeyword("ESCAPE") {
+		t := p.next()
+		if t.kind != tokenString || len([]rune(t.value)) != 1 {
+			return nil, fmt.Errorf("posição %d: ESCAPE exige um único caractere entre aspas", t.pos)
+		}
+		escape = t.value
+	}
+
+	re, err := likeToRegexp(pattern.value, escape)
+	if err != nil {
+		return nil, fmt.Errorf("posição %d: %w", pattern.pos, err)
+	}
+	return like{operand: operand, pattern: re, negate: negate}, nil
+}
+
+func (p *parser) parseIn(operand node, negate bool) (node, error) {
+	if err := p.expectOperator("("); err != nil {
+		return nil, err
+	}
+
+	var values []node
+	for {
+		value, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if !p.acceptOperator(",") {
+			break
+		}
+	}
+
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return in{operand: operand, values: values, negate: negate}, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenOperator || (t.value != "+" && t.value != "-") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmetic{operator: t.value, left: left, right: right}
+	}
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenOperator || (t.value != "*" && t.value != "/" && t.value != "%") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmetic{operator: t.value, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.acceptOperator("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic{operator: "-", left: literal{value: int64(0)}, right: operand}, nil
+	}
+	if p.acceptOperator("+") {
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenOperator && t.value == "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	case t.kind == tokenString:
+		p.next()
+		return literal{value: t.value}, nil
+
+	case t.kind == tokenNumber:
+		p.next()
+		if !strings.ContainsAny(t.value, ".eE") {
+			if value, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+				return literal{value: value}, nil
+			}
+		}
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("posição %d: número inválido '%s'", t.pos, t.text)
+		}
+		return literal{value: value}, nil
+
+	case t.kind == tokenKeyword && (t.value == "TRUE" || t.value == "FALSE"):
+		p.next()
+		return literal{value: t.value == "TRUE"}, nil
+
+	case t.kind == tokenKeyword && t.value == "NULL":
+		p.next()
+		return literal{value: nil}, nil
+
+	case t.kind == tokenIdentifier:
+		return p.parseProperty()
+
+	default:
+		return nil, fmt.Errorf("posição %d: esperado valor ou propriedade, encontrado '%s'", t.pos, describe(t))
+	}
+}
+
+// parseProperty interpreta sys.Nome, user.Nome ou Nome (propriedade de aplicação)
+func (p *parser) parseProperty() (property, error) {
+	t := p.next()
+	if t.kind != tokenIdentifier {
+		return property{}, fmt.Errorf("posição %d: esperado nome de propriedade, encontrado '%s'", t.pos, describe(t))
+	}
+
+	name := t.value
+	scope := ""
+	if index := strings.Index(name, "."); index > 0 {
+		switch prefix := strings.ToLower(name[:index]); prefix {
+		case "sys", "user":
+			scope = prefix
+			name = name[index+1:]
+		}
+	}
+	if name == "" {
+		return property{}, fmt.Errorf("posição %d: nome de propriedade vazio", t.pos)
+	}
+
+	if scope == "sys" {
+		canonical, ok := canonicalSystemProperty(name)
+		if !ok {
+			return property{}, fmt.Errorf("posição %d: propriedade de sistema desconhecida 'sys.%s'", t.pos, name)
+		}
+		return property{system: true, name: canonical}, nil
+	}
+	return property{name: name}, nil
+}
+
+// likeToRegexp converte um padrão LIKE (% e _) em expressão regular ancorada
+func likeToRegexp(pattern, escape string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case escape != "" && string(r) == escape:
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("padrão LIKE termina com o caractere de escape")
+			}
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '%':
+			builder.WriteString(".*")
+		case r == '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	builder.WriteString("$")
+	return regexp.Compile("(?s)" + builder.String())
+}
+
+// describe descreve um token nas mensagens de erro
+func describe(t token) string {
+	if t.kind == tokenEOF {
+		return "fim da expressão"
+	}
+	return t.text
+}
@@ -0,0 +1,257 @@
+// Package traffic grava o tráfego de filas e subscriptions em NDJSON com horários relativos
+// e o reproduz mantendo a ordem e o espaçamento entre as mensagens.
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+)
+
+// Record é uma linha da gravação
+//
+// Exemplo:
+//
+//	{"offsetMs": 1532, "entity": "sbt.orion.core/subscription.orion.core", "message": {"body": {...}, "messageId": "..."}}
+type Record struct {
+	// OffsetMs é o horário de enfileiramento relativo ao início da gravação, em milissegundos
+	OffsetMs int64 `json:"offsetMs"`
+	// Entity é a fila ou tópico/subscription onde a mensagem foi vista
+	Entity string `json:"entity"`
+	// Message é a mensagem completa, no formato do export
+	Message *servicebus.Message `json:"message"`
+}
+
+// Offset retorna OffsetMs como duração
+func (r Record) Offset() time.Duration {
+	return time.Duration(r.OffsetMs) * time.Millisecond
+}
+
+// Recorder grava as mensagens como NDJSON, uma linha por mensagem, logo que são vistas
+type Recorder struct {
+	mu      sync.Mutex
+	w       io.Writer
+	started time.Time
+	seen    map[string]bool
+	count   int
+}
+
+// NewRecorder cria um Recorder cujos horários são relativos a started
+func NewRecorder(w io.Writer, started time.Time) *Recorder {
+	return &Recorder{w: w, started: started, seen: make(map[string]bool)}
+}
+
+// Add grava a mensagem vista na entidade. Cópias da mesma mensagem em várias subscriptions de um
+// tópico são gravadas uma única vez, já que o replay envia ao tópico; nesse caso o Record retornado é nil.
+func (r *Recorder) Add(entity servicebus.Entity, message *servicebus.Message) (*Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := entity.SendTarget() + "#" + message.MessageID
+	if message.SequenceNumber > 0 {
+		key = entity.SendTarget() + "#" + strconv.FormatInt(message.SequenceNumber, 10)
+	}
+	if r.seen[key] {
+		return nil, nil
+	}
+	r.seen[key] = true
+
+	at := time.Now()
+	if message.EnqueuedTimeUtc != nil {
+		at = *message.EnqueuedTimeUtc
+	}
+	record := &Record{OffsetMs: at.Sub(r.started).Milliseconds(), Entity: entity.String(), Message: message}
+
+	// A mensagem usa o formato do export, que preserva os bytes do body
+	encoded, err := servicebus.EncodeMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(struct {
+		OffsetMs int64           `json:"offsetMs"`
+		Entity   string          `json:"entity"`
+		Message  json.RawMessage `json:"message"`
+	}{record.OffsetMs, record.Entity, encoded})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar mensagem: %w", err)
+	}
+	if _, err := r.w.Write(line.Bytes()); err != nil {
+		return nil, fmt.Errorf("erro ao gravar mensagem: %w", err)
+	}
+	r.count++
+	return record, nil
+}
+
+// Count retorna o número de mensagens gravadas
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// ReadRecords lê uma gravação e ordena as mensagens pelo horário relativo, preservando a ordem
+// do arquivo entre mensagens do mesmo instante
+func ReadRecords(reader io.Reader) ([]Record, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var records []Record
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var raw struct {
+			OffsetMs *int64          `json:"offsetMs"`
+			Entity   string          `json:"entity"`
+			Message  json.RawMessage `json:"message"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+		if raw.OffsetMs == nil || raw.Entity == "" || len(raw.Message) == 0 {
+			return nil, fmt.Errorf("linha %d: campos offsetMs, entity e message são obrigatórios", line)
+		}
+		if _, err := servicebus.ParseEntity(raw.Entity); err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+		message, err := servicebus.DecodeMessage(raw.Message)
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+		records = append(records, Record{OffsetMs: *raw.OffsetMs, Entity: raw.Entity, Message: message})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler gravação: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].OffsetMs < records[j].OffsetMs })
+	return records, nil
+}
+
+// ParseSpeed interpreta a velocidade do replay: "2x", "0.5x", "1" ou "max" (sem esperas, retorna 0)
+func ParseSpeed(text string) (float64, error) {
+	text = strings.TrimSpace(strings.ToLower(text))
+	if text == "max" {
+		return 0, nil
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(text, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("velocidade inválida '%s' (ex: 1x, 2x, 0.5x, max)", text)
+	}
+	return speed, nil
+}
+
+// Sender envia as mensagens do replay
+type Sender interface {
+	Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error
+}
+
+// ReplayOptions controla o replay
+type ReplayOptions struct {
+	// Speed divide os intervalos entre as mensagens; 0 envia sem esperas
+	Speed float64
+	// Map troca o destino: a chave é a entidade gravada (fila ou tópico/subscription) ou o tópico,
+	// e o valor é a fila ou tópico de destino
+	Map map[string]string
+	// NewIDs gera MessageIDs novos, para destinos com detecção de duplicadas ou consumidores idempotentes
+	NewIDs bool
+	// OnSend é chamado depois de cada envio
+	OnSend func(index int, record Record, target string, err error)
+}
+
+// Target retorna o destino do envio de uma mensagem gravada
+func (o ReplayOptions) Target(record Record) (string, error) {
+	if target, ok := o.Map[record.Entity]; ok {
+		return target, nil
+	}
+	entity, err := servicebus.ParseEntity(record.Entity)
+	if err != nil {
+		return "", err
+	}
+	if target, ok := o.Map[entity.SendTarget()]; ok {
+		return target, nil
+	}
+	return entity.SendTarget(), nil
+}
+
+// ReplayResult resume o replay
+type ReplayResult struct {
+	Sent     int
+	Failed   int
+	Duration time.Duration
+	// MaxLag é o maior atraso de um envio em relação ao horário previsto
+	MaxLag time.Duration
+}
+
+// Replay reenvia as mensagens em ordem, respeitando o espaçamento gravado dividido pela velocidade.
+// A primeira mensagem é enviada imediatamente. O cancelamento de ctx interrompe o replay.
+func Replay(ctx context.Context, sender Sender, records []Record, options ReplayOptions) (ReplayResult, error) {
+	var result ReplayResult
+	if len(records) == 0 {
+		return result, nil
+	}
+
+	started := time.Now()
+	base := records[0].Offset()
+	for i, record := range records {
+		target, err := options.Target(record)
+		if err != nil {
+			return result, err
+		}
+
+		due := started
+		if options.Speed > 0 {
+			due = started.Add(time.Duration(float64(record.Offset()-base) / options.Speed))
+		}
+		if wait := time.Until(due); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				result.Duration = time.Since(started)
+				return result, ctx.Err()
+			}
+		}
+		if lag := time.Since(due); lag > result.MaxLag {
+			result.MaxLag = lag
+		}
+
+		message := *record.Message
+		message.PrepareForImport(time.Now())
+		if options.NewIDs {
+			message.MessageID = fmt.Sprintf("replay-%d-%d", started.Unix(), i)
+		}
+
+		err = sender.Send(ctx, target, &message)
+		if err != nil {
+			result.Failed++
+		} else {
+			result.Sent++
+		}
+		if options.OnSend != nil {
+			options.OnSend(i, record, target, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	result.Duration = time.Since(started)
+	return result, ctx.Err()
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 )
@@ -16,15 +18,30 @@ func ValidateJSONFile(filePath string) error {
 	return ValidateJSON(data)
 }
 
-// ValidateJSON valida se os dados contêm JSON válido
+// ValidateJSON valida se os dados contêm JSON válido. Erros de sintaxe informam linha e coluna.
 func ValidateJSON(data []byte) error {
 	var v interface{}
 	if err := json.Unmarshal(data, &v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// Offset conta os bytes lidos até o caractere inválido, inclusive
+			line, column := textPosition(data, int(syntaxErr.Offset)-1)
+			return fmt.Errorf("json inválido (linha %d, coluna %d): %w", line, column, err)
+		}
 		return fmt.Errorf("json inválido: %w", err)
 	}
 	return nil
 }
 
+// textPosition converte um offset em linha e coluna, ambas a partir de 1
+func textPosition(data []byte, offset int) (int, int) {
+	offset = max(0, min(offset, len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
 // FormatJSON formata JSON com indentação
 func FormatJSON(data []byte) ([]byte, error) {
 	var v interface{}
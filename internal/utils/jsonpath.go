@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment representa um passo de um caminho JSON: chave de objeto ou índice de array
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath interpreta caminhos em notação de ponto, como "data.items[0].id" ou "$.data.id"
+func parseJSONPath(path string) ([]pathSegment, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return nil, nil
+	}
+
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("caminho JSON inválido: %s", path)
+		}
+
+		key := part
+		rest := ""
+		if open := strings.Index(part, "["); open >= 0 {
+			key = part[:open]
+			rest = part[open:]
+		}
+		if key != "" {
+			segments = append(segments, pathSegment{key: key})
+		}
+
+		for rest != "" {
+			closeIdx := strings.Index(rest, "]")
+			if !strings.HasPrefix(rest, "[") || closeIdx < 0 {
+				return nil, fmt.Errorf("caminho JSON inválido: %s", path)
+			}
+			index, err := strconv.Atoi(rest[1:closeIdx])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("índice inválido em %s", path)
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			rest = rest[closeIdx+1:]
+		}
+	}
+
+	return segments, nil
+}
+
+// LookupJSONPath busca um valor em um documento JSON decodificado usando notação de ponto
+// (ex: "data.items[0].id" ou "$.data.id"). Retorna false se o caminho não existir.
+func LookupJSONPath(document interface{}, path string) (interface{}, bool) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	current := document
+	for _, segment := range segments {
+		if segment.isIndex {
+			items, ok := current.([]interface{})
+			if !ok || segment.index >= len(items) {
+				return nil, false
+			}
+			current = items[segment.index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[segment.key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// JSONValueString converte um valor JSON decodificado em texto para comparações e exibição
+func JSONValueString(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// SetJSONPath altera o valor de um caminho em notação de ponto, criando os objetos intermediários
+// que não existirem. Índices de array precisam existir. Retorna o documento alterado.
+func SetJSONPath(document interface{}, path string, value interface{}) (interface{}, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("caminho JSON vazio")
+	}
+	return setSegments(document, segments, value, path)
+}
+
+// setSegments aplica o valor no caminho restante e retorna o nó atualizado
+func setSegments(current interface{}, segments []pathSegment, value interface{}, path string) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	segment := segments[0]
+
+	if segment.isIndex {
+		items, ok := current.([]interface{})
+		if !ok || segment.index >= len(items) {
+			return nil, fmt.Errorf("índice %d não existe em %s", segment.index, path)
+		}
+		updated, err := setSegments(items[segment.index], segments[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+		items[segment.index] = updated
+		return items, nil
+	}
+
+	object, ok := current.(map[string]interface{})
+	if current == nil {
+		object, ok = make(map[string]interface{}), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("'%s' não é um objeto em %s", segment.key, path)
+	}
+	updated, err := setSegments(object[segment.key], segments[1:], value, path)
+	if err != nil {
+		return nil, err
+	}
+	object[segment.key] = updated
+	return object, nil
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fin.orion.dev/internal/catalog"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadCatalog testa a leitura dos tipos de mensagem de uma pasta
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pix.yaml"), []byte(`
+description: PIX recebido
+targets: [sbq.pismo.pix.transaction.in, "sbq.pix.*"]
+subject: pix.in
+properties: {eventType: pix-in}
+vars: {amount: "10"}
+template: '{"amount": {{.amount}}}'
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "from-file.yml"), []byte(`
+name: arquivo
+templateFile: transacao.json
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignorado"), 0644))
+
+	cat, err := catalog.Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arquivo", "pix", "simple"}, catalog.Names(cat.All()))
+
+	pix, ok := cat.Get("pix")
+	require.True(t, ok)
+	assert.Equal(t, "pix.in", pix.Subject)
+	assert.Equal(t, filepath.Join(dir, "pix.yaml"), pix.Path)
+	assert.True(t, pix.Supports("sbq.pismo.pix.transaction.in"))
+	assert.True(t, pix.Supports("sbq.pix.recurrence.payment.order.failure"))
+	assert.False(t, pix.Supports("sbq.pismo.all"))
+
+	assert.Equal(t, []string{"arquivo", "simple"}, catalog.Names(cat.ForTarget("sbq.pismo.all")))
+	assert.Equal(t, []string{"arquivo", "pix", "simple"}, catalog.Names(cat.ForTarget("sbq.pix.outra")))
+
+	name, data, err := pix.Source("messages")
+	require.NoError(t, err)
+	assert.Equal(t, pix.Path, name)
+	assert.Equal(t, `{"amount": {{.amount}}}`, string(data))
+
+	arquivo, _ := cat.Get("arquivo")
+	_, _, err = arquivo.Source(dir)
+	assert.ErrorContains(t, err, "template não encontrado")
+}
+
+// TestCatalogWithoutDir testa o catálogo sem pasta, apenas com o tipo embutido
+func TestCatalogWithoutDir(t *testing.T) {
+	cat, err := catalog.Load(filepath.Join(t.TempDir(), "inexistente"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"simple"}, catalog.Names(cat.All()))
+	assert.Len(t, cat.ForTarget("qualquer.fila"), 1)
+}
+
+// TestCatalogValidation testa a validação dos tipos de mensagem
+func TestCatalogValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"sem template", "targets: [a]", "template"},
+		{"dois templates", "template: '{}'\ntemplateFile: a.json", "template"},
+		{"campo desconhecido", "template: '{}'\ntarget: a", "target"},
+		{"nome com espaço", "name: meu tipo\ntemplate: '{}'", "nome"},
+		{"padrão inválido", "template: '{}'\ntargets: ['sbq.[']", "destino"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := catalog.Parse([]byte(tt.yaml), "tipo")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	first, err := catalog.Parse([]byte("template: '{}'"), "tipo")
+	require.NoError(t, err)
+	second, err := catalog.Parse([]byte("template: '{}'"), "tipo")
+	require.NoError(t, err)
+	_, err = catalog.New(first, second)
+	assert.ErrorContains(t, err, "tipo 'tipo' declarado")
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fin.orion.dev/internal/commands"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCLI executa o CLI com os argumentos informados, a partir da raiz do repositório
+func runCLI(t *testing.T, args ...string) error {
+	t.Helper()
+	previous := os.Args
+	os.Args = append([]string{"orion-dev"}, args...)
+	defer func() { os.Args = previous }()
+	return commands.Execute()
+}
+
+// TestSettleFlags testa que --peek=false pode ser combinado com um modo de liquidação: a
+// validação é de servicebus.ParseSettleMode, e não do grupo de flags exclusivas do cobra
+func TestSettleFlags(t *testing.T) {
+	t.Chdir("..")
+
+	err := runCLI(t, "check-queue", "fila-inexistente", "--peek=false", "--complete")
+	assert.EqualError(t, err, "fila inválida")
+
+	err = runCLI(t, "tail", "sbq.pismo.all", "--peek=false")
+	assert.ErrorContains(t, err, "--peek=false exige")
+
+	err = runCLI(t, "tail", "sbq.pismo.all", "--peek=false", "--abandon", "--output", "nenhum")
+	assert.ErrorContains(t, err, "--output inválido")
+}
+
+// TestPushBatchSchemaCheck testa que o push-batch valida cada mensagem do lote contra o schema
+// do destino antes de conectar ao Service Bus
+func TestPushBatchSchemaCheck(t *testing.T) {
+	dir := t.TempDir()
+	valid := `{"type": "pismo.transaction.creation", "timestamp": "2025-01-31T12:00:00Z", "data": {"transactionId": "t-{{.n}}", "amount": 10, "currency": "BRL", "status": "created", "accountId": "a-1"}}`
+	lines := valid + "\n" + `{"type": "pismo.transaction.creation", "timestamp": "2025-01-31T12:00:00Z", "data": {"transactionId": "t-2"}}` + "\n"
+	path := filepath.Join(dir, "lote.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(lines), 0644))
+
+	t.Chdir("..")
+	err := runCLI(t, "push-batch", "sbq.pismo.transaction.creation", path, "--var", "n=1", "--schema-check", "strict")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "não atende ao schema")
+	assert.Contains(t, err.Error(), "4 violação(ões)")
+}
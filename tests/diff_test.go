@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"fin.orion.dev/internal/diff"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeDocument decodifica JSON com números como json.Number, como o golden
+func decodeDocument(t *testing.T, text string) interface{} {
+	t.Helper()
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(text), &value))
+	document, err := diff.Normalize(value)
+	require.NoError(t, err)
+	return document
+}
+
+// TestDiffCompare testa diferenças alteradas, ausentes e adicionadas em objetos e arrays
+func TestDiffCompare(t *testing.T) {
+	comparer, err := diff.New(diff.Options{})
+	require.NoError(t, err)
+
+	expected := decodeDocument(t, `{"body": {"status": "created", "amount": 10, "items": [{"id": 1}, {"id": 2}], "old": true}}`)
+	actual := decodeDocument(t, `{"body": {"status": "failed", "amount": 10.0, "items": [{"id": 1}], "new": null}}`)
+
+	var descriptions []string
+	for _, difference := range comparer.Compare(expected, actual) {
+		descriptions = append(descriptions, difference.String())
+	}
+	assert.Equal(t, []string{
+		"body.items[1]: ausente, esperado {\"id\":2}",
+		"body.new: adicionado null",
+		"body.old: ausente, esperado true",
+		"body.status: esperado \"created\", obtido \"failed\"",
+	}, descriptions)
+
+	assert.Empty(t, comparer.Compare(expected, expected))
+	differences := comparer.Compare(decodeDocument(t, `{"a": {"b": 1}}`), decodeDocument(t, `{"a": [1]}`))
+	require.Len(t, differences, 1)
+	assert.Equal(t, diff.Changed, differences[0].Kind)
+	assert.Equal(t, "a", differences[0].Path)
+}
+
+// TestDiffRules testa caminhos ignorados, expressões regulares e tolerâncias, inclusive com curingas
+func TestDiffRules(t *testing.T) {
+	comparer, err := diff.New(diff.Options{
+		Ignore:    []string{"body.createdAt", "body.items[*].traceId", "properties.*"},
+		Match:     map[string]string{"body.id": "^[0-9a-f-]{36}$", "body.items[*].ref": "^ref-"},
+		Tolerance: map[string]float64{"$.body.amount": 0.01},
+	})
+	require.NoError(t, err)
+
+	expected := decodeDocument(t, `{
+		"body": {"id": "x", "createdAt": "2025-01-01", "amount": 150.5, "items": [{"ref": "ref-1", "traceId": "a"}]},
+		"properties": {"traceId": "a"}
+	}`)
+	actual := decodeDocument(t, `{
+		"body": {"id": "0b8f3c52-3f0e-4d5c-9a55-5c1f5b2d7c11", "createdAt": "2026-10-17", "amount": 150.509, "items": [{"ref": "ref-99", "traceId": "b", "extra": 1}]},
+		"properties": {"traceId": "b", "novo": 1}
+	}`)
+
+	differences := comparer.Compare(expected, actual)
+	require.Len(t, differences, 1)
+	assert.Equal(t, "body.items[0].extra", differences[0].Path)
+	assert.Equal(t, diff.Added, differences[0].Kind)
+
+	actual = decodeDocument(t, `{"body": {"id": "nao-e-uuid", "amount": 150.6, "items": [{"ref": "x"}]}, "properties": {}}`)
+	var paths []string
+	for _, difference := range comparer.Compare(expected, actual) {
+		paths = append(paths, difference.Path)
+		assert.NotEqual(t, "body.createdAt", difference.Path)
+	}
+	assert.Equal(t, []string{"body.amount", "body.id", "body.items[0].ref"}, paths)
+
+	for _, options := range []diff.Options{
+		{Ignore: []string{""}},
+		{Ignore: []string{"body..id"}},
+		{Ignore: []string{"body.items[x]"}},
+		{Match: map[string]string{"body.id": "("}},
+		{Tolerance: map[string]float64{"body.amount": -1}},
+	} {
+		_, err := diff.New(options)
+		assert.Error(t, err)
+	}
+}
+
+// TestDiffWrite testa o diff estrutural mostrado ao usuário
+func TestDiffWrite(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	comparer, err := diff.New(diff.Options{Ignore: []string{"body.createdAt"}, Match: map[string]string{"body.id": "^t-"}})
+	require.NoError(t, err)
+
+	expected := decodeDocument(t, `{"body": {"id": "t-1", "createdAt": "2025", "status": "created", "tags": ["a", "b"]}}`)
+	actual := decodeDocument(t, `{"body": {"id": "t-2", "createdAt": "2026", "status": "failed", "tags": ["a", "b"]}}`)
+
+	var out bytes.Buffer
+	comparer.Write(&out, expected, actual)
+	assert.Equal(t, strings.Join([]string{
+		`  {`,
+		`    "body": {`,
+		`      "createdAt": "2026"  # ignorado`,
+		`      "id": "t-2"  # /^t-/`,
+		`-     "status": "created"`,
+		`+     "status": "failed"`,
+		`      "tags": ["a","b"]`,
+		`    }`,
+		`  }`,
+		``,
+	}, "\n"), out.String())
+}
+
+// TestGolden testa a leitura, a comparação com a mensagem e a atualização do golden
+func TestGolden(t *testing.T) {
+	golden, err := diff.ReadGolden(strings.NewReader(`{
+		"body": {"id": "t-1", "amount": 10},
+		"ignore": ["body.id"]
+	}`))
+	require.NoError(t, err)
+
+	expected, err := golden.Expected()
+	require.NoError(t, err)
+	message := &servicebus.Message{
+		Body:       map[string]interface{}{"id": "t-2", "amount": 10.0},
+		Properties: map[string]interface{}{"eventType": "transaction-created"},
+	}
+
+	// Sem a seção properties no golden, as propriedades não são comparadas
+	actual, err := golden.Actual(message)
+	require.NoError(t, err)
+	comparer, err := diff.New(golden.Options())
+	require.NoError(t, err)
+	assert.Empty(t, comparer.Compare(expected, actual))
+
+	require.NoError(t, golden.Update(message))
+	var out bytes.Buffer
+	require.NoError(t, diff.WriteGolden(&out, golden))
+
+	updated, err := diff.ReadGolden(&out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"body.id"}, updated.Ignore)
+	assert.JSONEq(t, `{"id": "t-2", "amount": 10}`, string(updated.Body))
+	assert.JSONEq(t, `{"eventType": "transaction-created"}`, string(updated.Properties))
+
+	for _, input := range []string{`{}`, `{"body": 1, "desconhecido": true}`, `nao e json`} {
+		_, err := diff.ReadGolden(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
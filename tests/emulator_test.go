@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/emulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const emulatorConfigPath = "../docker/service-bus/config.json"
+
+// TestEmulatorConfigRoundTrip garante que o modelo tipado preserva o arquivo do emulador
+func TestEmulatorConfigRoundTrip(t *testing.T) {
+	original, err := os.ReadFile(emulatorConfigPath)
+	require.NoError(t, err)
+
+	config, err := emulator.ParseConfig(original)
+	require.NoError(t, err)
+
+	data, err := config.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(data))
+}
+
+// TestEmulatorConfigSetRequiresSession testa a alteração de RequiresSession
+func TestEmulatorConfigSetRequiresSession(t *testing.T) {
+	config, err := emulator.LoadConfig(emulatorConfigPath)
+	require.NoError(t, err)
+
+	t.Run("fila existente", func(t *testing.T) {
+		require.NoError(t, config.SetQueueRequiresSession("sbq.pismo.all", true))
+		assert.True(t, config.FindQueue("sbq.pismo.all").Properties.RequiresSession)
+		assert.False(t, config.FindQueue("sbq.pismo.ted.transaction").Properties.RequiresSession)
+	})
+
+	t.Run("subscription existente", func(t *testing.T) {
+		require.NoError(t, config.SetSubscriptionRequiresSession("sbt.orion.core", "subscription.orion.core", true))
+		assert.True(t, config.FindSubscription("sbt.orion.core", "subscription.orion.core").Properties.RequiresSession)
+	})
+
+	t.Run("entidades inexistentes", func(t *testing.T) {
+		assert.Error(t, config.SetQueueRequiresSession("test-queue", true))
+		assert.Error(t, config.SetSubscriptionRequiresSession("sbt.orion.core", "inexistente", true))
+	})
+}
+
+// TestEmulatorParseDuration testa a interpretação de durações ISO-8601
+func TestEmulatorParseDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"PT1H":                       time.Hour,
+		"PT20S":                      20 * time.Second,
+		"PT1M":                       time.Minute,
+		"P1DT12H":                    36 * time.Hour,
+		"P1W":                        7 * 24 * time.Hour,
+		"PT0.5S":                     500 * time.Millisecond,
+		"pt1h30m":                    90 * time.Minute,
+		"P10675199DT2H48M5.4775807S": time.Duration(math.MaxInt64),
+	}
+	for input, expected := range valid {
+		duration, err := emulator.ParseDuration(input)
+		require.NoError(t, err, input)
+		assert.InDelta(t, float64(expected), float64(duration), float64(time.Microsecond), input)
+	}
+
+	for _, input := range []string{"", "P", "PT", "1H", "PT1D", "P1H", "P1Y", "P1M", "PTXH", "PT-1H", "1h"} {
+		_, err := emulator.ParseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestEmulatorConfigAddEntities testa a inclusão de filas, tópicos e subscriptions
+func TestEmulatorConfigAddEntities(t *testing.T) {
+	config, err := emulator.LoadConfig(emulatorConfigPath)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+
+	require.NoError(t, config.AddQueue(emulator.Queue{Name: "sbq.test.new", Properties: emulator.DefaultQueueProperties()}))
+	require.NoError(t, config.AddTopic(emulator.Topic{Name: "sbt.test.new", Properties: emulator.DefaultTopicProperties()}))
+	require.NoError(t, config.AddSubscription("sbt.test.new", emulator.Subscription{Name: "sub.test", Properties: emulator.DefaultSubscriptionProperties()}))
+	require.NoError(t, config.Validate())
+
+	assert.NotNil(t, config.FindQueue("sbq.test.new"))
+	assert.NotNil(t, config.FindSubscription("sbt.test.new", "sub.test"))
+
+	t.Run("nomes duplicados ou inválidos", func(t *testing.T) {
+		assert.Error(t, config.AddQueue(emulator.Queue{Name: "sbq.test.new"}))
+		assert.Error(t, config.AddQueue(emulator.Queue{Name: "sbt.test.new"}))
+		assert.Error(t, config.AddTopic(emulator.Topic{Name: "sbq.pismo.all"}))
+		assert.Error(t, config.AddQueue(emulator.Queue{Name: "fila/invalida"}))
+		assert.Error(t, config.AddQueue(emulator.Queue{Name: ".fila"}))
+		assert.Error(t, config.AddSubscription("sbt.test.new", emulator.Subscription{Name: "sub.test"}))
+		assert.Error(t, config.AddSubscription("inexistente", emulator.Subscription{Name: "sub"}))
+	})
+
+	t.Run("remoção", func(t *testing.T) {
+		require.NoError(t, config.RemoveSubscription("sbt.test.new", "sub.test"))
+		require.NoError(t, config.RemoveTopic("sbt.test.new"))
+		require.NoError(t, config.RemoveQueue("sbq.test.new"))
+		assert.Error(t, config.RemoveQueue("sbq.test.new"))
+		assert.Nil(t, config.FindTopic("sbt.test.new"))
+	})
+}
+
+// TestEmulatorConfigSetProperty testa a alteração de propriedades pelo nome do arquivo
+func TestEmulatorConfigSetProperty(t *testing.T) {
+	config, err := emulator.LoadConfig(emulatorConfigPath)
+	require.NoError(t, err)
+
+	require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "LockDuration", "PT2M"))
+	require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "maxdeliverycount", "5"))
+	require.NoError(t, config.SetTopicProperty("sbt.orion.core", "RequiresDuplicateDetection", "true"))
+	require.NoError(t, config.SetSubscriptionProperty("sbt.orion.core", "subscription.orion.core", "ForwardTo", "sbq.pismo.all"))
+	require.NoError(t, config.Validate())
+
+	queue := config.FindQueue("sbq.pismo.all")
+	assert.Equal(t, "PT2M", queue.Properties.LockDuration)
+	assert.Equal(t, 5, queue.Properties.MaxDeliveryCount)
+	assert.True(t, config.FindTopic("sbt.orion.core").Properties.RequiresDuplicateDetection)
+
+	assert.Error(t, config.SetQueueProperty("sbq.pismo.all", "Inexistente", "x"))
+	assert.Error(t, config.SetQueueProperty("sbq.pismo.all", "MaxDeliveryCount", "muitos"))
+	assert.Error(t, config.SetQueueProperty("sbq.pismo.all", "RequiresSession", "talvez"))
+	assert.Error(t, config.SetQueueProperty("inexistente", "LockDuration", "PT1M"))
+}
+
+// TestEmulatorConfigValidate testa durações, destinos de ForwardTo e limites do emulador
+func TestEmulatorConfigValidate(t *testing.T) {
+	load := func(t *testing.T) *emulator.Config {
+		config, err := emulator.LoadConfig(emulatorConfigPath)
+		require.NoError(t, err)
+		return config
+	}
+
+	t.Run("durações inválidas", func(t *testing.T) {
+		config := load(t)
+		require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "DefaultMessageTimeToLive", "1h"))
+		require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "LockDuration", "PT10M"))
+		require.NoError(t, config.SetTopicProperty("sbt.orion.core", "DuplicateDetectionHistoryTimeWindow", "PT5S"))
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DefaultMessageTimeToLive")
+		assert.Contains(t, err.Error(), "LockDuration")
+		assert.Contains(t, err.Error(), "DuplicateDetectionHistoryTimeWindow")
+	})
+
+	t.Run("ForwardTo inexistente", func(t *testing.T) {
+		config := load(t)
+		require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "ForwardTo", "sbq.inexistente"))
+		assert.ErrorContains(t, config.Validate(), "sbq.inexistente")
+	})
+
+	t.Run("ForwardTo para a própria fila", func(t *testing.T) {
+		config := load(t)
+		require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "ForwardTo", "sbq.pismo.all"))
+		assert.Error(t, config.Validate())
+	})
+
+	t.Run("remoção de destino de ForwardTo", func(t *testing.T) {
+		config := load(t)
+		require.NoError(t, config.SetQueueProperty("sbq.pismo.all", "ForwardDeadLetteredMessagesTo", "sbq.pismo.ted.transaction"))
+		require.NoError(t, config.Validate())
+		require.NoError(t, config.RemoveQueue("sbq.pismo.ted.transaction"))
+		assert.Error(t, config.Validate())
+	})
+
+	t.Run("limite de entidades por namespace", func(t *testing.T) {
+		config := load(t)
+		namespace := config.UserConfig.Namespaces[0]
+		for i := len(namespace.Queues) + len(namespace.Topics); i < emulator.MaxEntitiesPerNamespace; i++ {
+			require.NoError(t, config.AddQueue(emulator.Queue{Name: fmt.Sprintf("sbq.limit.%d", i), Properties: emulator.DefaultQueueProperties()}))
+		}
+		require.NoError(t, config.Validate())
+
+		require.NoError(t, config.AddQueue(emulator.Queue{Name: "sbq.limit.extra", Properties: emulator.DefaultQueueProperties()}))
+		assert.ErrorContains(t, config.Validate(), "no máximo 50")
+	})
+
+	t.Run("limite de subscriptions por tópico", func(t *testing.T) {
+		config := load(t)
+		for i := 1; i <= emulator.MaxSubscriptionsPerTopic; i++ {
+			require.NoError(t, config.AddSubscription("sbt.orion.core", emulator.Subscription{Name: fmt.Sprintf("sub-%d", i), Properties: emulator.DefaultSubscriptionProperties()}))
+		}
+		assert.ErrorContains(t, config.Validate(), "subscriptions")
+	})
+
+	t.Run("mais de um namespace", func(t *testing.T) {
+		config := load(t)
+		config.UserConfig.Namespaces = append(config.UserConfig.Namespaces, emulator.Namespace{Name: "extra"})
+		assert.ErrorContains(t, config.Validate(), "namespace")
+	})
+}
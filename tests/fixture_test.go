@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/fixture"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderFixture testa as funções de template das fixtures
+func TestRenderFixture(t *testing.T) {
+	t.Setenv("ORION_TENANT", "orion")
+
+	renderer := fixture.NewRenderer()
+	renderer.Now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	template := []byte(`{
+		"id": "{{uuid}}",
+		"createdAt": "{{now "-1h"}}",
+		"day": "{{date "+2d"}}",
+		"custom": "{{now "" "02/01/2006"}}",
+		"unix": {{unix}},
+		"amount": {{amount 10 20}},
+		"digit": {{randInt 1 9}},
+		"first": {{seq "pedido"}},
+		"second": {{seq "pedido"}},
+		"account": "{{.conta}}",
+		"same": "{{var "conta"}}",
+		"tenant": "{{env "ORION_TENANT"}}",
+		"fallback": "{{env "ORION_MISSING" "padrão"}}",
+		"quoted": {{json .descricao}}
+	}`)
+
+	rendered, err := renderer.Render("fixture.json", template, map[string]string{"conta": "42", "descricao": `diz "oi"`})
+	require.NoError(t, err)
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(rendered, &document), string(rendered))
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), document["id"])
+	assert.Equal(t, "2025-01-02T02:04:05Z", document["createdAt"])
+	assert.Equal(t, "2025-01-04", document["day"])
+	assert.Equal(t, "02/01/2025", document["custom"])
+	assert.Equal(t, float64(1735787045), document["unix"])
+	assert.GreaterOrEqual(t, document["amount"], 10.0)
+	assert.LessOrEqual(t, document["amount"], 20.0)
+	assert.GreaterOrEqual(t, document["digit"], 1.0)
+	assert.LessOrEqual(t, document["digit"], 9.0)
+	assert.Equal(t, float64(1), document["first"])
+	assert.Equal(t, float64(2), document["second"])
+	assert.Equal(t, "42", document["account"])
+	assert.Equal(t, "42", document["same"])
+	assert.Equal(t, "orion", document["tenant"])
+	assert.Equal(t, "padrão", document["fallback"])
+	assert.Equal(t, `diz "oi"`, document["quoted"])
+
+	// Os contadores continuam entre renderizações do mesmo Renderer
+	next, err := renderer.Render("seq", []byte(`{{seq "pedido"}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3", string(next))
+}
+
+// TestRenderFixtureErrors testa os erros de renderização
+func TestRenderFixtureErrors(t *testing.T) {
+	renderer := fixture.NewRenderer()
+
+	plain := []byte(`{"id": "sem template"}`)
+	rendered, err := renderer.Render("plain.json", plain, nil)
+	require.NoError(t, err)
+	assert.Equal(t, plain, rendered)
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"função desconhecida", `{{nope}}`, "nope"},
+		{"variável ausente", `{{.conta}}`, "conta"},
+		{"var ausente", `{{var "conta"}}`, "--var conta=valor"},
+		{"ambiente ausente", `{{env "ORION_MISSING_VAR"}}`, "ORION_MISSING_VAR"},
+		{"deslocamento inválido", `{{now "ontem"}}`, "deslocamento inválido"},
+		{"intervalo invertido", `{{amount 10 1}}`, "mínimo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderer.Render("erro.json", []byte(tt.template), nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+// TestParseOffset testa os deslocamentos de horário dos templates
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "-1h", want: -time.Hour},
+		{input: "+30m", want: 30 * time.Minute},
+		{input: "2d", want: 48 * time.Hour},
+		{input: "-1d12h", want: -36 * time.Hour},
+		{input: "xd", wantErr: true},
+		{input: "--1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := fixture.ParseOffset(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestLoadBatchDirectory testa a leitura dos arquivos JSON de um diretório para push-batch
+func TestLoadBatchDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.json":     `{"id": "{{uuid}}"}`,
+		"a.json":     `{"id": 1}`,
+		"notas.txt":  `ignorado`,
+		"c.json.bak": `ignorado`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub.json"), 0755))
+
+	sources, err := fixture.LoadBatch(dir)
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "a.json", sources[0].Origin)
+	assert.Equal(t, "b.json", sources[1].Origin)
+	// O template não é renderizado na leitura: cada repetição renderiza de novo
+	assert.Equal(t, `{"id": "{{uuid}}"}`, string(sources[1].Data))
+
+	renderer := fixture.NewRenderer()
+	first, err := renderer.Render(sources[1].Origin, sources[1].Data, nil)
+	require.NoError(t, err)
+	second, err := renderer.Render(sources[1].Origin, sources[1].Data, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(first), string(second))
+
+	empty, err := fixture.LoadBatch(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = fixture.LoadBatch(filepath.Join(dir, "inexistente"))
+	assert.Error(t, err)
+}
+
+// TestLoadBatchNDJSON testa a leitura de um arquivo NDJSON, com a linha de origem de cada mensagem
+func TestLoadBatchNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lote.ndjson")
+	content := "{\"id\": 1}\n\n   \n  {\"id\": 2}  \r\n{\"id\": {{seq \"lote\"}}}"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	sources, err := fixture.LoadBatch(path)
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+	assert.Equal(t, []string{"lote.ndjson:1", "lote.ndjson:4", "lote.ndjson:5"},
+		[]string{sources[0].Origin, sources[1].Origin, sources[2].Origin})
+	assert.Equal(t, `{"id": 2}`, string(sources[1].Data))
+	assert.Equal(t, `{"id": {{seq "lote"}}}`, string(sources[2].Data))
+
+	long := filepath.Join(t.TempDir(), "longo.ndjson")
+	line := `{"data": "` + strings.Repeat("x", fixture.MaxNDJSONLineSize) + `"}`
+	require.NoError(t, os.WriteFile(long, []byte(line), 0644))
+	_, err = fixture.LoadBatch(long)
+	assert.Error(t, err)
+}
+
+func TestApplySetsLargeIntegers(t *testing.T) {
+	sets, err := fixture.ParseSets([]string{"b=12345678901234567890", "c=texto", "d={\"x\":9007199254740993}"})
+	assert.NoError(t, err)
+
+	data, err := fixture.ApplySets([]byte(`{"id": 9007199254740993, "a": 1}`), sets)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"id": 9007199254740993`)
+	assert.Contains(t, string(data), `"b": 12345678901234567890`)
+	assert.Contains(t, string(data), `"c": "texto"`)
+	assert.Contains(t, string(data), `"x": 9007199254740993`)
+
+	envelope := []byte(`{"body": {"id": 9007199254740993}, "applicationProperties": {"tipo": "a"}}`)
+	data, err = fixture.ApplySets(envelope, []fixture.Set{{Path: "valor", Value: json.Number("12345678901234567890")}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"id": 9007199254740993`)
+	assert.Contains(t, string(data), `"valor": 12345678901234567890`)
+
+	_, err = fixture.ParseSets([]string{"sem-valor"})
+	assert.Error(t, err)
+}
+
+// TestApplySetsOrder testa que overrides sobrepostos são aplicados na ordem da linha de comando
+func TestApplySetsOrder(t *testing.T) {
+	sets, err := fixture.ParseSets([]string{`data={"a":1,"b":1}`, "data.a=2"})
+	require.NoError(t, err)
+	require.Len(t, sets, 2)
+	assert.Equal(t, "data", sets[0].Path)
+	assert.Equal(t, "data.a", sets[1].Path)
+
+	for i := 0; i < 20; i++ {
+		data, err := fixture.ApplySets([]byte(`{"data": {"a": 0}}`), sets)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": {"a": 2, "b": 1}}`, string(data))
+	}
+
+	// Na ordem inversa, o objeto informado por último substitui o campo
+	sets, err = fixture.ParseSets([]string{"data.a=2", `data={"a":1}`})
+	require.NoError(t, err)
+	data, err := fixture.ApplySets([]byte(`{"data": {"a": 0}}`), sets)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"a": 1}}`, string(data))
+}
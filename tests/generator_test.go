@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"regexp"
+	"testing"
+
+	"fin.orion.dev/internal/fixture"
+	"fin.orion.dev/internal/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedDocuments testa os dígitos verificadores de CPF e CNPJ gerados
+func TestGeneratedDocuments(t *testing.T) {
+	g := generator.New(42)
+	for i := 0; i < 200; i++ {
+		cpf := g.CPF()
+		assert.Len(t, cpf, 11)
+		assert.True(t, generator.ValidCPF(cpf), cpf)
+		assert.True(t, generator.ValidCPF(generator.FormatCPF(cpf)), cpf)
+
+		cnpj := g.CNPJ()
+		assert.Len(t, cnpj, 14)
+		assert.Equal(t, "0001", cnpj[8:12])
+		assert.True(t, generator.ValidCNPJ(cnpj), cnpj)
+	}
+}
+
+// TestValidateDocuments testa a validação de documentos conhecidos
+func TestValidateDocuments(t *testing.T) {
+	assert.True(t, generator.ValidCPF("529.982.247-25"))
+	assert.False(t, generator.ValidCPF("529.982.247-26"))
+	assert.False(t, generator.ValidCPF("111.111.111-11"))
+	assert.False(t, generator.ValidCPF("5299822472"))
+	assert.False(t, generator.ValidCPF("52998224a25"))
+
+	assert.True(t, generator.ValidCNPJ("11.222.333/0001-81"))
+	assert.False(t, generator.ValidCNPJ("11.222.333/0001-82"))
+	assert.False(t, generator.ValidCNPJ("00000000000000"))
+
+	assert.Equal(t, "529.982.247-25", generator.FormatCPF("52998224725"))
+	assert.Equal(t, "11.222.333/0001-81", generator.FormatCNPJ("11222333000181"))
+}
+
+// TestGeneratorSeed testa que a mesma seed produz a mesma sequência
+func TestGeneratorSeed(t *testing.T) {
+	first, second := generator.New(7), generator.New(7)
+	for i := 0; i < 10; i++ {
+		key1, err := first.PixKey("")
+		require.NoError(t, err)
+		key2, err := second.PixKey("")
+		require.NoError(t, err)
+		assert.Equal(t, key1, key2)
+	}
+	assert.Equal(t, first.Account(), second.Account())
+	assert.NotEqual(t, generator.New(1).CPF(), generator.New(2).CPF())
+}
+
+// TestPixKeys testa o formato de cada tipo de chave PIX
+func TestPixKeys(t *testing.T) {
+	g := generator.New(3)
+	formats := map[string]*regexp.Regexp{
+		generator.PixKeyCPF:   regexp.MustCompile(`^\d{11}$`),
+		generator.PixKeyCNPJ:  regexp.MustCompile(`^\d{14}$`),
+		generator.PixKeyEmail: regexp.MustCompile(`^[a-z]+\.[a-z]+\d+@[a-z.]+$`),
+		generator.PixKeyPhone: regexp.MustCompile(`^\+55\d{2}9\d{8}$`),
+		generator.PixKeyEVP:   regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+	}
+	for kind, format := range formats {
+		for i := 0; i < 20; i++ {
+			key, err := g.PixKey(kind)
+			require.NoError(t, err)
+			assert.Regexp(t, format, key, kind)
+		}
+	}
+
+	_, err := g.PixKey("aleatoria")
+	assert.ErrorContains(t, err, "tipo de chave PIX inválido")
+}
+
+// TestAccountAndAmounts testa conta, ISPB e valores em reais
+func TestAccountAndAmounts(t *testing.T) {
+	assert.Equal(t, "0", generator.AccountDigit("00000000"))
+	assert.Equal(t, "9", generator.AccountDigit("12345678"))
+
+	g := generator.New(5)
+	account := g.Account()
+	assert.Regexp(t, `^\d{4}$`, account.Branch)
+	assert.Regexp(t, `^\d{8}$`, account.Number)
+	assert.Equal(t, generator.AccountDigit(account.Number), account.Digit)
+	assert.Contains(t, generator.Banks, account.Bank)
+	assert.Regexp(t, `^\d{8}-\d$`, g.AccountNumber())
+	assert.Regexp(t, `^\d{8}$`, g.ISPB())
+
+	assert.Equal(t, "R$ 0,99", generator.FormatBRL(0.99))
+	assert.Equal(t, "R$ 1.234,56", generator.FormatBRL(1234.56))
+	assert.Equal(t, "R$ 1.000.000,00", generator.FormatBRL(1e6))
+	assert.Equal(t, "-R$ 10,50", generator.FormatBRL(-10.5))
+
+	_, err := g.Amount(10, 1)
+	assert.Error(t, err)
+}
+
+// TestRenderBrazilianFunctions testa as funções de dados brasileiros nas fixtures e a seed do Renderer
+func TestRenderBrazilianFunctions(t *testing.T) {
+	data := []byte(`{"cpf": "{{cpf "mask"}}", "cnpj": "{{cnpj}}", "key": "{{pixKey "phone"}}", "branch": "{{branch}}", "account": "{{account}}", "ispb": "{{ispb}}", "value": "{{brl 1 10}}"}`)
+
+	render := func(seed uint64) string {
+		r := fixture.NewRenderer()
+		r.Seed(seed)
+		out, err := r.Render("br.json", data, nil)
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	out := render(11)
+	assert.Equal(t, out, render(11))
+	assert.NotEqual(t, out, render(12))
+	assert.Regexp(t, `"cpf": "\d{3}\.\d{3}\.\d{3}-\d{2}"`, out)
+	assert.Regexp(t, `"value": "R\$ \d+,\d{2}"`, out)
+
+	_, err := fixture.NewRenderer().Render("br.json", []byte(`{{cpf "x"}}`), nil)
+	assert.ErrorContains(t, err, "mask")
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"fin.orion.dev/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLookupJSONPath testa a busca de valores por caminho JSON
+func TestLookupJSONPath(t *testing.T) {
+	var document interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "pix.in",
+		"data": {
+			"amount": 150.5,
+			"confirmed": true,
+			"items": [{"id": "a"}, {"id": "b"}]
+		}
+	}`), &document))
+
+	tests := []struct {
+		name  string
+		path  string
+		want  string
+		found bool
+	}{
+		{name: "campo simples", path: "type", want: "pix.in", found: true},
+		{name: "prefixo $", path: "$.data.amount", want: "150.5", found: true},
+		{name: "booleano", path: "data.confirmed", want: "true", found: true},
+		{name: "índice de array", path: "data.items[1].id", want: "b", found: true},
+		{name: "índice fora do array", path: "data.items[5].id", found: false},
+		{name: "campo inexistente", path: "data.missing", found: false},
+		{name: "caminho inválido", path: "data..amount", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, found := utils.LookupJSONPath(document, tt.path)
+			assert.Equal(t, tt.found, found)
+			if tt.found {
+				assert.Equal(t, tt.want, utils.JSONValueString(value))
+			}
+		})
+	}
+}
+
+// TestSetJSONPath testa a alteração de valores por caminho JSON
+func TestSetJSONPath(t *testing.T) {
+	var document interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"data": {"amount": 1, "items": [{"id": "a"}]}}`), &document))
+
+	document, err := utils.SetJSONPath(document, "data.amount", 10.5)
+	require.NoError(t, err)
+	document, err = utils.SetJSONPath(document, "$.data.items[0].id", "b")
+	require.NoError(t, err)
+	document, err = utils.SetJSONPath(document, "meta.origin.name", "teste")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(document)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"amount": 10.5, "items": [{"id": "b"}]}, "meta": {"origin": {"name": "teste"}}}`, string(data))
+
+	_, err = utils.SetJSONPath(document, "data.items[3].id", "x")
+	assert.Error(t, err)
+	_, err = utils.SetJSONPath(document, "data.amount.value", 1)
+	assert.Error(t, err)
+	_, err = utils.SetJSONPath(document, "", 1)
+	assert.Error(t, err)
+}
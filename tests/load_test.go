@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/load"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoadBus cria o fakeBus com 41 mensagens anteriores à carga na entidade de resposta
+func newLoadBus() *fakeBus {
+	bus := &fakeBus{fail: map[int]bool{}, drop: map[int]bool{}}
+	for i := 0; i < 41; i++ {
+		bus.existing = append(bus.existing, &servicebus.Message{CorrelationID: fmt.Sprintf("anterior-%d", i)})
+	}
+	return bus
+}
+
+// TestParseRate testa a interpretação das taxas
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"200/s", 200},
+		{"50", 50},
+		{"600/m", 10},
+		{"7200/h", 2},
+		{" 2.5/s ", 2.5},
+	}
+	for _, tt := range tests {
+		rate, err := load.ParseRate(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.InDelta(t, tt.expected, rate, 1e-9, tt.input)
+	}
+
+	for _, input := range []string{"", "0/s", "-1/s", "abc", "10/d"} {
+		_, err := load.ParseRate(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestLoadRun testa a execução com respostas, falhas e mensagens sem resposta
+func TestLoadRun(t *testing.T) {
+	bus := newLoadBus()
+	bus.fail[3] = true
+	bus.drop[5] = true
+	reply := servicebus.Entity{Topic: "sbt.orion.core", Subscription: "subscription.orion.core"}
+
+	start := time.Now()
+	result, err := load.Run(context.Background(), bus, load.Config{
+		Target:       "sbq.pismo.all",
+		Rate:         100,
+		Duration:     200 * time.Millisecond,
+		Concurrency:  4,
+		Reply:        &reply,
+		ReplyTimeout: 300 * time.Millisecond,
+		Build: func(index int) (*servicebus.Message, error) {
+			// Uma resposta que não pertence à carga deve ser ignorada
+			if index == 0 {
+				bus.deliver(&servicebus.Message{CorrelationID: "outra"})
+			}
+			return &servicebus.Message{Body: map[string]interface{}{"index": index}, MessageID: "fixo"}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	// 20 mensagens a 100/s levam ao menos 190ms para serem despachadas
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	assert.Equal(t, int64(42), bus.watched)
+	assert.Equal(t, 20, result.Planned)
+	assert.Len(t, result.Samples, 20)
+	assert.Equal(t, 19, result.Sent)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 18, result.Replied)
+	assert.Equal(t, 1, result.Missing)
+	assert.Equal(t, map[string]int{"fila cheia": 1}, result.Errors)
+	assert.Equal(t, "sbt.orion.core/subscription.orion.core", result.Reply)
+	require.NotNil(t, result.ReplyLatency)
+	assert.Equal(t, 18, result.ReplyLatency.Count)
+	assert.Equal(t, 19, result.SendLatency.Count)
+
+	// Cada envio recebe IDs próprios e a entidade de resposta em ReplyTo
+	ids := make(map[string]bool)
+	for _, message := range bus.sent {
+		assert.True(t, strings.HasPrefix(message.MessageID, "load-"))
+		assert.NotEmpty(t, message.CorrelationID)
+		assert.Equal(t, "sbt.orion.core", message.ReplyTo)
+		ids[message.MessageID] = true
+	}
+	assert.Len(t, ids, 20)
+}
+
+// TestLoadRunCanceled testa a interrupção com resultado parcial
+func TestLoadRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	result, err := load.Run(ctx, newLoadBus(), load.Config{
+		Target:      "sbq.pismo.all",
+		Rate:        50,
+		Duration:    10 * time.Second,
+		Concurrency: 2,
+		Build: func(int) (*servicebus.Message, error) {
+			return &servicebus.Message{Body: "x"}, nil
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Canceled)
+	assert.Equal(t, 500, result.Planned)
+	assert.Less(t, len(result.Samples), 20)
+	assert.Equal(t, len(result.Samples), result.Sent)
+	assert.Nil(t, result.ReplyLatency)
+
+	_, err = load.Run(context.Background(), newLoadBus(), load.Config{Rate: 1, Duration: 100 * time.Millisecond, Concurrency: 1, Build: func(int) (*servicebus.Message, error) { return nil, nil }})
+	assert.ErrorContains(t, err, "nenhuma mensagem")
+}
+
+// TestSummarize testa os percentis das latências
+func TestSummarize(t *testing.T) {
+	var values []time.Duration
+	for i := 100; i >= 1; i-- {
+		values = append(values, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := load.Summarize(values)
+	assert.Equal(t, 100, stats.Count)
+	assert.Equal(t, time.Millisecond, stats.Min)
+	assert.Equal(t, 100*time.Millisecond, stats.Max)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+	assert.Equal(t, 50500*time.Microsecond, stats.Avg)
+
+	assert.Equal(t, load.Stats{}, load.Summarize(nil))
+}
+
+// TestLoadReports testa a exportação em CSV e JSON
+func TestLoadReports(t *testing.T) {
+	sentAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &load.Result{
+		Target:      "sbq.pismo.all",
+		Planned:     2,
+		Sent:        1,
+		Failed:      1,
+		SendLatency: load.Summarize([]time.Duration{1500 * time.Microsecond}),
+		Samples: []load.Sample{
+			{Index: 0, MessageID: "load-a-0", CorrelationID: "c0", SentAt: sentAt, SendMs: 1.5, Replied: true, ReplyMs: 20.25},
+			{Index: 1, MessageID: "load-a-1", CorrelationID: "c1", SentAt: sentAt, SendMs: 3, Error: "fila cheia"},
+		},
+	}
+
+	var csvOut bytes.Buffer
+	require.NoError(t, load.WriteCSV(&csvOut, result))
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"index", "message_id", "correlation_id", "sent_at", "send_ms", "replied", "reply_ms", "error"}, records[0])
+	assert.Equal(t, []string{"0", "load-a-0", "c0", "2025-01-02T03:04:05Z", "1.500", "true", "20.250", ""}, records[1])
+	assert.Equal(t, []string{"1", "load-a-1", "c1", "2025-01-02T03:04:05Z", "3.000", "false", "", "fila cheia"}, records[2])
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, load.WriteJSON(&jsonOut, result))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Equal(t, "sbq.pismo.all", decoded["target"])
+	assert.Equal(t, 1.5, decoded["sendLatency"].(map[string]interface{})["p99Ms"])
+	assert.NotContains(t, decoded, "replyLatency")
+	assert.Len(t, decoded["samples"], 2)
+}
@@ -3,6 +3,9 @@ package tests
 import (
 	"os"
 	"testing"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -421,3 +424,71 @@ func TestMessagesIntegration(t *testing.T) {
 		AssertCommandSuccess(t, stdout, stderr, err)
 	})
 }
+
+// TestParseScheduleTime testa a interpretação das flags --at/--in de push-message e send-json
+func TestParseScheduleTime(t *testing.T) {
+	now := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		at        string
+		in        time.Duration
+		expected  time.Time
+		scheduled bool
+		wantErr   bool
+	}{
+		{name: "sem agendamento"},
+		{name: "at com fuso", at: "2025-01-31T10:00:00-03:00", expected: time.Date(2025, 1, 31, 13, 0, 0, 0, time.UTC), scheduled: true},
+		{name: "at em UTC", at: "2025-02-01T00:00:00Z", expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), scheduled: true},
+		{name: "at no passado", at: "2020-01-01T00:00:00Z", expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), scheduled: true},
+		{name: "in", in: 90 * time.Second, expected: now.Add(90 * time.Second), scheduled: true},
+		{name: "in negativo", in: -time.Minute, wantErr: true},
+		{name: "at e in juntos", at: "2025-02-01T00:00:00Z", in: time.Minute, wantErr: true},
+		{name: "at sem fuso", at: "2025-01-31T10:00:00", wantErr: true},
+		{name: "at só com data", at: "2025-01-31", wantErr: true},
+		{name: "at inválido", at: "amanhã", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueueTime, scheduled, err := servicebus.ParseScheduleTime(tt.at, tt.in, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, scheduled)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.scheduled, scheduled)
+			assert.True(t, tt.expected.Equal(enqueueTime), "esperado %s, obtido %s", tt.expected, enqueueTime)
+		})
+	}
+}
+
+// TestParseSequenceNumbers testa os sequence numbers aceitos por cancel-scheduled
+func TestParseSequenceNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []int64
+		wantErr  bool
+	}{
+		{name: "um", args: []string{"42"}, expected: []int64{42}},
+		{name: "vários", args: []string{"1", "2", "9223372036854775807"}, expected: []int64{1, 2, 9223372036854775807}},
+		{name: "vazio", args: nil, wantErr: true},
+		{name: "não numérico", args: []string{"1", "abc"}, wantErr: true},
+		{name: "negativo", args: []string{"-1"}, wantErr: true},
+		{name: "fora do intervalo", args: []string{"9223372036854775808"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequenceNumbers, err := servicebus.ParseSequenceNumbers(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sequenceNumbers)
+		})
+	}
+}
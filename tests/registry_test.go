@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"testing"
+
+	"fin.orion.dev/internal/registry"
+	"fin.orion.dev/internal/servicebus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistryFromEmulatorConfig testa o registro montado a partir da configuração do emulador
+func TestRegistryFromEmulatorConfig(t *testing.T) {
+	reg, err := registry.Load(emulatorConfigPath)
+	require.NoError(t, err)
+
+	queues := reg.QueueNames()
+	assert.Len(t, queues, 10)
+	assert.Equal(t, "sbq.pismo.onboarding.succeeded", queues[0])
+	assert.Contains(t, queues, "sbq.pix.recurrence.payment.order.failure")
+
+	assert.Equal(t, []string{"sbt.orion.core"}, reg.TopicNames())
+	assert.Equal(t, []string{"sbt.orion.core/subscription.orion.core"}, reg.SubscriptionNames())
+	assert.Len(t, reg.EntityNames(), 11)
+
+	assert.True(t, reg.HasQueue("sbq.pismo.all"))
+	assert.False(t, reg.HasQueue("test-queue"))
+	assert.True(t, reg.HasTopic("sbt.orion.core"))
+
+	queue, ok := reg.Queue("sbq.pismo.all")
+	require.True(t, ok)
+	assert.Equal(t, 3, queue.Properties.MaxDeliveryCount)
+}
+
+// TestRegistryValidateEntity testa a validação de filas e subscriptions
+func TestRegistryValidateEntity(t *testing.T) {
+	reg, err := registry.Parse([]byte(`{
+		"UserConfig": {
+			"Namespaces": [{
+				"Name": "sbemulatorns",
+				"Queues": [{"Name": "fila.a", "Properties": {}}],
+				"Topics": [{"Name": "topico.a", "Properties": {}, "Subscriptions": [{"Name": "sub.a", "Properties": {}}]}]
+			}],
+			"Logging": {"Type": "File"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		entity  servicebus.Entity
+		wantErr bool
+	}{
+		{name: "fila existente", entity: servicebus.Entity{Queue: "fila.a"}},
+		{name: "fila inexistente", entity: servicebus.Entity{Queue: "test-queue"}, wantErr: true},
+		{name: "subscription existente", entity: servicebus.Entity{Topic: "topico.a", Subscription: "sub.a"}},
+		{name: "tópico inexistente", entity: servicebus.Entity{Topic: "topico.b", Subscription: "sub.a"}, wantErr: true},
+		{name: "subscription inexistente", entity: servicebus.Entity{Topic: "topico.a", Subscription: "sub.b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reg.ValidateEntity(tt.entity)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestRegistryValidateTopic testa a validação dos tópicos usados por push-topic, send-topic e check-topic
+func TestRegistryValidateTopic(t *testing.T) {
+	reg, err := registry.Load(emulatorConfigPath)
+	require.NoError(t, err)
+
+	topic, err := reg.ValidateTopic("sbt.orion.core")
+	require.NoError(t, err)
+	assert.Equal(t, "sbt.orion.core", topic.Name)
+	require.Len(t, topic.Subscriptions, 1)
+	assert.Equal(t, "subscription.orion.core", topic.Subscriptions[0].Name)
+
+	for _, name := range []string{"sbt.orion.inexistente", "sbq.pismo.all", ""} {
+		_, err := reg.ValidateTopic(name)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "tópicos válidos: sbt.orion.core")
+	}
+
+	empty, err := registry.Parse([]byte(`{
+		"UserConfig": {
+			"Namespaces": [{"Name": "sbemulatorns", "Queues": [{"Name": "fila.a", "Properties": {}}]}],
+			"Logging": {"Type": "File"}
+		}
+	}`))
+	require.NoError(t, err)
+	_, err = empty.ValidateTopic("sbt.orion.core")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nenhum tópico declarado")
+}
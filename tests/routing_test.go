@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"testing"
+
+	"fin.orion.dev/internal/emulator"
+	"fin.orion.dev/internal/routing"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routingTopic monta um tópico com subscriptions sem regras, com filtro SQL e com filtro de correlação
+func routingTopic() *emulator.Topic {
+	return &emulator.Topic{
+		Name: "sbt.orion.core",
+		Subscriptions: []emulator.Subscription{
+			{Name: "todas"},
+			{
+				Name: "pix-alto",
+				Rules: []emulator.Rule{{
+					Name: "pix",
+					Properties: emulator.RuleProperties{
+						FilterType: emulator.FilterTypeSQL,
+						SqlFilter:  &emulator.SqlFilter{SqlExpression: "tipo = 'pix' AND valor > 100"},
+						Action:     &emulator.SqlAction{SqlExpression: "SET prioridade = 'alta'"},
+					},
+				}},
+			},
+			{
+				Name: "ted",
+				Rules: []emulator.Rule{{
+					Name: "ted",
+					Properties: emulator.RuleProperties{
+						FilterType: emulator.FilterTypeCorrelation,
+						CorrelationFilter: &emulator.CorrelationFilter{
+							Label:      "ted",
+							Properties: map[string]interface{}{"origem": "pismo"},
+						},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// TestRoutingRoute testa a simulação de roteamento pelas regras das subscriptions
+func TestRoutingRoute(t *testing.T) {
+	topic := routingTopic()
+
+	matched := func(t *testing.T, message *servicebus.Message) []string {
+		results, err := routing.Route(topic, message)
+		require.NoError(t, err)
+		var names []string
+		for _, result := range results {
+			if result.Matched() {
+				names = append(names, result.Subscription)
+			}
+		}
+		return names
+	}
+
+	t.Run("filtro SQL", func(t *testing.T) {
+		message := &servicebus.Message{Properties: map[string]interface{}{"tipo": "pix", "valor": float64(150)}}
+		assert.Equal(t, []string{"todas", "pix-alto"}, matched(t, message))
+
+		results, err := routing.Route(topic, message)
+		require.NoError(t, err)
+		require.Len(t, results[1].Matches, 1)
+		assert.Equal(t, "alta", results[1].Matches[0].Message.Properties["prioridade"])
+		assert.Equal(t, emulator.DefaultRuleName, results[0].Matches[0].Rule)
+	})
+
+	t.Run("filtro de correlação", func(t *testing.T) {
+		message := &servicebus.Message{Subject: "ted", Properties: map[string]interface{}{"origem": "pismo"}}
+		assert.Equal(t, []string{"todas", "ted"}, matched(t, message))
+
+		message.Properties["origem"] = "core"
+		assert.Equal(t, []string{"todas"}, matched(t, message))
+	})
+
+	t.Run("sem propriedades", func(t *testing.T) {
+		assert.Equal(t, []string{"todas"}, matched(t, &servicebus.Message{}))
+	})
+}
+
+// TestEmulatorConfigRules testa a inclusão, validação e remoção de regras
+func TestEmulatorConfigRules(t *testing.T) {
+	config, err := emulator.LoadConfig(emulatorConfigPath)
+	require.NoError(t, err)
+
+	sqlRule := func(name, expression string) emulator.Rule {
+		return emulator.Rule{Name: name, Properties: emulator.RuleProperties{
+			FilterType: emulator.FilterTypeSQL,
+			SqlFilter:  &emulator.SqlFilter{SqlExpression: expression},
+		}}
+	}
+
+	require.NoError(t, config.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("pix", "tipo = 'pix'")))
+	require.NoError(t, config.Validate())
+	assert.Error(t, config.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("pix", "1=1")))
+	assert.Error(t, config.AddRule("sbt.orion.core", "inexistente", sqlRule("x", "1=1")))
+
+	require.NoError(t, config.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("invalida", "tipo = ")))
+	assert.ErrorContains(t, config.Validate(), "invalida")
+	require.NoError(t, config.RemoveRule("sbt.orion.core", "subscription.orion.core", "invalida"))
+
+	require.NoError(t, config.AddRule("sbt.orion.core", "subscription.orion.core", emulator.Rule{
+		Name:       "vazia",
+		Properties: emulator.RuleProperties{FilterType: emulator.FilterTypeCorrelation, CorrelationFilter: &emulator.CorrelationFilter{}},
+	}))
+	assert.ErrorContains(t, config.Validate(), "CorrelationFilter")
+	require.NoError(t, config.RemoveRule("sbt.orion.core", "subscription.orion.core", "vazia"))
+	require.NoError(t, config.Validate())
+
+	t.Run("limite de filtros SQL por tópico", func(t *testing.T) {
+		for i := 1; i < emulator.MaxSQLFiltersPerTopic; i++ {
+			require.NoError(t, config.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("regra-"+string(rune('a'+i)), "1=1")))
+		}
+		require.NoError(t, config.Validate())
+		require.NoError(t, config.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("excedente", "1=1")))
+		assert.ErrorContains(t, config.Validate(), "filtros SQL")
+	})
+
+	t.Run("remoção mantém o arquivo original", func(t *testing.T) {
+		original, err := emulator.LoadConfig(emulatorConfigPath)
+		require.NoError(t, err)
+		require.NoError(t, original.AddRule("sbt.orion.core", "subscription.orion.core", sqlRule("pix", "1=1")))
+		require.NoError(t, original.RemoveRule("sbt.orion.core", "subscription.orion.core", "pix"))
+
+		data, err := original.Marshal()
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "Rules")
+	})
+}
@@ -0,0 +1,300 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/scenario"
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBus simula o Service Bus usado por cenários e pela carga: cada envio aparece, com o próximo
+// sequence number, na entidade observada por Watch, exceto os índices em fail (erro) e drop (perdidos).
+// O valor zero é utilizável.
+type fakeBus struct {
+	mu        sync.Mutex
+	existing  []*servicebus.Message
+	sent      []*servicebus.Message
+	delivered []*servicebus.Message
+	fail      map[int]bool
+	drop      map[int]bool
+	watched   int64
+	changed   chan struct{}
+}
+
+func (b *fakeBus) Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error {
+	b.mu.Lock()
+	index := len(b.sent)
+	b.sent = append(b.sent, message)
+	b.mu.Unlock()
+
+	if b.fail[index] {
+		return fmt.Errorf("fila cheia")
+	}
+	if !b.drop[index] {
+		b.deliver(message)
+	}
+	return nil
+}
+
+// deliver publica uma mensagem na entidade observada, como faria o serviço que responde
+func (b *fakeBus) deliver(message *servicebus.Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// O sequence number e o horário de entrada são atribuídos pelo broker
+	copied := *message
+	copied.SequenceNumber = int64(len(b.existing) + len(b.delivered) + 1)
+	enqueued := time.Now()
+	copied.EnqueuedTimeUtc = &enqueued
+	b.delivered = append(b.delivered, &copied)
+
+	if b.changed != nil {
+		close(b.changed)
+		b.changed = nil
+	}
+}
+
+func (b *fakeBus) LastSequenceNumber(ctx context.Context, entity servicebus.Entity) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int64(len(b.existing) + len(b.delivered)), nil
+}
+
+func (b *fakeBus) Watch(ctx context.Context, entity servicebus.Entity, fromSequence int64, handler func(*servicebus.Message) bool) error {
+	b.mu.Lock()
+	b.watched = fromSequence
+	b.mu.Unlock()
+
+	next := fromSequence
+	for {
+		b.mu.Lock()
+		var pending []*servicebus.Message
+		for i, message := range b.existing {
+			if int64(i+1) >= next {
+				copied := *message
+				copied.SequenceNumber = int64(i + 1)
+				pending = append(pending, &copied)
+			}
+		}
+		for _, message := range b.delivered {
+			if message.SequenceNumber >= next {
+				pending = append(pending, message)
+			}
+		}
+		if b.changed == nil {
+			b.changed = make(chan struct{})
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		for _, message := range pending {
+			next = message.SequenceNumber + 1
+			if handler(message) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// TestParseScenario testa a leitura e a validação de cenários
+func TestParseScenario(t *testing.T) {
+	valid := `
+name: fluxo
+steps:
+  - id: envio
+    send: {to: in, body: {id: 1}}
+  - wait:
+      entity: out
+      match: [{path: body.id, equals: 1}]
+  - sleep: 10ms
+  - from: envio
+    assert: [{path: body.id, exists: true}]
+`
+	parsed, err := scenario.Parse([]byte(valid))
+	require.NoError(t, err)
+	assert.Len(t, parsed.Steps, 4)
+	assert.Equal(t, "wait", parsed.Steps[1].Kind())
+	assert.Equal(t, "sleep 10ms", parsed.Steps[2].Title())
+
+	invalid := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"sem nome", "steps: [{sleep: 1s}]", "name"},
+		{"duas ações", "name: x\nsteps: [{sleep: 1s, http: {url: /a}}]", "apenas uma ação"},
+		{"send sem fixture", "name: x\nsteps: [{send: {to: in}}]", "fixture"},
+		{"from inexistente", "name: x\nsteps: [{from: a, assert: [{path: body}]}]", "não existe"},
+		{"campo desconhecido", "name: x\nsteps: [{sleeep: 1s}]", "sleeep"},
+		{"operador desconhecido", "name: x\nsteps: [{sleep: 1s, assert: [{path: a, equal: 1}]}]", "equal"},
+		{"dois operadores", "name: x\nsteps: [{sleep: 1s, assert: [{path: a, equals: 1, exists: true}]}]", "apenas um operador"},
+		{"regex inválida", "name: x\nsteps: [{sleep: 1s, assert: [{path: a, matches: '('}]}]", "expressão regular"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scenario.Parse([]byte(tt.yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+// TestScenarioAssertions testa os operadores das assertions
+func TestScenarioAssertions(t *testing.T) {
+	parsed, err := scenario.Parse([]byte(`
+name: assertions
+steps:
+  - sleep: 1ms
+    assert:
+      - {path: body.amount, equals: 150.5}
+      - {path: body.amount, equals: "150.5"}
+      - {path: body.status, matches: "^crea"}
+      - {path: body.tags, contains: pix}
+      - {path: body.error, exists: false}
+      - {path: body.id}
+`))
+	require.NoError(t, err)
+
+	document := map[string]interface{}{
+		"body": map[string]interface{}{
+			"amount": 150.5,
+			"status": "created",
+			"tags":   []interface{}{"pix", "ted"},
+			"id":     "abc",
+		},
+	}
+	for _, assertion := range parsed.Steps[0].Assert {
+		assert.NoError(t, assertion.Check(document), assertion.String())
+	}
+
+	document["body"].(map[string]interface{})["status"] = "failed"
+	err = parsed.Steps[0].Assert[2].Check(document)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "body.status")
+}
+
+// TestScenarioRunner testa a execução de um cenário com Service Bus simulado e servidor HTTP
+func TestScenarioRunner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/transactions/123" {
+			_, _ = w.Write([]byte(`{"status":"created"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	parsed, err := scenario.Parse([]byte(`
+name: criação de transação
+baseUrl: ` + server.URL + `
+timeout: 200ms
+steps:
+  - name: enviar
+    send: {to: in, fixture: transacao.json, correlationId: c-1}
+  - name: aguardar
+    wait:
+      entity: out
+      match: [{path: correlationId, equals: c-1}]
+    assert: [{path: body.id, equals: "123"}]
+  - name: consultar
+    http: {url: /transactions/123, status: 200}
+    assert: [{path: body.status, equals: created}]
+  - name: inexistente
+    http: {url: /missing}
+  - name: ignorado
+    sleep: 1ms
+`))
+	require.NoError(t, err)
+
+	bus := &fakeBus{existing: []*servicebus.Message{{Body: map[string]interface{}{"id": "antiga"}, CorrelationID: "c-1"}}}
+	var progress []string
+	runner := &scenario.Runner{
+		Bus: bus,
+		LoadFixture: func(name string) (*servicebus.Message, error) {
+			return &servicebus.Message{Body: map[string]interface{}{"id": "123"}}, nil
+		},
+		OnStep: func(index int, step *scenario.Step, result scenario.StepResult) {
+			progress = append(progress, step.Kind()+":"+string(result.Status))
+		},
+	}
+
+	report := runner.Run(context.Background(), parsed)
+	assert.False(t, report.Passed)
+	assert.Equal(t, []string{"send:passed", "wait:passed", "http:passed", "http:failed", "sleep:skipped"}, progress)
+	assert.Contains(t, report.Steps[3].Error, "status 404")
+	require.Len(t, bus.sent, 1)
+	assert.Equal(t, "c-1", bus.sent[0].CorrelationID)
+	assert.True(t, scenario.Failed([]*scenario.Report{report}))
+
+	var junit bytes.Buffer
+	require.NoError(t, scenario.WriteJUnit(&junit, []*scenario.Report{report}))
+	assert.Contains(t, junit.String(), `<testsuites tests="5" failures="1" skipped="1"`)
+	assert.Contains(t, junit.String(), `<testcase name="04 inexistente"`)
+	assert.Contains(t, junit.String(), "<skipped>")
+
+	var output bytes.Buffer
+	require.NoError(t, scenario.WriteJSON(&output, []*scenario.Report{report}))
+	assert.Contains(t, output.String(), `"status": "failed"`)
+}
+
+// TestScenarioWaitTimeout testa o timeout de um passo wait sem mensagem correspondente
+func TestScenarioWaitTimeout(t *testing.T) {
+	parsed, err := scenario.Parse([]byte(`
+name: timeout
+steps:
+  - wait:
+      entity: out
+      timeout: 50ms
+      match: [{path: body.id, equals: "nunca"}]
+`))
+	require.NoError(t, err)
+
+	report := (&scenario.Runner{Bus: &fakeBus{}}).Run(context.Background(), parsed)
+	assert.False(t, report.Passed)
+	assert.Contains(t, report.Steps[0].Error, "nenhuma mensagem em 'out'")
+}
+
+// TestScenarioWaitCursor testa que esperas seguidas na mesma entidade não aceitam a mesma mensagem
+func TestScenarioWaitCursor(t *testing.T) {
+	twoWaits := func(sends int) *scenario.Scenario {
+		yaml := "name: cursor\ntimeout: 50ms\nsteps:\n"
+		for i := 0; i < sends; i++ {
+			yaml += fmt.Sprintf("  - send: {to: in, body: {id: %d}, correlationId: c-1}\n", i+1)
+		}
+		yaml += `  - wait: {entity: out, match: [{path: correlationId, equals: c-1}]}
+    assert: [{path: body.id, equals: 1}]
+  - wait: {entity: out, match: [{path: correlationId, equals: c-1}]}
+    assert: [{path: body.id, equals: 2}]
+`
+		parsed, err := scenario.Parse([]byte(yaml))
+		require.NoError(t, err)
+		return parsed
+	}
+
+	report := (&scenario.Runner{Bus: &fakeBus{}}).Run(context.Background(), twoWaits(2))
+	assert.True(t, report.Passed, "%+v", report.Steps)
+
+	// Com uma única resposta, a segunda espera não pode reaproveitá-la
+	report = (&scenario.Runner{Bus: &fakeBus{}}).Run(context.Background(), twoWaits(1))
+	assert.False(t, report.Passed)
+	require.Len(t, report.Steps, 3)
+	assert.Equal(t, scenario.StatusPassed, report.Steps[1].Status)
+	assert.Contains(t, report.Steps[2].Error, "nenhuma mensagem em 'out'")
+}
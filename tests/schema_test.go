@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fin.orion.dev/internal/schema"
+	"fin.orion.dev/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transactionSchema = `{
+  "type": "object",
+  "required": ["id", "data"],
+  "properties": {
+    "id": {"type": "string", "format": "uuid"},
+    "data": {"$ref": "#/definitions/data"},
+    "tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2}
+  },
+  "definitions": {
+    "data": {
+      "type": "object",
+      "required": ["amount", "status"],
+      "additionalProperties": false,
+      "properties": {
+        "amount": {"type": "number", "exclusiveMinimum": 0},
+        "status": {"enum": ["created", "settled"]},
+        "currency": {"type": "string", "pattern": "^[A-Z]{3}$"}
+      }
+    }
+  }
+}`
+
+// TestSchemaViolations testa as violações com JSON pointer e linha
+func TestSchemaViolations(t *testing.T) {
+	sch, err := schema.Parse([]byte(transactionSchema))
+	require.NoError(t, err)
+
+	data := []byte(`{
+  "id": "abc",
+  "data": {
+    "amount": "10",
+    "currency": "brl",
+    "extra": true
+  },
+  "tags": ["a", 1, "c"]
+}`)
+
+	violations, err := sch.ValidateJSON(data, "")
+	require.NoError(t, err)
+
+	var lines []string
+	for _, violation := range violations {
+		lines = append(lines, violation.String())
+	}
+	assert.Equal(t, []string{
+		`/id (linha 2): "abc" não está no formato uuid`,
+		`/data (linha 3): campo obrigatório 'status' ausente`,
+		`/data/amount (linha 4): tipo inválido: esperado number, obtido string`,
+		`/data/currency (linha 5): "brl" não corresponde ao padrão /^[A-Z]{3}$/`,
+		`/data/extra (linha 6): campo 'extra' não permitido`,
+		`/tags (linha 8): array com 3 item(ns), máximo 2`,
+		`/tags/1 (linha 8): tipo inválido: esperado string, obtido integer`,
+	}, lines)
+
+	valid := []byte(`{"id": "9b2e7c1a-4f3d-4a8e-9c1b-2d3e4f5a6b7c", "data": {"amount": 10.5, "status": "created"}}`)
+	violations, err = sch.ValidateJSON(valid, "")
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+// TestSchemaEnvelopeBase testa a validação do body de envelopes
+func TestSchemaEnvelopeBase(t *testing.T) {
+	sch, err := schema.Parse([]byte(`{"type": "object", "required": ["id"]}`))
+	require.NoError(t, err)
+
+	data := []byte("{\n  \"$envelope\": 1,\n  \"body\": {\n    \"name\": \"x\"\n  }\n}")
+	violations, err := sch.ValidateJSON(data, "/body")
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/body", violations[0].Pointer)
+	assert.Equal(t, 3, violations[0].Line)
+
+	_, err = sch.ValidateJSON([]byte(`{"$envelope": 1}`), "/body")
+	assert.Error(t, err)
+}
+
+// TestSchemaCombinators testa allOf, anyOf, oneOf, not e const
+func TestSchemaCombinators(t *testing.T) {
+	sch, err := schema.Parse([]byte(`{
+  "properties": {
+    "key": {"anyOf": [{"type": "string", "minLength": 11}, {"type": "integer"}]},
+    "kind": {"oneOf": [{"const": "cpf"}, {"const": "cnpj"}]},
+    "amount": {"allOf": [{"minimum": 1}, {"multipleOf": 0.01}]},
+    "note": {"not": {"type": "null"}},
+    "date": {"type": "string", "format": "date"}
+  }
+}`))
+	require.NoError(t, err)
+
+	violations := sch.Validate(map[string]interface{}{
+		"key":    "123",
+		"kind":   "email",
+		"amount": 0.005,
+		"note":   nil,
+		"date":   "2025-13-01",
+	})
+	messages := make(map[string]string)
+	for _, violation := range violations {
+		messages[violation.Pointer] += violation.Message + ";"
+	}
+	assert.Contains(t, messages["/key"], "anyOf")
+	assert.Contains(t, messages["/kind"], "atende a 0")
+	assert.Contains(t, messages["/amount"], "menor que o mínimo")
+	assert.Contains(t, messages["/amount"], "múltiplo")
+	assert.Contains(t, messages["/note"], "not")
+	assert.Contains(t, messages["/date"], "formato date")
+
+	assert.Empty(t, sch.Validate(map[string]interface{}{"key": float64(42), "kind": "cnpj", "amount": 12.34, "date": "2025-01-31"}))
+}
+
+// TestParseSchemaErrors testa a rejeição de schemas inválidos
+func TestParseSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		message string
+	}{
+		{"json inválido", `{"type": `, "schema inválido"},
+		{"tipo desconhecido", `{"type": "texto"}`, "tipo desconhecido 'texto'"},
+		{"ref inexistente", `{"$ref": "#/definitions/nada"}`, "não encontrada"},
+		{"ref externa", `{"$ref": "outro.json"}`, "apenas referências locais"},
+		{"pattern inválido", `{"properties": {"a": {"pattern": "("}}}`, "#/properties/a/pattern"},
+		{"minLength negativo", `{"minLength": -1}`, "inteiro não negativo"},
+		{"ciclo de ref", `{"definitions": {"a": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`, "ciclo de $ref"},
+		{"ciclo entre refs", `{"definitions": {"a": {"allOf": [{"$ref": "#/definitions/b"}]}, "b": {"not": {"$ref": "#/definitions/a"}}}, "$ref": "#/definitions/a"}`, "ciclo de $ref"},
+		{"ciclo na raiz", `{"anyOf": [{"$ref": "#"}]}`, "ciclo de $ref"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.Parse([]byte(tt.schema))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+// TestSchemaRecursiveRef testa que referências recursivas que descem no documento continuam válidas
+func TestSchemaRecursiveRef(t *testing.T) {
+	s, err := schema.Parse([]byte(`{
+		"definitions": {"node": {"type": "object", "properties": {"child": {"$ref": "#/definitions/node"}, "id": {"type": "integer"}}}},
+		"$ref": "#/definitions/node"
+	}`))
+	require.NoError(t, err)
+
+	violations := s.Validate(map[string]interface{}{"child": map[string]interface{}{"child": map[string]interface{}{"id": "x"}}})
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/child/child/id", violations[0].Pointer)
+}
+
+// TestSchemaForEntity testa a busca do schema de uma fila na pasta de schemas
+func TestSchemaForEntity(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sbt.pix"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sbq.pix.json"), []byte(`{"type": "object"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sbt.pix", "sub.json"), []byte(`{"type": "array"}`), 0644))
+
+	sch, path, err := schema.ForEntity(dir, "sbq.pix")
+	require.NoError(t, err)
+	require.NotNil(t, sch)
+	assert.Equal(t, filepath.Join(dir, "sbq.pix.json"), path)
+
+	sch, _, err = schema.ForEntity(dir, "sbt.pix/sub")
+	require.NoError(t, err)
+	require.NotNil(t, sch)
+	assert.NotEmpty(t, sch.Validate(map[string]interface{}{}))
+
+	sch, path, err = schema.ForEntity(dir, "sbq.sem-schema")
+	require.NoError(t, err)
+	assert.Nil(t, sch)
+	assert.Empty(t, path)
+}
+
+// TestLocate testa o mapeamento de JSON pointers para linhas
+func TestLocate(t *testing.T) {
+	lines := schema.Locate([]byte(`{
+  "a": 1,
+  "b/c": {
+    "d": [
+      "x",
+      {"e": null}
+    ]
+  }
+}`))
+	assert.Equal(t, 1, lines[""])
+	assert.Equal(t, 2, lines["/a"])
+	assert.Equal(t, 3, lines["/b~1c"])
+	assert.Equal(t, 5, lines["/b~1c/d/0"])
+	assert.Equal(t, 6, lines["/b~1c/d/1/e"])
+}
+
+// TestValidateJSONPosition testa a linha e coluna de erros de sintaxe
+func TestValidateJSONPosition(t *testing.T) {
+	err := utils.ValidateJSON([]byte("{\n  \"a\": 1,\n  \"b\": x\n}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "linha 3, coluna 8")
+}
@@ -0,0 +1,675 @@
+package tests
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReceiver simula uma entidade com locks: mensagens recebidas ficam bloqueadas e não são
+// entregues de novo até serem completadas ou abandonadas
+type memoryReceiver struct {
+	messages  []*azservicebus.ReceivedMessage
+	locked    map[int64]bool
+	removed   map[int64]bool
+	abandoned map[int64]int
+	received  int
+}
+
+func newMemoryReceiver(count int) *memoryReceiver {
+	r := &memoryReceiver{locked: map[int64]bool{}, removed: map[int64]bool{}, abandoned: map[int64]int{}}
+	for i := 1; i <= count; i++ {
+		seq := int64(i)
+		r.messages = append(r.messages, &azservicebus.ReceivedMessage{
+			MessageID:             fmt.Sprintf("m%d", i),
+			SequenceNumber:        &seq,
+			Body:                  []byte(fmt.Sprintf(`{"index": %d}`, i)),
+			ApplicationProperties: map[string]interface{}{"par": i%2 == 0, "DeadLetterReason": "teste"},
+		})
+	}
+	return r
+}
+
+func (r *memoryReceiver) PeekMessages(ctx context.Context, maxMessageCount int, options *azservicebus.PeekMessagesOptions) ([]*azservicebus.ReceivedMessage, error) {
+	var page []*azservicebus.ReceivedMessage
+	for _, msg := range r.messages {
+		if len(page) < maxMessageCount && !r.removed[*msg.SequenceNumber] && *msg.SequenceNumber >= *options.FromSequenceNumber {
+			page = append(page, msg)
+		}
+	}
+	return page, nil
+}
+
+func (r *memoryReceiver) ReceiveMessages(ctx context.Context, maxMessages int, options *azservicebus.ReceiveMessagesOptions) ([]*azservicebus.ReceivedMessage, error) {
+	var page []*azservicebus.ReceivedMessage
+	for _, msg := range r.messages {
+		seq := *msg.SequenceNumber
+		scheduled := msg.State == azservicebus.MessageStateScheduled
+		if len(page) < maxMessages && !r.removed[seq] && !r.locked[seq] && !scheduled {
+			r.locked[seq] = true
+			page = append(page, msg)
+		}
+	}
+	r.received += len(page)
+	return page, nil
+}
+
+func (r *memoryReceiver) settle(message *azservicebus.ReceivedMessage) error {
+	if !r.locked[*message.SequenceNumber] {
+		return fmt.Errorf("mensagem %d não está bloqueada", *message.SequenceNumber)
+	}
+	delete(r.locked, *message.SequenceNumber)
+	return nil
+}
+
+func (r *memoryReceiver) CompleteMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.CompleteMessageOptions) error {
+	r.removed[*message.SequenceNumber] = true
+	return r.settle(message)
+}
+
+func (r *memoryReceiver) AbandonMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.AbandonMessageOptions) error {
+	r.abandoned[*message.SequenceNumber]++
+	return r.settle(message)
+}
+
+func (r *memoryReceiver) DeadLetterMessage(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.DeadLetterOptions) error {
+	return fmt.Errorf("não suportado")
+}
+
+func (r *memoryReceiver) RenewMessageLock(ctx context.Context, message *azservicebus.ReceivedMessage, options *azservicebus.RenewMessageLockOptions) error {
+	if !r.locked[*message.SequenceNumber] {
+		return fmt.Errorf("mensagem %d não está bloqueada", *message.SequenceNumber)
+	}
+	return nil
+}
+
+// memorySender guarda as mensagens enviadas
+type memorySender struct {
+	sent []*azservicebus.Message
+}
+
+func (s *memorySender) SendMessage(ctx context.Context, message *azservicebus.Message, options *azservicebus.SendMessageOptions) error {
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+// TestParseEntity testa a interpretação de filas e subscriptions
+func TestParseEntity(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    servicebus.Entity
+		target  string
+		wantErr bool
+	}{
+		{
+			name:   "fila",
+			input:  "sbq.pismo.all",
+			want:   servicebus.Entity{Queue: "sbq.pismo.all"},
+			target: "sbq.pismo.all",
+		},
+		{
+			name:   "subscription",
+			input:  "sbt.orion.core/subscription.orion.core",
+			want:   servicebus.Entity{Topic: "sbt.orion.core", Subscription: "subscription.orion.core"},
+			target: "sbt.orion.core",
+		},
+		{
+			name:    "vazio",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "subscription sem nome",
+			input:   "sbt.orion.core/",
+			wantErr: true,
+		},
+		{
+			name:    "muitos segmentos",
+			input:   "a/b/c",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity, err := servicebus.ParseEntity(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, entity)
+			assert.Equal(t, tt.target, entity.SendTarget())
+			assert.Equal(t, tt.input, entity.String())
+		})
+	}
+}
+
+// TestIsEnvelope testa a detecção do formato envelope
+func TestIsEnvelope(t *testing.T) {
+	assert.True(t, servicebus.IsEnvelope([]byte(`{"$envelope": 1, "body": {}}`)))
+	assert.True(t, servicebus.IsEnvelope([]byte("  \n{\"$envelope\": true, \"body\": 1}")))
+	assert.False(t, servicebus.IsEnvelope([]byte(`{"body": {"$envelope": 1}}`)))
+	assert.False(t, servicebus.IsEnvelope([]byte(`[{"$envelope": 1}]`)))
+	assert.False(t, servicebus.IsEnvelope([]byte(`{inválido`)))
+}
+
+// TestParseEnvelope testa a conversão de envelopes em mensagens
+func TestParseEnvelope(t *testing.T) {
+	data := []byte(`{
+		"$envelope": 1,
+		"messageId": "msg-1",
+		"subject": "transaction.created",
+		"to": "orion",
+		"replyTo": "sbq.orion.reply",
+		"replyToSessionId": "reply-1",
+		"sessionId": "pedido-1",
+		"partitionKey": "pedido-1",
+		"timeToLive": "10m",
+		"scheduledEnqueueTime": "2025-01-02T03:04:05Z",
+		"applicationProperties": {"eventType": "transaction-created", "version": 2},
+		"body": {"id": "123"}
+	}`)
+
+	message, err := servicebus.ParseEnvelope(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", message.MessageID)
+	assert.Equal(t, "application/json", message.ContentType)
+	assert.Equal(t, "transaction.created", message.Subject)
+	assert.Equal(t, "orion", message.To)
+	assert.Equal(t, "sbq.orion.reply", message.ReplyTo)
+	assert.Equal(t, "reply-1", message.ReplyToSessionID)
+	assert.Equal(t, "pedido-1", message.SessionID)
+	assert.Equal(t, "pedido-1", message.PartitionKey)
+	assert.Equal(t, 10*time.Minute, *message.TimeToLive)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), message.ScheduledEnqueueTime.UTC())
+	assert.Equal(t, map[string]interface{}{"eventType": "transaction-created", "version": float64(2)}, message.Properties)
+	assert.Equal(t, map[string]interface{}{"id": "123"}, message.Body)
+}
+
+// TestParseEnvelopeErrors testa envelopes inválidos
+func TestParseEnvelopeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "sem body", data: `{"$envelope": 1}`},
+		{name: "campo desconhecido", data: `{"$envelope": 1, "body": {}, "label": "x"}`},
+		{name: "timeToLive inválido", data: `{"$envelope": 1, "body": {}, "timeToLive": "dez minutos"}`},
+		{name: "scheduledEnqueueTime inválido", data: `{"$envelope": 1, "body": {}, "scheduledEnqueueTime": "amanhã"}`},
+		{name: "partitionKey diferente da sessão", data: `{"$envelope": 1, "body": {}, "sessionId": "a", "partitionKey": "b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := servicebus.ParseEnvelope([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestEncodeDecodeMessage testa o formato das linhas do export e o preparo para o import
+func TestEncodeDecodeMessage(t *testing.T) {
+	ttl := 10 * time.Minute
+	enqueued := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	scheduled := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	original := &servicebus.Message{
+		Body:                       map[string]interface{}{"id": "123", "valor": 150.25},
+		MessageID:                  "msg-1",
+		CorrelationID:              "corr-1",
+		ContentType:                "application/json",
+		Properties:                 map[string]interface{}{"eventType": "transaction-created", "version": int64(2), "taxa": 0.5},
+		EnqueuedTimeUtc:            &enqueued,
+		DeliveryCount:              3,
+		SequenceNumber:             42,
+		SessionID:                  "pedido-1",
+		Subject:                    "transaction.created",
+		TimeToLive:                 &ttl,
+		ScheduledEnqueueTime:       &scheduled,
+		DeadLetterReason:           "MaxDeliveryCountExceeded",
+		DeadLetterErrorDescription: "falhou 3 vezes",
+	}
+
+	line, err := servicebus.EncodeMessage(original)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(line), "\n")
+
+	decoded, err := servicebus.DecodeMessage(line)
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", decoded.MessageID)
+	assert.Equal(t, "corr-1", decoded.CorrelationID)
+	assert.Equal(t, "pedido-1", decoded.SessionID)
+	assert.Equal(t, "transaction.created", decoded.Subject)
+	assert.Equal(t, ttl, *decoded.TimeToLive)
+	assert.Equal(t, int64(42), decoded.SequenceNumber)
+	assert.Equal(t, "MaxDeliveryCountExceeded", decoded.DeadLetterReason)
+	assert.Equal(t, map[string]interface{}{"eventType": "transaction-created", "version": int64(2), "taxa": 0.5}, decoded.Properties)
+
+	body, err := json.Marshal(decoded.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": "123", "valor": 150.25}`, string(body))
+
+	decoded.PrepareForImport(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC))
+	assert.Zero(t, decoded.SequenceNumber)
+	assert.Nil(t, decoded.EnqueuedTimeUtc)
+	assert.Zero(t, decoded.DeliveryCount)
+	assert.Empty(t, decoded.DeadLetterReason)
+	assert.Empty(t, decoded.DeadLetterErrorDescription)
+	assert.Equal(t, scheduled, decoded.ScheduledEnqueueTime.UTC())
+	assert.Equal(t, "msg-1", decoded.MessageID)
+
+	// Agendamentos vencidos são enviados imediatamente
+	decoded.PrepareForImport(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, decoded.ScheduledEnqueueTime)
+
+	_, err = servicebus.DecodeMessage([]byte(`{"body": {}, "label": "x"}`))
+	assert.Error(t, err)
+}
+
+// TestEncodeDecodeMessageRawBody testa que o export preserva exatamente os bytes de bodies que não são JSON compacto
+func TestEncodeDecodeMessageRawBody(t *testing.T) {
+	bodies := map[string][]byte{
+		"texto sem content type": []byte("pagamento aprovado"),
+		"texto com aspas":        []byte(`"entre aspas"`),
+		"binário":                {0x00, 0xff, 0xfe, 0x80, '\n', 0x7f},
+		"json com espaços":       []byte("{\n  \"id\": 1\n}"),
+		"json compacto":          []byte(`{"id":9007199254740993,"html":"<b>&</b>"}`),
+		"vazio":                  {},
+	}
+
+	for name, raw := range bodies {
+		t.Run(name, func(t *testing.T) {
+			line, err := servicebus.EncodeMessage(&servicebus.Message{MessageID: "m1", RawBody: raw})
+			require.NoError(t, err)
+			assert.NotContains(t, string(line), "\n")
+
+			decoded, err := servicebus.DecodeMessage(line)
+			require.NoError(t, err)
+			assert.Equal(t, raw, decoded.RawBody)
+
+			again, err := servicebus.EncodeMessage(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, string(line), string(again))
+		})
+	}
+
+	// JSON compacto fica legível no arquivo; os demais bodies vão em base64
+	line, err := servicebus.EncodeMessage(&servicebus.Message{RawBody: []byte(`{"id":1}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body": {"id": 1}}`, string(line))
+	line, err = servicebus.EncodeMessage(&servicebus.Message{RawBody: []byte("texto")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body": "dGV4dG8=", "bodyEncoding": "base64"}`, string(line))
+
+	decoded, err := servicebus.DecodeMessage(line)
+	require.NoError(t, err)
+	assert.Equal(t, "texto", decoded.Body)
+
+	for _, input := range []string{
+		`{"body": "dGV4dG8=", "bodyEncoding": "gzip"}`,
+		`{"body": "não é base64", "bodyEncoding": "base64"}`,
+		`{"body": {}, "bodyEncoding": "base64"}`,
+		`{"messageId": "sem-body"}`,
+	} {
+		_, err := servicebus.DecodeMessage([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+// TestExportTimeToLive testa que o TTL usa a mesma duração Go nos envelopes e no export
+func TestExportTimeToLive(t *testing.T) {
+	envelope, err := servicebus.ParseEnvelope([]byte(`{"$envelope": 1, "body": {}, "timeToLive": "1h30m"}`))
+	require.NoError(t, err)
+
+	line, err := servicebus.EncodeMessage(envelope)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(line, &fields))
+	assert.Equal(t, "1h30m0s", fields["timeToLive"])
+
+	decoded, err := servicebus.DecodeMessage(line)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, *decoded.TimeToLive)
+
+	// O mesmo valor é aceito de volta em um envelope
+	_, err = servicebus.ParseEnvelope([]byte(`{"$envelope": 1, "body": {}, "timeToLive": "1h30m0s"}`))
+	require.NoError(t, err)
+
+	// As saídas JSON das mensagens (tail -o full, request -o json) usam o mesmo formato
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	fields = nil
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "1h30m0s", fields["timeToLive"])
+	assert.Equal(t, map[string]interface{}{}, fields["body"])
+
+	data, err = json.Marshal(&servicebus.Message{Body: "x"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body": "x"}`, string(data))
+
+	for _, input := range []string{`{"body": {}, "timeToLive": 600000000000}`, `{"body": {}, "timeToLive": "-1m"}`} {
+		_, err := servicebus.DecodeMessage([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+// TestNewClientOptions testa a criação do cliente com opções, sem conectar ao Service Bus
+func TestNewClientOptions(t *testing.T) {
+	client, err := servicebus.NewClient()
+	assert.NoError(t, err)
+	assert.NoError(t, client.Close(context.Background()))
+
+	client, err = servicebus.NewClient(
+		servicebus.WithConnectionString(servicebus.DefaultConnectionString),
+		servicebus.WithTimeouts(servicebus.Timeouts{Send: time.Second}),
+		servicebus.WithRetryOptions(azservicebus.RetryOptions{MaxRetries: 1}),
+		servicebus.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}),
+		servicebus.WithLogger(log.New(io.Discard, "", 0)),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Close(context.Background()))
+
+	_, err = servicebus.NewClient(servicebus.WithConnectionString("connection string inválida"))
+	assert.Error(t, err)
+}
+
+// TestTransferValidation testa as validações de move e copy feitas antes de conectar
+func TestTransferValidation(t *testing.T) {
+	client, err := servicebus.NewClient()
+	assert.NoError(t, err)
+	defer func() { _ = client.Close(context.Background()) }()
+
+	ctx := context.Background()
+	queue := servicebus.Entity{Queue: "sbq.pismo.all"}
+	subscription := servicebus.Entity{Topic: "sbt.orion.core", Subscription: "subscription.orion.core"}
+
+	_, err = client.MoveMessages(ctx, queue, "sbq.pismo.all", servicebus.TransferOptions{})
+	assert.ErrorContains(t, err, "mesma entidade")
+
+	_, err = client.CopyMessages(ctx, subscription, "sbt.orion.core", servicebus.TransferOptions{})
+	assert.ErrorContains(t, err, "mesma entidade")
+
+	_, err = client.CopyMessages(ctx, queue, "sbq.pismo.transaction.creation", servicebus.TransferOptions{MaxMessages: -1})
+	assert.Error(t, err)
+}
+
+// TestDefaultTimeouts testa os timeouts padrão do cliente
+func TestDefaultTimeouts(t *testing.T) {
+	timeouts := servicebus.DefaultTimeouts()
+	assert.Equal(t, 30*time.Second, timeouts.Send)
+	assert.Equal(t, 30*time.Second, timeouts.Receive)
+	assert.Equal(t, 5*time.Second, timeouts.Drain)
+	assert.Equal(t, 30*time.Second, timeouts.Operation)
+	assert.Equal(t, 30*time.Second, timeouts.Close)
+}
+
+// TestSetDevelopmentEmulator testa a troca entre AMQP (5672) e AMQPS (5671) na connection string
+func TestSetDevelopmentEmulator(t *testing.T) {
+	base := "Endpoint=sb://localhost;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=KEY"
+
+	assert.Equal(t, base+";UseDevelopmentEmulator=true", servicebus.SetDevelopmentEmulator(base, true))
+	assert.Equal(t, base, servicebus.SetDevelopmentEmulator(base, false))
+	assert.Equal(t, base, servicebus.SetDevelopmentEmulator(base+";UseDevelopmentEmulator=true;", false))
+	assert.Equal(t, base+";UseDevelopmentEmulator=true", servicebus.SetDevelopmentEmulator(base+";usedevelopmentemulator=false", true))
+}
+
+// TestParseSettleMode testa a escolha do modo de liquidação pelas flags de leitura
+func TestParseSettleMode(t *testing.T) {
+	tests := []struct {
+		name                                string
+		peek, complete, abandon, deadLetter bool
+		want                                servicebus.SettleMode
+		wantErr                             string
+	}{
+		{name: "padrão", peek: true, want: servicebus.SettlePeek},
+		{name: "complete", peek: true, complete: true, want: servicebus.SettleComplete},
+		{name: "abandon", peek: true, abandon: true, want: servicebus.SettleAbandon},
+		{name: "dead-letter", peek: true, deadLetter: true, want: servicebus.SettleDeadLetter},
+		{name: "peek=false com complete", complete: true, want: servicebus.SettleComplete},
+		{name: "peek=false sozinho", wantErr: "--peek=false"},
+		{name: "dois modos", peek: true, complete: true, abandon: true, wantErr: "apenas um modo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := servicebus.ParseSettleMode(tt.peek, tt.complete, tt.abandon, tt.deadLetter)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
+
+// TestPaging testa a validação da paginação e o cálculo da próxima página de peek
+func TestPaging(t *testing.T) {
+	assert.NoError(t, servicebus.CheckPaging(servicebus.SettlePeek, 10, 0))
+	assert.NoError(t, servicebus.CheckPaging(servicebus.SettlePeek, 10, 42))
+	assert.NoError(t, servicebus.CheckPaging(servicebus.SettleComplete, 10, 0))
+	assert.ErrorContains(t, servicebus.CheckPaging(servicebus.SettlePeek, 0, 0), "--max")
+	assert.ErrorContains(t, servicebus.CheckPaging(servicebus.SettlePeek, 10, -1), "--from-sequence")
+	assert.ErrorContains(t, servicebus.CheckPaging(servicebus.SettleComplete, 10, 42), "--peek")
+
+	page := []*servicebus.Message{{SequenceNumber: 7}, {SequenceNumber: 9}}
+	next, ok := servicebus.NextPageSequence(page, 2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), next)
+
+	// Página incompleta ou vazia: não há próxima página
+	_, ok = servicebus.NextPageSequence(page, 3)
+	assert.False(t, ok)
+	_, ok = servicebus.NextPageSequence(nil, 10)
+	assert.False(t, ok)
+}
+
+// TestResubmitFrom testa o reenvio de mensagens escolhidas por sequence number fora da primeira página
+func TestResubmitFrom(t *testing.T) {
+	client, err := servicebus.NewClient()
+	require.NoError(t, err)
+
+	receiver := newMemoryReceiver(250)
+	sender := &memorySender{}
+	result, err := client.ResubmitFrom(context.Background(), receiver, sender, servicebus.ResubmitOptions{
+		SequenceNumbers: []int64{150, 240},
+		Properties:      map[string]interface{}{"reenviada": true},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, int64(150), result[0].SequenceNumber)
+	assert.Equal(t, int64(240), result[1].SequenceNumber)
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, true, sender.sent[0].ApplicationProperties["reenviada"])
+	assert.NotContains(t, sender.sent[0].ApplicationProperties, "DeadLetterReason")
+	assert.True(t, receiver.removed[150])
+	assert.True(t, receiver.removed[240])
+	assert.Len(t, receiver.removed, 2)
+
+	// As mensagens antes da última escolhida voltam uma única vez; as seguintes nem são recebidas
+	assert.Empty(t, receiver.locked)
+	assert.Len(t, receiver.abandoned, 238)
+	for seq, count := range receiver.abandoned {
+		assert.Equal(t, 1, count, seq)
+		assert.Less(t, seq, int64(240))
+	}
+	assert.Equal(t, 240, receiver.received)
+
+	// Sem --sequence, --max limita o reenvio às primeiras mensagens
+	receiver = newMemoryReceiver(5)
+	result, err = client.ResubmitFrom(context.Background(), receiver, &memorySender{}, servicebus.ResubmitOptions{MaxMessages: 3})
+	require.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Empty(t, receiver.abandoned)
+	assert.Equal(t, 3, receiver.received)
+
+	// Sequence number inexistente: nada é recebido
+	receiver = newMemoryReceiver(5)
+	result, err = client.ResubmitFrom(context.Background(), receiver, &memorySender{}, servicebus.ResubmitOptions{SequenceNumbers: []int64{99}})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Zero(t, receiver.received)
+}
+
+// TestEntityStatsAddActive testa a contagem de ativas e agendadas e a mensagem mais antiga e mais recente
+func TestEntityStatsAddActive(t *testing.T) {
+	base := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+	message := func(seq int64, id string, enqueued time.Time, state azservicebus.MessageState) *azservicebus.ReceivedMessage {
+		return &azservicebus.ReceivedMessage{MessageID: id, SequenceNumber: &seq, EnqueuedTime: &enqueued, State: state}
+	}
+
+	stats := &servicebus.EntityStats{}
+	stats.AddActive(message(3, "c", base.Add(time.Minute), azservicebus.MessageStateActive))
+	stats.AddActive(message(1, "a", base, azservicebus.MessageStateActive))
+	stats.AddActive(message(9, "agendada", base.Add(-time.Hour), azservicebus.MessageStateScheduled))
+	stats.AddActive(message(5, "e", base.Add(2*time.Minute), azservicebus.MessageStateActive))
+	stats.AddActive(&azservicebus.ReceivedMessage{MessageID: "sem-seq"})
+
+	assert.Equal(t, 4, stats.ActiveCount)
+	assert.Equal(t, 1, stats.ScheduledCount)
+	require.NotNil(t, stats.OldestEnqueuedTime)
+	// A mensagem agendada não conta como a mais antiga nem como a última
+	assert.True(t, base.Equal(*stats.OldestEnqueuedTime))
+	assert.Equal(t, int64(5), stats.LastSequenceNumber)
+	assert.Equal(t, "e", stats.LastMessageID)
+}
+
+// TestStatsFromTruncated testa que apenas entidades com mais mensagens que o limite são marcadas como truncadas
+func TestStatsFromTruncated(t *testing.T) {
+	client, err := servicebus.NewClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	stats, err := client.StatsFrom(ctx, "fila", newMemoryReceiver(5), newMemoryReceiver(2), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.ActiveCount)
+	assert.Equal(t, 2, stats.DeadLetterCount)
+	assert.False(t, stats.Truncated)
+
+	stats, err = client.StatsFrom(ctx, "fila", newMemoryReceiver(6), newMemoryReceiver(0), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.ActiveCount)
+	assert.True(t, stats.Truncated)
+
+	stats, err = client.StatsFrom(ctx, "fila", nil, newMemoryReceiver(150), 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.ActiveCount)
+	assert.Equal(t, 100, stats.DeadLetterCount)
+	assert.True(t, stats.Truncated)
+}
+
+// TestEntityStatsJSON testa o formato JSON das estatísticas, usado na saída do check-messages
+func TestEntityStatsJSON(t *testing.T) {
+	oldest := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+	stats := []servicebus.EntityStats{
+		{
+			Entity: "sbq.pismo.all", ActiveCount: 2, ScheduledCount: 1, DeadLetterCount: 3,
+			OldestEnqueuedTime: &oldest, LastMessageID: "m2", LastSequenceNumber: 7, Truncated: true,
+		},
+		{Entity: "sbq.vazia"},
+	}
+
+	data, err := json.Marshal(stats)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"entity": "sbq.pismo.all", "activeCount": 2, "scheduledCount": 1, "deadLetterCount": 3,
+		 "oldestEnqueuedTime": "2025-01-31T10:00:00Z", "lastMessageId": "m2", "lastSequenceNumber": 7, "truncated": true},
+		{"entity": "sbq.vazia", "activeCount": 0, "scheduledCount": 0, "deadLetterCount": 0}
+	]`, string(data))
+}
+
+// TestMoveFrom testa que as mensagens ignoradas pelo filtro são contadas e abandonadas uma única vez
+func TestMoveFrom(t *testing.T) {
+	client, err := servicebus.NewClient()
+	require.NoError(t, err)
+
+	receiver := newMemoryReceiver(250)
+	// Uma mensagem agendada não pode ser recebida e fica na origem sem ser contada
+	receiver.messages[9].State = azservicebus.MessageStateScheduled
+	sender := &memorySender{}
+	options := servicebus.TransferOptions{
+		MaxMessages: 30,
+		Match:       func(message *servicebus.Message) bool { return message.Properties["par"] == true },
+	}
+
+	result, err := client.MoveFrom(context.Background(), receiver, sender, options)
+	require.NoError(t, err)
+
+	require.Len(t, result.Transferred, 30)
+	assert.Equal(t, int64(2), result.Transferred[0].SequenceNumber)
+	assert.Equal(t, int64(62), result.Transferred[29].SequenceNumber)
+	assert.Len(t, sender.sent, 30)
+	assert.Len(t, receiver.removed, 30)
+
+	// As ímpares até a 61 foram lidas uma vez e devolvidas uma única vez
+	assert.Equal(t, 31, result.Skipped)
+	assert.Len(t, receiver.abandoned, 31)
+	for seq, count := range receiver.abandoned {
+		assert.Equal(t, 1, count, seq)
+		assert.Equal(t, int64(1), seq%2)
+	}
+	assert.Empty(t, receiver.locked)
+	assert.Equal(t, 61, receiver.received)
+
+	// Sem limite, todas as pares são movidas e a agendada permanece
+	receiver = newMemoryReceiver(10)
+	receiver.messages[9].State = azservicebus.MessageStateScheduled
+	result, err = client.MoveFrom(context.Background(), receiver, &memorySender{}, servicebus.TransferOptions{Match: options.Match})
+	require.NoError(t, err)
+	assert.Len(t, result.Transferred, 4)
+	assert.Equal(t, 5, result.Skipped)
+	assert.False(t, receiver.removed[10])
+}
+
+// TestTailFrom testa o tail com liquidação: cada mensagem é mostrada uma vez e devolvida logo após
+func TestTailFrom(t *testing.T) {
+	client, err := servicebus.NewClient()
+	require.NoError(t, err)
+
+	receiver := newMemoryReceiver(5)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var shown []int64
+	options := servicebus.TailOptions{Mode: servicebus.SettleAbandon, PollInterval: 5 * time.Millisecond}
+	err = client.TailFrom(ctx, receiver, options, func(message *servicebus.Message) {
+		shown = append(shown, message.SequenceNumber)
+	})
+	require.NoError(t, err)
+
+	// As entregas repetidas são devolvidas sem serem mostradas, e nenhum lock fica retido
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, shown)
+	assert.Empty(t, receiver.locked)
+	assert.Empty(t, receiver.removed)
+	for seq := int64(1); seq <= 5; seq++ {
+		assert.Greater(t, receiver.abandoned[seq], 1, seq)
+	}
+
+	// No modo complete as mensagens são removidas
+	receiver = newMemoryReceiver(5)
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	shown = nil
+	options.Mode = servicebus.SettleComplete
+	err = client.TailFrom(ctx, receiver, options, func(message *servicebus.Message) {
+		shown = append(shown, message.SequenceNumber)
+	})
+	require.NoError(t, err)
+	assert.Len(t, shown, 5)
+	assert.Len(t, receiver.removed, 5)
+	assert.Empty(t, receiver.abandoned)
+}
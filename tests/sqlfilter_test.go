@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"testing"
+
+	"fin.orion.dev/internal/sqlfilter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSQLFilterMatch testa a avaliação de filtros SQL sobre propriedades de sistema e de aplicação
+func TestSQLFilterMatch(t *testing.T) {
+	message := &sqlfilter.Message{
+		System: map[string]interface{}{
+			"MessageId": "msg-1",
+			"Label":     "pix",
+		},
+		Properties: map[string]interface{}{
+			"tipo":    "pix",
+			"valor":   float64(150),
+			"urgente": true,
+			"origem":  "pismo-api",
+		},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"1=1", true},
+		{"1=0", false},
+		{"tipo = 'pix'", true},
+		{"user.tipo = 'pix'", true},
+		{"TIPO = 'pix'", true},
+		{"tipo <> 'pix'", false},
+		{"valor > 100 AND valor <= 150", true},
+		{"valor * 2 = 300", true},
+		{"valor / 4 = 37.5", true},
+		{"-valor < 0", true},
+		{"sys.Label = 'pix'", true},
+		{"sys.Subject = 'pix'", true},
+		{"sys.MessageId LIKE 'msg-%'", true},
+		{"origem LIKE 'pismo\\_%' ESCAPE '\\'", false},
+		{"origem LIKE 'pismo_api'", true},
+		{"origem NOT LIKE 'core%'", true},
+		{"tipo IN ('ted', 'pix')", true},
+		{"tipo NOT IN ('ted', 'pix')", false},
+		{"urgente = TRUE", true},
+		{"EXISTS(valor)", true},
+		{"EXISTS(inexistente)", false},
+		{"inexistente IS NULL", true},
+		{"tipo IS NOT NULL", true},
+		{"inexistente = 'x'", false},
+		{"NOT (inexistente = 'x')", false},
+		{"inexistente = 'x' OR tipo = 'pix'", true},
+		{"inexistente = 'x' AND tipo = 'ted'", false},
+		{"valor = 'texto'", false},
+		{"(tipo = 'ted' OR tipo = 'pix') AND NOT urgente = FALSE", true},
+		{"sys.CorrelationId = 'x'", false},
+		{"tipo + '-' + origem = 'pix-pismo-api'", true},
+		{"[tipo] = 'pix'", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			filter, err := sqlfilter.Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Match(message))
+		})
+	}
+}
+
+// TestSQLFilterParseErrors testa as mensagens de erro de expressões inválidas
+func TestSQLFilterParseErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"tipo = ",
+		"tipo = = 1",
+		"tipo = 'pix",
+		"(tipo = 'pix'",
+		"tipo IN ()",
+		"tipo LIKE 10",
+		"sys.Inexistente = 1",
+		"tipo = 'pix' extra",
+		"tipo # 1",
+	} {
+		_, err := sqlfilter.Parse(expression)
+		assert.Error(t, err, expression)
+	}
+
+	_, err := sqlfilter.Parse("tipo = = 1")
+	assert.ErrorContains(t, err, "posição 8")
+}
+
+// TestSQLFilterAction testa as ações SET e REMOVE
+func TestSQLFilterAction(t *testing.T) {
+	message := &sqlfilter.Message{
+		System:     map[string]interface{}{"Label": "pix"},
+		Properties: map[string]interface{}{"valor": 100, "temporario": "x"},
+	}
+
+	action, err := sqlfilter.ParseAction("SET prioridade = 'alta'; SET valor = valor * 2; SET sys.Label = 'pix-alta'; REMOVE temporario")
+	require.NoError(t, err)
+	action.Apply(message)
+
+	assert.Equal(t, "alta", message.Properties["prioridade"])
+	assert.Equal(t, int64(200), message.Properties["valor"])
+	assert.Equal(t, "pix-alta", message.System["Label"])
+	assert.NotContains(t, message.Properties, "temporario")
+
+	for _, expression := range []string{"", "prioridade = 'alta'", "SET prioridade", "SET a = 1 SET b = 2", "REMOVE"} {
+		_, err := sqlfilter.ParseAction(expression)
+		assert.Error(t, err, expression)
+	}
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fin.orion.dev/internal/servicebus"
+	"fin.orion.dev/internal/traffic"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replaySender guarda os envios do replay com o instante de cada um
+type replaySender struct {
+	mu      sync.Mutex
+	started time.Time
+	targets []string
+	sent    []*servicebus.Message
+	offsets []time.Duration
+	fail    string
+}
+
+func (s *replaySender) Send(ctx context.Context, queueOrTopic string, message *servicebus.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets = append(s.targets, queueOrTopic)
+	s.sent = append(s.sent, message)
+	s.offsets = append(s.offsets, time.Since(s.started))
+	if message.MessageID == s.fail {
+		return fmt.Errorf("destino indisponível")
+	}
+	return nil
+}
+
+// TestRecordAndRead testa a gravação com horários relativos e a leitura ordenada
+func TestRecordAndRead(t *testing.T) {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(ms int) *time.Time {
+		value := started.Add(time.Duration(ms) * time.Millisecond)
+		return &value
+	}
+
+	var out bytes.Buffer
+	recorder := traffic.NewRecorder(&out, started)
+	queue := servicebus.Entity{Queue: "sbq.pismo.transaction.creation"}
+	subA := servicebus.Entity{Topic: "sbt.orion.core", Subscription: "a"}
+	subB := servicebus.Entity{Topic: "sbt.orion.core", Subscription: "b"}
+
+	record, err := recorder.Add(queue, &servicebus.Message{MessageID: "transacao", SequenceNumber: 7, EnqueuedTimeUtc: at(900), Body: map[string]interface{}{"id": "t1"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), record.OffsetMs)
+
+	_, err = recorder.Add(subA, &servicebus.Message{MessageID: "autorizacao", SequenceNumber: 3, EnqueuedTimeUtc: at(100), Body: "a", Properties: map[string]interface{}{"tentativa": int64(1)}})
+	require.NoError(t, err)
+
+	// A mesma mensagem vista em outra subscription do tópico não é gravada de novo
+	record, err = recorder.Add(subB, &servicebus.Message{MessageID: "autorizacao", SequenceNumber: 3, EnqueuedTimeUtc: at(100), Body: "a"})
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	_, err = recorder.Add(subB, &servicebus.Message{MessageID: "cancelamento", SequenceNumber: 4, EnqueuedTimeUtc: at(400), Body: "c"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, recorder.Count())
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+
+	records, err := traffic.ReadRecords(&out)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"autorizacao", "cancelamento", "transacao"}, []string{records[0].Message.MessageID, records[1].Message.MessageID, records[2].Message.MessageID})
+	assert.Equal(t, "sbt.orion.core/a", records[0].Entity)
+	assert.Equal(t, int64(1), records[0].Message.Properties["tentativa"])
+	assert.Equal(t, 400*time.Millisecond, records[1].Offset())
+}
+
+// TestReadRecordsErrors testa a rejeição de linhas inválidas
+func TestReadRecordsErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"offsetMs": 1, "entity": "sbq.a"}`:          "linha 1: campos",
+		`{"entity": "sbq.a", "message": {"body": 1}}`: "linha 1: campos",
+		"\n{nao e json}": "linha 2",
+		`{"offsetMs": 1, "entity": "sbq.a", "message": {"desconhecido": 1}}`: "linha 1",
+	}
+	for input, message := range tests {
+		_, err := traffic.ReadRecords(strings.NewReader(input))
+		require.Error(t, err, input)
+		assert.Contains(t, err.Error(), message, input)
+	}
+}
+
+// TestParseSpeed testa a interpretação da velocidade do replay
+func TestParseSpeed(t *testing.T) {
+	for input, expected := range map[string]float64{"2x": 2, "0.5x": 0.5, "1": 1, " 10X ": 10, "max": 0} {
+		speed, err := traffic.ParseSpeed(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, speed, input)
+	}
+	for _, input := range []string{"", "0x", "-2x", "rápido"} {
+		_, err := traffic.ParseSpeed(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestReplay testa a ordem, o espaçamento com velocidade, a troca de destinos e novos IDs
+func TestReplay(t *testing.T) {
+	records := []traffic.Record{
+		{OffsetMs: 1000, Entity: "sbt.orion.core/a", Message: &servicebus.Message{MessageID: "autorizacao", SequenceNumber: 3, Body: "a"}},
+		{OffsetMs: 1200, Entity: "sbq.pismo.authorization.cancel", Message: &servicebus.Message{MessageID: "cancelamento", Body: "c"}},
+		{OffsetMs: 1400, Entity: "sbq.pismo.transaction.creation", Message: &servicebus.Message{MessageID: "transacao", Body: "t"}},
+	}
+	options := traffic.ReplayOptions{
+		Speed: 2,
+		Map: map[string]string{
+			"sbt.orion.core":                 "sbt.orion.replay",
+			"sbq.pismo.authorization.cancel": "sbq.pismo.all",
+		},
+		NewIDs: true,
+	}
+
+	sender := &replaySender{started: time.Now()}
+	var sentIndexes []int
+	options.OnSend = func(index int, record traffic.Record, target string, err error) {
+		sentIndexes = append(sentIndexes, index)
+	}
+
+	result, err := traffic.Replay(context.Background(), sender, records, options)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Sent)
+	assert.Equal(t, []int{0, 1, 2}, sentIndexes)
+	assert.Equal(t, []string{"sbt.orion.replay", "sbq.pismo.all", "sbq.pismo.transaction.creation"}, sender.targets)
+
+	// Intervalos de 200ms a 2x viram 100ms; a primeira mensagem sai imediatamente
+	assert.Less(t, sender.offsets[0], 50*time.Millisecond)
+	assert.GreaterOrEqual(t, sender.offsets[1], 100*time.Millisecond)
+	assert.GreaterOrEqual(t, sender.offsets[2], 200*time.Millisecond)
+	assert.Less(t, sender.offsets[2], 400*time.Millisecond)
+
+	for i, message := range sender.sent {
+		assert.True(t, strings.HasPrefix(message.MessageID, "replay-"), message.MessageID)
+		assert.Zero(t, message.SequenceNumber)
+		assert.NotSame(t, records[i].Message, message)
+	}
+	assert.Equal(t, "autorizacao", records[0].Message.MessageID)
+}
+
+// TestReplayFailuresAndCancel testa falhas de envio e a interrupção do replay
+func TestReplayFailuresAndCancel(t *testing.T) {
+	records := []traffic.Record{
+		{OffsetMs: 0, Entity: "sbq.a", Message: &servicebus.Message{MessageID: "m1"}},
+		{OffsetMs: 10, Entity: "sbq.a", Message: &servicebus.Message{MessageID: "m2"}},
+		{OffsetMs: 60000, Entity: "sbq.a", Message: &servicebus.Message{MessageID: "m3"}},
+	}
+
+	sender := &replaySender{started: time.Now(), fail: "m2"}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	result, err := traffic.Replay(ctx, sender, records, traffic.ReplayOptions{Speed: 1})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, result.Sent)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []string{"sbq.a", "sbq.a"}, sender.targets)
+
+	// Sem esperas, tudo é enviado imediatamente
+	sender = &replaySender{started: time.Now()}
+	result, err = traffic.Replay(context.Background(), sender, records, traffic.ReplayOptions{Speed: 0})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Sent)
+	assert.Less(t, sender.offsets[2], 50*time.Millisecond)
+}
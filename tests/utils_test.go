@@ -2,9 +2,12 @@ package tests
 
 import (
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
+	"fin.orion.dev/internal/utils"
+
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -322,3 +325,16 @@ func (m *mockFileInfo) Mode() os.FileMode  { return m.mode }
 func (m *mockFileInfo) IsDir() bool        { return m.isDir }
 func (m *mockFileInfo) ModTime() time.Time { return m.modTime }
 func (m *mockFileInfo) Sys() interface{}   { return nil }
+
+// TestNewUUID testa o formato e a unicidade dos UUIDs gerados
+func TestNewUUID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := utils.NewUUID()
+		assert.Regexp(t, pattern, id)
+		assert.False(t, seen[id], "UUID repetido: %s", id)
+		seen[id] = true
+	}
+}